@@ -0,0 +1,34 @@
+package events
+
+import "time"
+
+// Backoff controls how a failed publish is retried. Attempts counts the
+// first try, so Attempts == 1 disables retries.
+type Backoff struct {
+	Attempts   int
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+var DefaultBackoff = Backoff{
+	Attempts:   3,
+	Initial:    250 * time.Millisecond,
+	Max:        2 * time.Second,
+	Multiplier: 2,
+}
+
+// Delay returns the pause before retry number attempt, starting from 1.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+	delay := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		delay *= b.Multiplier
+		if b.Max > 0 && delay >= float64(b.Max) {
+			return b.Max
+		}
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Attempts: 5, Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 3}
+
+	testCases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 0},
+		{1, 100 * time.Millisecond},
+		{2, 300 * time.Millisecond},
+		{3, 900 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tc := range testCases {
+		if got := b.Delay(tc.attempt); got != tc.want {
+			t.Errorf("Delay(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+)
+
+const (
+	// SchemaVersion is the envelope schema version written by this code.
+	SchemaVersion uint32 = 1
+
+	HeaderContentType   = "content-type"
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+var ErrUnsupportedSchema = errors.New("unsupported schema version")
+
+var (
+	jsonMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// NewEnvelope wraps payload, which must be one of the Envelope payload
+// messages, with a fresh event ID and the current schema version.
+func NewEnvelope(payload proto.Message, occurredAt time.Time) (*eventspb.Envelope, error) {
+	env := &eventspb.Envelope{}
+	m := env.ProtoReflect()
+	name := payload.ProtoReflect().Descriptor().FullName()
+
+	fields := m.Descriptor().Oneofs().ByName("payload").Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Message().FullName() != name {
+			continue
+		}
+		m.Set(fd, protoreflect.ValueOfMessage(payload.ProtoReflect()))
+		env.EventId = uuid.NewString()
+		env.EventType = string(fd.Name())
+		env.SchemaVersion = SchemaVersion
+		env.OccurredAt = timestamppb.New(occurredAt)
+		return env, nil
+	}
+	return nil, fmt.Errorf("%s is not an envelope payload", name)
+}
+
+// Codec encodes envelopes in one wire format and labels the resulting
+// messages with the matching content-type header.
+type Codec struct {
+	contentType string
+}
+
+// NewCodec accepts "json" (also the default for an empty string) or
+// "protobuf".
+func NewCodec(encoding string) (Codec, error) {
+	switch encoding {
+	case "", "json":
+		return Codec{contentType: ContentTypeJSON}, nil
+	case "protobuf":
+		return Codec{contentType: ContentTypeProtobuf}, nil
+	default:
+		return Codec{}, fmt.Errorf("unknown event encoding %q, expected json or protobuf", encoding)
+	}
+}
+
+func (c Codec) ContentType() string {
+	return c.contentType
+}
+
+func (c Codec) Encode(env *eventspb.Envelope) ([]byte, error) {
+	if c.contentType == ContentTypeProtobuf {
+		return proto.Marshal(env)
+	}
+	return jsonMarshal.Marshal(env)
+}
+
+// Message encodes env into a message for topic.
+func (c Codec) Message(topic, key string, env *eventspb.Envelope) (Message, error) {
+	value, err := c.Encode(env)
+	if err != nil {
+		return Message{}, fmt.Errorf("could not encode %s event: %w", env.GetEventType(), err)
+	}
+	return Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: map[string]string{HeaderContentType: c.contentType},
+	}, nil
+}
+
+// Decode parses an envelope in the format named by contentType. Envelopes
+// with a newer schema version fail with ErrUnsupportedSchema.
+func Decode(value []byte, contentType string) (*eventspb.Envelope, error) {
+	env := &eventspb.Envelope{}
+	var err error
+	switch contentType {
+	case ContentTypeJSON:
+		err = jsonUnmarshal.Unmarshal(value, env)
+	case ContentTypeProtobuf:
+		err = proto.Unmarshal(value, env)
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope: %w", err)
+	}
+	if env.GetSchemaVersion() > SchemaVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedSchema, env.GetSchemaVersion())
+	}
+	if env.GetPayload() == nil {
+		return nil, fmt.Errorf("envelope %s has no payload", env.GetEventId())
+	}
+	return env, nil
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	occurredAt := time.Date(2025, 4, 20, 12, 30, 0, 0, time.UTC)
+	env, err := NewEnvelope(&eventspb.PostLiked{UserId: "u", PostId: "p"}, occurredAt)
+	if err != nil {
+		t.Fatalf("NewEnvelope returned error: %v", err)
+	}
+	if env.GetEventType() != "post_liked" || env.GetSchemaVersion() != SchemaVersion || env.GetEventId() == "" {
+		t.Errorf("unexpected envelope header: %v", env)
+	}
+	if !env.GetOccurredAt().AsTime().Equal(occurredAt) {
+		t.Errorf("OccurredAt = %v, want %v", env.GetOccurredAt().AsTime(), occurredAt)
+	}
+	if env.GetPostLiked().GetPostId() != "p" {
+		t.Errorf("payload not set: %v", env)
+	}
+
+	if _, err := NewEnvelope(&eventspb.PostSnapshot{}, occurredAt); err == nil {
+		t.Error("NewEnvelope accepted a message that is not an envelope payload")
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	env, err := NewEnvelope(&eventspb.PostCreated{Post: &eventspb.PostSnapshot{PostId: "p", UserId: "u"}}, time.Now())
+	if err != nil {
+		t.Fatalf("NewEnvelope returned error: %v", err)
+	}
+
+	for _, encoding := range []string{"json", "protobuf"} {
+		t.Run(encoding, func(t *testing.T) {
+			codec, err := NewCodec(encoding)
+			if err != nil {
+				t.Fatalf("NewCodec returned error: %v", err)
+			}
+			msg, err := codec.Message("post-lifecycle", "p", env)
+			if err != nil {
+				t.Fatalf("Message returned error: %v", err)
+			}
+			if msg.Headers[HeaderContentType] != codec.ContentType() {
+				t.Errorf("content-type header = %q, want %q", msg.Headers[HeaderContentType], codec.ContentType())
+			}
+
+			decoded, err := Decode(msg.Value, msg.Headers[HeaderContentType])
+			if err != nil {
+				t.Fatalf("Decode returned error: %v", err)
+			}
+			if !proto.Equal(decoded, env) {
+				t.Errorf("decoded %v, want %v", decoded, env)
+			}
+		})
+	}
+
+	if _, err := NewCodec("xml"); err == nil {
+		t.Error("NewCodec accepted an unknown encoding")
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	json := ContentTypeJSON
+	testCases := []struct {
+		name        string
+		value       string
+		contentType string
+		wantErr     error
+	}{
+		{"unknown content type", `{}`, "text/plain", nil},
+		{"not json", `nope`, json, nil},
+		{"no payload", `{"event_id":"1","schema_version":1}`, json, nil},
+		{"newer schema", `{"event_id":"1","schema_version":99,"post_viewed":{"post_id":"p"}}`, json, ErrUnsupportedSchema},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode([]byte(tc.value), tc.contentType)
+			if err == nil {
+				t.Fatal("Decode expected error, got nil")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("Decode error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	if _, err := Decode([]byte(`{"event_id":"1","schema_version":1,"post_viewed":{"post_id":"p"},"added_later":true}`), json); err != nil {
+		t.Errorf("Decode must ignore unknown fields, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Package events publishes domain events to the message broker. Services
+// depend on the Publisher interface; KafkaPublisher is the production
+// implementation and MemoryPublisher is meant for tests.
+package events
+
+import "context"
+
+// Message is a broker-agnostic event ready to be published.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+type Publisher interface {
+	// Publish delivers msgs or returns an error once retries are exhausted.
+	Publish(ctx context.Context, msgs ...Message) error
+	Close() error
+}
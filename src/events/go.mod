@@ -0,0 +1,15 @@
+module github.com/zahartd/social-network/src/events
+
+go 1.24.0
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/segmentio/kafka-go v0.4.47
+	github.com/zahartd/social-network/src/gen/go v0.0.0-20250408164253-8dc6c5116635
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+)
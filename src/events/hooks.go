@@ -0,0 +1,32 @@
+package events
+
+import "time"
+
+// Hooks lets callers plug in metrics or logging without this package
+// depending on a particular library. Every field is optional.
+type Hooks struct {
+	// OnPublish is called after a successful publish of n messages.
+	OnPublish func(topic string, n int, took time.Duration)
+	// OnRetry is called before retry number attempt, starting from 1.
+	OnRetry func(topic string, attempt int, err error)
+	// OnError is called when a publish fails for good.
+	OnError func(topic string, err error)
+}
+
+func (h Hooks) published(topic string, n int, took time.Duration) {
+	if h.OnPublish != nil {
+		h.OnPublish(topic, n, took)
+	}
+}
+
+func (h Hooks) retrying(topic string, attempt int, err error) {
+	if h.OnRetry != nil {
+		h.OnRetry(topic, attempt, err)
+	}
+}
+
+func (h Hooks) failed(topic string, err error) {
+	if h.OnError != nil {
+		h.OnError(topic, err)
+	}
+}
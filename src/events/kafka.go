@@ -0,0 +1,181 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const defaultAttemptTimeout = 10 * time.Second
+
+// Writer is the part of *kafka.Writer used by KafkaPublisher.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type KafkaPublisher struct {
+	writer         Writer
+	backoff        Backoff
+	hooks          Hooks
+	attemptTimeout time.Duration
+}
+
+type Option func(*KafkaPublisher)
+
+func WithBackoff(b Backoff) Option {
+	return func(p *KafkaPublisher) { p.backoff = b }
+}
+
+func WithHooks(h Hooks) Option {
+	return func(p *KafkaPublisher) { p.hooks = h }
+}
+
+// WithAttemptTimeout bounds a single write attempt; retries get a fresh
+// timeout each.
+func WithAttemptTimeout(d time.Duration) Option {
+	return func(p *KafkaPublisher) { p.attemptTimeout = d }
+}
+
+// NewKafkaPublisher wraps writer, which should be synchronous: an async
+// kafka.Writer reports no errors, leaving nothing to retry.
+func NewKafkaPublisher(writer Writer, opts ...Option) *KafkaPublisher {
+	p := &KafkaPublisher{
+		writer:         writer,
+		backoff:        DefaultBackoff,
+		attemptTimeout: defaultAttemptTimeout,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Publish writes msgs as one batch. A batch may mix topics; hooks are
+// called once per topic with that topic's share of the batch.
+func (p *KafkaPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	topics := countByTopic(msgs)
+	kafkaMsgs := toKafkaMessages(msgs)
+
+	start := time.Now()
+	attempts := max(p.backoff.Attempts, 1)
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = p.write(ctx, kafkaMsgs); err == nil {
+			took := time.Since(start)
+			for _, t := range topics {
+				p.hooks.published(t.topic, t.n, took)
+			}
+			return nil
+		}
+		if attempt >= attempts || !retryable(err) {
+			break
+		}
+
+		for _, t := range topics {
+			p.hooks.retrying(t.topic, attempt, err)
+		}
+		if waitErr := sleep(ctx, p.backoff.Delay(attempt)); waitErr != nil {
+			err = waitErr
+			break
+		}
+	}
+
+	names := make([]string, 0, len(topics))
+	for _, t := range topics {
+		names = append(names, t.topic)
+	}
+	err = fmt.Errorf("failed to publish %d messages to %s: %w", len(msgs), strings.Join(names, ", "), err)
+	for _, t := range topics {
+		p.hooks.failed(t.topic, err)
+	}
+	return err
+}
+
+type topicCount struct {
+	topic string
+	n     int
+}
+
+// countByTopic returns the topics of msgs in order of first appearance,
+// each with the number of its messages.
+func countByTopic(msgs []Message) []topicCount {
+	var counts []topicCount
+	index := make(map[string]int)
+	for _, msg := range msgs {
+		i, ok := index[msg.Topic]
+		if !ok {
+			i = len(counts)
+			index[msg.Topic] = i
+			counts = append(counts, topicCount{topic: msg.Topic})
+		}
+		counts[i].n++
+	}
+	return counts
+}
+
+func (p *KafkaPublisher) write(ctx context.Context, msgs []kafka.Message) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, p.attemptTimeout)
+	defer cancel()
+	return p.writer.WriteMessages(attemptCtx, msgs...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// retryable reports whether err may go away on its own: a leader election,
+// an attempt timeout or any error Kafka marks as temporary.
+func retryable(err error) bool {
+	if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr.Temporary()
+	}
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, e := range writeErrs {
+			if e != nil && !retryable(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func toKafkaMessages(msgs []Message) []kafka.Message {
+	res := make([]kafka.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		km := kafka.Message{
+			Topic: msg.Topic,
+			Key:   []byte(msg.Key),
+			Value: msg.Value,
+		}
+		for k, v := range msg.Headers {
+			km.Headers = append(km.Headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		res = append(res, km)
+	}
+	return res
+}
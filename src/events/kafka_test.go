@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeWriter struct {
+	errs    []error
+	calls   int
+	written []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.calls++
+	if len(w.errs) > 0 {
+		err := w.errs[0]
+		w.errs = w.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+var fastBackoff = Backoff{Attempts: 3, Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 2}
+
+func TestKafkaPublisherRetries(t *testing.T) {
+	testCases := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"first try", nil, 1, false},
+		{"leader election", []error{kafka.LeaderNotAvailable}, 2, false},
+		{"attempt timeout", []error{context.DeadlineExceeded, context.DeadlineExceeded}, 3, false},
+		{"attempts exhausted", []error{kafka.LeaderNotAvailable, kafka.LeaderNotAvailable, kafka.LeaderNotAvailable}, 3, true},
+		{"permanent error", []error{kafka.MessageSizeTooLarge}, 1, true},
+		{"unknown error", []error{errors.New("boom")}, 1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &fakeWriter{errs: tc.errs}
+			var retries, failures, published int
+			p := NewKafkaPublisher(w, WithBackoff(fastBackoff), WithHooks(Hooks{
+				OnPublish: func(topic string, n int, took time.Duration) { published += n },
+				OnRetry:   func(topic string, attempt int, err error) { retries++ },
+				OnError:   func(topic string, err error) { failures++ },
+			}))
+
+			err := p.Publish(context.Background(), Message{Topic: "t", Key: "k", Value: []byte("v"), Headers: map[string]string{"h": "1"}})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Publish error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if w.calls != tc.wantCalls {
+				t.Errorf("writer called %d times, want %d", w.calls, tc.wantCalls)
+			}
+			if retries != tc.wantCalls-1 {
+				t.Errorf("OnRetry called %d times, want %d", retries, tc.wantCalls-1)
+			}
+			if tc.wantErr {
+				if failures != 1 || published != 0 {
+					t.Errorf("got %d failures and %d published, want 1 and 0", failures, published)
+				}
+				return
+			}
+			if failures != 0 || published != 1 {
+				t.Errorf("got %d failures and %d published, want 0 and 1", failures, published)
+			}
+			msg := w.written[0]
+			if msg.Topic != "t" || string(msg.Key) != "k" || string(msg.Value) != "v" {
+				t.Errorf("unexpected message written: %+v", msg)
+			}
+			if len(msg.Headers) != 1 || msg.Headers[0].Key != "h" || string(msg.Headers[0].Value) != "1" {
+				t.Errorf("unexpected headers written: %+v", msg.Headers)
+			}
+		})
+	}
+}
+
+func TestKafkaPublisherHooksPerTopic(t *testing.T) {
+	w := &fakeWriter{errs: []error{kafka.LeaderNotAvailable}}
+	published := map[string]int{}
+	retries := map[string]int{}
+	p := NewKafkaPublisher(w, WithBackoff(fastBackoff), WithHooks(Hooks{
+		OnPublish: func(topic string, n int, took time.Duration) { published[topic] += n },
+		OnRetry:   func(topic string, attempt int, err error) { retries[topic]++ },
+	}))
+
+	err := p.Publish(context.Background(), Message{Topic: "a"}, Message{Topic: "b"}, Message{Topic: "a"})
+	if err != nil {
+		t.Fatalf("Publish error = %v", err)
+	}
+	if published["a"] != 2 || published["b"] != 1 || len(published) != 2 {
+		t.Errorf("OnPublish counts = %v, want a:2 b:1", published)
+	}
+	if retries["a"] != 1 || retries["b"] != 1 || len(retries) != 2 {
+		t.Errorf("OnRetry counts = %v, want a:1 b:1", retries)
+	}
+}
+
+func TestKafkaPublisherStopsOnCancel(t *testing.T) {
+	w := &fakeWriter{errs: []error{kafka.LeaderNotAvailable, kafka.LeaderNotAvailable}}
+	p := NewKafkaPublisher(w, WithBackoff(Backoff{Attempts: 3, Initial: time.Hour, Multiplier: 1}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := p.Publish(ctx, Message{Topic: "t"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Publish error = %v, want context.Canceled", err)
+	}
+	if w.calls != 1 {
+		t.Errorf("writer called %d times, want 1", w.calls)
+	}
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher keeps published messages in memory. Set Err to make
+// Publish fail.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	Err      error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Err != nil {
+		return p.Err
+	}
+	p.messages = append(p.messages, msgs...)
+	return nil
+}
+
+// Messages returns the messages published to topic, or all of them if
+// topic is empty, in publish order.
+func (p *MemoryPublisher) Messages(topic string) []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := []Message{}
+	for _, msg := range p.messages {
+		if topic == "" || msg.Topic == topic {
+			res = append(res, msg)
+		}
+	}
+	return res
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryPublisher(t *testing.T) {
+	pub := NewMemoryPublisher()
+	ctx := context.Background()
+
+	if err := pub.Publish(ctx, Message{Topic: "a", Key: "1"}, Message{Topic: "a", Key: "2"}); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	if err := pub.Publish(ctx, Message{Topic: "b", Key: "3"}); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+
+	if got := len(pub.Messages("")); got != 3 {
+		t.Errorf("got %d messages in total, want 3", got)
+	}
+	msgs := pub.Messages("a")
+	if len(msgs) != 2 || msgs[0].Key != "1" || msgs[1].Key != "2" {
+		t.Errorf("got %+v on topic a, want keys 1 and 2 in order", msgs)
+	}
+
+	pub.Err = errors.New("broker down")
+	if err := pub.Publish(ctx, Message{Topic: "a", Key: "4"}); err == nil {
+		t.Error("expected an error from a failing publisher")
+	}
+	if got := len(pub.Messages("a")); got != 2 {
+		t.Errorf("failed publish stored a message: got %d on topic a, want 2", got)
+	}
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// HeaderEventID carries the outbox event ID. It survives republishing, so
+// consumers can drop the duplicates at-least-once delivery produces.
+const HeaderEventID = "event_id"
+
+const (
+	outboxBatchSize       = 100
+	outboxPollInterval    = 500 * time.Millisecond
+	outboxMaxRetryDelay   = 10 * time.Second
+	outboxCleanupInterval = time.Hour
+	outboxRetention       = 24 * time.Hour
+)
+
+// OutboxMessage is an event stored with the change it describes, waiting
+// for the relay to publish it.
+type OutboxMessage struct {
+	EventID     string
+	Topic       string
+	Key         string
+	Payload     []byte
+	ContentType string
+}
+
+// OutboxStore is the outbox table of a service.
+type OutboxStore interface {
+	// PublishPending locks up to limit unsent messages in insertion order,
+	// passes them to publish and marks them sent once publish succeeds. It
+	// returns the number of messages published.
+	PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, msgs []OutboxMessage) error) (int, error)
+	DeleteSent(ctx context.Context, sentBefore time.Time) (int64, error)
+}
+
+// Relay moves messages from an outbox to the broker. The publisher must
+// report delivery failures, otherwise messages are marked sent regardless.
+type Relay struct {
+	store     OutboxStore
+	publisher Publisher
+}
+
+func NewRelay(store OutboxStore, publisher Publisher) *Relay {
+	return &Relay{store: store, publisher: publisher}
+}
+
+// Run publishes pending messages until ctx is cancelled. Full batches are
+// drained back to back; otherwise the outbox is polled every
+// outboxPollInterval. Failures are retried with exponential backoff.
+func (r *Relay) Run(ctx context.Context) {
+	delay := outboxPollInterval
+	lastCleanup := time.Now()
+	for {
+		published, err := r.store.PublishPending(ctx, outboxBatchSize, r.publish)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("outbox relay: %v", err)
+			delay = min(max(delay*2, outboxPollInterval), outboxMaxRetryDelay)
+		case published == outboxBatchSize:
+			delay = 0
+		default:
+			delay = outboxPollInterval
+		}
+
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			if deleted, err := r.store.DeleteSent(ctx, time.Now().Add(-outboxRetention)); err != nil {
+				log.Printf("outbox relay: %v", err)
+			} else if deleted > 0 {
+				log.Printf("outbox relay: deleted %d sent messages", deleted)
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, msgs []OutboxMessage) error {
+	res := make([]Message, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, Message{
+			Topic: msg.Topic,
+			Key:   msg.Key,
+			Value: msg.Payload,
+			Headers: map[string]string{
+				HeaderEventID:     msg.EventID,
+				HeaderContentType: msg.ContentType,
+			},
+		})
+	}
+	return r.publisher.Publish(ctx, res...)
+}
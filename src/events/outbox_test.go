@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRelayPublish(t *testing.T) {
+	pub := NewMemoryPublisher()
+	relay := NewRelay(nil, pub)
+
+	msgs := []OutboxMessage{
+		{EventID: "e1", Topic: "post-views", Key: "u1", Payload: []byte(`{"n":1}`), ContentType: ContentTypeJSON},
+		{EventID: "e2", Topic: "post-lifecycle", Key: "p1", Payload: []byte{0x0a}, ContentType: ContentTypeProtobuf},
+	}
+	if err := relay.publish(context.Background(), msgs); err != nil {
+		t.Fatalf("publish returned error: %v", err)
+	}
+
+	published := pub.Messages("")
+	if len(published) != len(msgs) {
+		t.Fatalf("published %d messages, want %d", len(published), len(msgs))
+	}
+	for i, msg := range published {
+		want := msgs[i]
+		if msg.Topic != want.Topic || msg.Key != want.Key || string(msg.Value) != string(want.Payload) {
+			t.Errorf("message %d = %+v, want topic %s key %s", i, msg, want.Topic, want.Key)
+		}
+		if msg.Headers[HeaderEventID] != want.EventID {
+			t.Errorf("message %d event ID header = %q, want %q", i, msg.Headers[HeaderEventID], want.EventID)
+		}
+		if msg.Headers[HeaderContentType] != want.ContentType {
+			t.Errorf("message %d content type = %q, want %q", i, msg.Headers[HeaderContentType], want.ContentType)
+		}
+	}
+
+	pub.Err = errors.New("broker down")
+	if err := relay.publish(context.Background(), msgs); err == nil {
+		t.Error("expected publish to fail when the publisher fails")
+	}
+}
@@ -0,0 +1,464 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: events/envelope.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Every Kafka message value is an Envelope, encoded as JSON (protojson with
+// proto field names) or binary protobuf as told by the content-type header.
+type Envelope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique per event and stable across redeliveries.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Name of the payload field that is set, e.g. "post_viewed".
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Bumped on breaking payload changes; consumers skip versions they do not
+	// know.
+	SchemaVersion uint32                 `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_PostViewed
+	//	*Envelope_PostLiked
+	//	*Envelope_PostUnliked
+	//	*Envelope_CommentAdded
+	//	*Envelope_ReplyAdded
+	//	*Envelope_PostCreated
+	//	*Envelope_PostUpdated
+	//	*Envelope_PostDeleted
+	//	*Envelope_CommentDeleted
+	//	*Envelope_PostHidden
+	//	*Envelope_PostUnhidden
+	//	*Envelope_UserRegistered
+	//	*Envelope_UserFollowed
+	//	*Envelope_UserUnfollowed
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_events_envelope_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_events_envelope_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_events_envelope_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Envelope) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Envelope) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostViewed() *PostViewed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostViewed); ok {
+			return x.PostViewed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostLiked() *PostLiked {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostLiked); ok {
+			return x.PostLiked
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostUnliked() *PostUnliked {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostUnliked); ok {
+			return x.PostUnliked
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCommentAdded() *CommentAdded {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CommentAdded); ok {
+			return x.CommentAdded
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReplyAdded() *ReplyAdded {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_ReplyAdded); ok {
+			return x.ReplyAdded
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostCreated() *PostCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostCreated); ok {
+			return x.PostCreated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostUpdated() *PostUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostUpdated); ok {
+			return x.PostUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostDeleted() *PostDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostDeleted); ok {
+			return x.PostDeleted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetCommentDeleted() *CommentDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_CommentDeleted); ok {
+			return x.CommentDeleted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostHidden() *PostHidden {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostHidden); ok {
+			return x.PostHidden
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetPostUnhidden() *PostUnhidden {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_PostUnhidden); ok {
+			return x.PostUnhidden
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserRegistered() *UserRegistered {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserRegistered); ok {
+			return x.UserRegistered
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserFollowed() *UserFollowed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserFollowed); ok {
+			return x.UserFollowed
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserUnfollowed() *UserUnfollowed {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserUnfollowed); ok {
+			return x.UserUnfollowed
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_PostViewed struct {
+	PostViewed *PostViewed `protobuf:"bytes,10,opt,name=post_viewed,json=postViewed,proto3,oneof"`
+}
+
+type Envelope_PostLiked struct {
+	PostLiked *PostLiked `protobuf:"bytes,11,opt,name=post_liked,json=postLiked,proto3,oneof"`
+}
+
+type Envelope_PostUnliked struct {
+	PostUnliked *PostUnliked `protobuf:"bytes,12,opt,name=post_unliked,json=postUnliked,proto3,oneof"`
+}
+
+type Envelope_CommentAdded struct {
+	CommentAdded *CommentAdded `protobuf:"bytes,13,opt,name=comment_added,json=commentAdded,proto3,oneof"`
+}
+
+type Envelope_ReplyAdded struct {
+	ReplyAdded *ReplyAdded `protobuf:"bytes,14,opt,name=reply_added,json=replyAdded,proto3,oneof"`
+}
+
+type Envelope_PostCreated struct {
+	PostCreated *PostCreated `protobuf:"bytes,15,opt,name=post_created,json=postCreated,proto3,oneof"`
+}
+
+type Envelope_PostUpdated struct {
+	PostUpdated *PostUpdated `protobuf:"bytes,16,opt,name=post_updated,json=postUpdated,proto3,oneof"`
+}
+
+type Envelope_PostDeleted struct {
+	PostDeleted *PostDeleted `protobuf:"bytes,17,opt,name=post_deleted,json=postDeleted,proto3,oneof"`
+}
+
+type Envelope_CommentDeleted struct {
+	CommentDeleted *CommentDeleted `protobuf:"bytes,18,opt,name=comment_deleted,json=commentDeleted,proto3,oneof"`
+}
+
+type Envelope_PostHidden struct {
+	PostHidden *PostHidden `protobuf:"bytes,19,opt,name=post_hidden,json=postHidden,proto3,oneof"`
+}
+
+type Envelope_PostUnhidden struct {
+	PostUnhidden *PostUnhidden `protobuf:"bytes,20,opt,name=post_unhidden,json=postUnhidden,proto3,oneof"`
+}
+
+type Envelope_UserRegistered struct {
+	UserRegistered *UserRegistered `protobuf:"bytes,30,opt,name=user_registered,json=userRegistered,proto3,oneof"`
+}
+
+type Envelope_UserFollowed struct {
+	UserFollowed *UserFollowed `protobuf:"bytes,31,opt,name=user_followed,json=userFollowed,proto3,oneof"`
+}
+
+type Envelope_UserUnfollowed struct {
+	UserUnfollowed *UserUnfollowed `protobuf:"bytes,32,opt,name=user_unfollowed,json=userUnfollowed,proto3,oneof"`
+}
+
+func (*Envelope_PostViewed) isEnvelope_Payload() {}
+
+func (*Envelope_PostLiked) isEnvelope_Payload() {}
+
+func (*Envelope_PostUnliked) isEnvelope_Payload() {}
+
+func (*Envelope_CommentAdded) isEnvelope_Payload() {}
+
+func (*Envelope_ReplyAdded) isEnvelope_Payload() {}
+
+func (*Envelope_PostCreated) isEnvelope_Payload() {}
+
+func (*Envelope_PostUpdated) isEnvelope_Payload() {}
+
+func (*Envelope_PostDeleted) isEnvelope_Payload() {}
+
+func (*Envelope_CommentDeleted) isEnvelope_Payload() {}
+
+func (*Envelope_PostHidden) isEnvelope_Payload() {}
+
+func (*Envelope_PostUnhidden) isEnvelope_Payload() {}
+
+func (*Envelope_UserRegistered) isEnvelope_Payload() {}
+
+func (*Envelope_UserFollowed) isEnvelope_Payload() {}
+
+func (*Envelope_UserUnfollowed) isEnvelope_Payload() {}
+
+var File_events_envelope_proto protoreflect.FileDescriptor
+
+const file_events_envelope_proto_rawDesc = "" +
+	"\n" +
+	"\x15events/envelope.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11events/post.proto\x1a\x11events/user.proto\"\xf4\a\n" +
+	"\bEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\rR\rschemaVersion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x125\n" +
+	"\vpost_viewed\x18\n" +
+	" \x01(\v2\x12.events.PostViewedH\x00R\n" +
+	"postViewed\x122\n" +
+	"\n" +
+	"post_liked\x18\v \x01(\v2\x11.events.PostLikedH\x00R\tpostLiked\x128\n" +
+	"\fpost_unliked\x18\f \x01(\v2\x13.events.PostUnlikedH\x00R\vpostUnliked\x12;\n" +
+	"\rcomment_added\x18\r \x01(\v2\x14.events.CommentAddedH\x00R\fcommentAdded\x125\n" +
+	"\vreply_added\x18\x0e \x01(\v2\x12.events.ReplyAddedH\x00R\n" +
+	"replyAdded\x128\n" +
+	"\fpost_created\x18\x0f \x01(\v2\x13.events.PostCreatedH\x00R\vpostCreated\x128\n" +
+	"\fpost_updated\x18\x10 \x01(\v2\x13.events.PostUpdatedH\x00R\vpostUpdated\x128\n" +
+	"\fpost_deleted\x18\x11 \x01(\v2\x13.events.PostDeletedH\x00R\vpostDeleted\x12A\n" +
+	"\x0fcomment_deleted\x18\x12 \x01(\v2\x16.events.CommentDeletedH\x00R\x0ecommentDeleted\x125\n" +
+	"\vpost_hidden\x18\x13 \x01(\v2\x12.events.PostHiddenH\x00R\n" +
+	"postHidden\x12;\n" +
+	"\rpost_unhidden\x18\x14 \x01(\v2\x14.events.PostUnhiddenH\x00R\fpostUnhidden\x12A\n" +
+	"\x0fuser_registered\x18\x1e \x01(\v2\x16.events.UserRegisteredH\x00R\x0euserRegistered\x12;\n" +
+	"\ruser_followed\x18\x1f \x01(\v2\x14.events.UserFollowedH\x00R\fuserFollowed\x12A\n" +
+	"\x0fuser_unfollowed\x18  \x01(\v2\x16.events.UserUnfollowedH\x00R\x0euserUnfollowedB\t\n" +
+	"\apayloadB5Z3github.com/zahartd/social-network/src/gen/go/eventsb\x06proto3"
+
+var (
+	file_events_envelope_proto_rawDescOnce sync.Once
+	file_events_envelope_proto_rawDescData []byte
+)
+
+func file_events_envelope_proto_rawDescGZIP() []byte {
+	file_events_envelope_proto_rawDescOnce.Do(func() {
+		file_events_envelope_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_envelope_proto_rawDesc), len(file_events_envelope_proto_rawDesc)))
+	})
+	return file_events_envelope_proto_rawDescData
+}
+
+var file_events_envelope_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_envelope_proto_goTypes = []any{
+	(*Envelope)(nil),              // 0: events.Envelope
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*PostViewed)(nil),            // 2: events.PostViewed
+	(*PostLiked)(nil),             // 3: events.PostLiked
+	(*PostUnliked)(nil),           // 4: events.PostUnliked
+	(*CommentAdded)(nil),          // 5: events.CommentAdded
+	(*ReplyAdded)(nil),            // 6: events.ReplyAdded
+	(*PostCreated)(nil),           // 7: events.PostCreated
+	(*PostUpdated)(nil),           // 8: events.PostUpdated
+	(*PostDeleted)(nil),           // 9: events.PostDeleted
+	(*CommentDeleted)(nil),        // 10: events.CommentDeleted
+	(*PostHidden)(nil),            // 11: events.PostHidden
+	(*PostUnhidden)(nil),          // 12: events.PostUnhidden
+	(*UserRegistered)(nil),        // 13: events.UserRegistered
+	(*UserFollowed)(nil),          // 14: events.UserFollowed
+	(*UserUnfollowed)(nil),        // 15: events.UserUnfollowed
+}
+var file_events_envelope_proto_depIdxs = []int32{
+	1,  // 0: events.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: events.Envelope.post_viewed:type_name -> events.PostViewed
+	3,  // 2: events.Envelope.post_liked:type_name -> events.PostLiked
+	4,  // 3: events.Envelope.post_unliked:type_name -> events.PostUnliked
+	5,  // 4: events.Envelope.comment_added:type_name -> events.CommentAdded
+	6,  // 5: events.Envelope.reply_added:type_name -> events.ReplyAdded
+	7,  // 6: events.Envelope.post_created:type_name -> events.PostCreated
+	8,  // 7: events.Envelope.post_updated:type_name -> events.PostUpdated
+	9,  // 8: events.Envelope.post_deleted:type_name -> events.PostDeleted
+	10, // 9: events.Envelope.comment_deleted:type_name -> events.CommentDeleted
+	11, // 10: events.Envelope.post_hidden:type_name -> events.PostHidden
+	12, // 11: events.Envelope.post_unhidden:type_name -> events.PostUnhidden
+	13, // 12: events.Envelope.user_registered:type_name -> events.UserRegistered
+	14, // 13: events.Envelope.user_followed:type_name -> events.UserFollowed
+	15, // 14: events.Envelope.user_unfollowed:type_name -> events.UserUnfollowed
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_events_envelope_proto_init() }
+func file_events_envelope_proto_init() {
+	if File_events_envelope_proto != nil {
+		return
+	}
+	file_events_post_proto_init()
+	file_events_user_proto_init()
+	file_events_envelope_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_PostViewed)(nil),
+		(*Envelope_PostLiked)(nil),
+		(*Envelope_PostUnliked)(nil),
+		(*Envelope_CommentAdded)(nil),
+		(*Envelope_ReplyAdded)(nil),
+		(*Envelope_PostCreated)(nil),
+		(*Envelope_PostUpdated)(nil),
+		(*Envelope_PostDeleted)(nil),
+		(*Envelope_CommentDeleted)(nil),
+		(*Envelope_PostHidden)(nil),
+		(*Envelope_PostUnhidden)(nil),
+		(*Envelope_UserRegistered)(nil),
+		(*Envelope_UserFollowed)(nil),
+		(*Envelope_UserUnfollowed)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_envelope_proto_rawDesc), len(file_events_envelope_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_envelope_proto_goTypes,
+		DependencyIndexes: file_events_envelope_proto_depIdxs,
+		MessageInfos:      file_events_envelope_proto_msgTypes,
+	}.Build()
+	File_events_envelope_proto = out.File
+	file_events_envelope_proto_goTypes = nil
+	file_events_envelope_proto_depIdxs = nil
+}
@@ -0,0 +1,818 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: events/post.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Topic post-views, key: user ID.
+type PostViewed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostViewed) Reset() {
+	*x = PostViewed{}
+	mi := &file_events_post_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostViewed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostViewed) ProtoMessage() {}
+
+func (x *PostViewed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostViewed.ProtoReflect.Descriptor instead.
+func (*PostViewed) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PostViewed) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostViewed) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Topic post-likes, key: user ID.
+type PostLiked struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostLiked) Reset() {
+	*x = PostLiked{}
+	mi := &file_events_post_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostLiked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostLiked) ProtoMessage() {}
+
+func (x *PostLiked) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostLiked.ProtoReflect.Descriptor instead.
+func (*PostLiked) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostLiked) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostLiked) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Topic post-likes, key: user ID.
+type PostUnliked struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUnliked) Reset() {
+	*x = PostUnliked{}
+	mi := &file_events_post_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUnliked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUnliked) ProtoMessage() {}
+
+func (x *PostUnliked) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUnliked.ProtoReflect.Descriptor instead.
+func (*PostUnliked) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PostUnliked) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostUnliked) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Topic post-comments, key: user ID.
+type CommentAdded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentAdded) Reset() {
+	*x = CommentAdded{}
+	mi := &file_events_post_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentAdded) ProtoMessage() {}
+
+func (x *CommentAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentAdded.ProtoReflect.Descriptor instead.
+func (*CommentAdded) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommentAdded) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CommentAdded) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *CommentAdded) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+// Topic post-comments, key: user ID.
+type ReplyAdded struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId          string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	CommentId       string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	ParentCommentId string                 `protobuf:"bytes,4,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReplyAdded) Reset() {
+	*x = ReplyAdded{}
+	mi := &file_events_post_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyAdded) ProtoMessage() {}
+
+func (x *ReplyAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyAdded.ProtoReflect.Descriptor instead.
+func (*ReplyAdded) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplyAdded) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReplyAdded) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ReplyAdded) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ReplyAdded) GetParentCommentId() string {
+	if x != nil {
+		return x.ParentCommentId
+	}
+	return ""
+}
+
+// Topic post-comments, key: user ID of the comment's author. Sent for every
+// comment and reply removed by a moderator, including the replies deleted
+// along with a comment.
+type CommentDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentDeleted) Reset() {
+	*x = CommentDeleted{}
+	mi := &file_events_post_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentDeleted) ProtoMessage() {}
+
+func (x *CommentDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentDeleted.ProtoReflect.Descriptor instead.
+func (*CommentDeleted) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CommentDeleted) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CommentDeleted) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *CommentDeleted) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+// State of a post carried by lifecycle events.
+type PostSnapshot struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PostId    string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Tags      []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	IsPrivate bool                   `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set while a moderator has the post hidden.
+	Hidden        bool `protobuf:"varint,7,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSnapshot) Reset() {
+	*x = PostSnapshot{}
+	mi := &file_events_post_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSnapshot) ProtoMessage() {}
+
+func (x *PostSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSnapshot.ProtoReflect.Descriptor instead.
+func (*PostSnapshot) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PostSnapshot) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostSnapshot) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostSnapshot) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostSnapshot) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *PostSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PostSnapshot) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PostSnapshot) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+// Topic post-lifecycle, key: post ID.
+type PostCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *PostSnapshot          `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostCreated) Reset() {
+	*x = PostCreated{}
+	mi := &file_events_post_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCreated) ProtoMessage() {}
+
+func (x *PostCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCreated.ProtoReflect.Descriptor instead.
+func (*PostCreated) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PostCreated) GetPost() *PostSnapshot {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// Topic post-lifecycle, key: post ID.
+type PostUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *PostSnapshot          `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUpdated) Reset() {
+	*x = PostUpdated{}
+	mi := &file_events_post_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUpdated) ProtoMessage() {}
+
+func (x *PostUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUpdated.ProtoReflect.Descriptor instead.
+func (*PostUpdated) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PostUpdated) GetPost() *PostSnapshot {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// Topic post-lifecycle, key: post ID. The snapshot is the state right
+// before the deletion.
+type PostDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *PostSnapshot          `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostDeleted) Reset() {
+	*x = PostDeleted{}
+	mi := &file_events_post_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostDeleted) ProtoMessage() {}
+
+func (x *PostDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostDeleted.ProtoReflect.Descriptor instead.
+func (*PostDeleted) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PostDeleted) GetPost() *PostSnapshot {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// Topic post-lifecycle, key: post ID. Sent when a moderator hides the post.
+type PostHidden struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *PostSnapshot          `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostHidden) Reset() {
+	*x = PostHidden{}
+	mi := &file_events_post_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostHidden) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostHidden) ProtoMessage() {}
+
+func (x *PostHidden) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostHidden.ProtoReflect.Descriptor instead.
+func (*PostHidden) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PostHidden) GetPost() *PostSnapshot {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+// Topic post-lifecycle, key: post ID. Sent when a moderator unhides the
+// post.
+type PostUnhidden struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *PostSnapshot          `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUnhidden) Reset() {
+	*x = PostUnhidden{}
+	mi := &file_events_post_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUnhidden) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUnhidden) ProtoMessage() {}
+
+func (x *PostUnhidden) ProtoReflect() protoreflect.Message {
+	mi := &file_events_post_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUnhidden.ProtoReflect.Descriptor instead.
+func (*PostUnhidden) Descriptor() ([]byte, []int) {
+	return file_events_post_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PostUnhidden) GetPost() *PostSnapshot {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+var File_events_post_proto protoreflect.FileDescriptor
+
+const file_events_post_proto_rawDesc = "" +
+	"\n" +
+	"\x11events/post.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
+	"\n" +
+	"PostViewed\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\"=\n" +
+	"\tPostLiked\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\"?\n" +
+	"\vPostUnliked\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\"_\n" +
+	"\fCommentAdded\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\"\x89\x01\n" +
+	"\n" +
+	"ReplyAdded\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\x12*\n" +
+	"\x11parent_comment_id\x18\x04 \x01(\tR\x0fparentCommentId\"a\n" +
+	"\x0eCommentDeleted\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\"\x81\x02\n" +
+	"\fPostSnapshot\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x04 \x01(\bR\tisPrivate\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06hidden\x18\a \x01(\bR\x06hidden\"7\n" +
+	"\vPostCreated\x12(\n" +
+	"\x04post\x18\x01 \x01(\v2\x14.events.PostSnapshotR\x04post\"7\n" +
+	"\vPostUpdated\x12(\n" +
+	"\x04post\x18\x01 \x01(\v2\x14.events.PostSnapshotR\x04post\"7\n" +
+	"\vPostDeleted\x12(\n" +
+	"\x04post\x18\x01 \x01(\v2\x14.events.PostSnapshotR\x04post\"6\n" +
+	"\n" +
+	"PostHidden\x12(\n" +
+	"\x04post\x18\x01 \x01(\v2\x14.events.PostSnapshotR\x04post\"8\n" +
+	"\fPostUnhidden\x12(\n" +
+	"\x04post\x18\x01 \x01(\v2\x14.events.PostSnapshotR\x04postB5Z3github.com/zahartd/social-network/src/gen/go/eventsb\x06proto3"
+
+var (
+	file_events_post_proto_rawDescOnce sync.Once
+	file_events_post_proto_rawDescData []byte
+)
+
+func file_events_post_proto_rawDescGZIP() []byte {
+	file_events_post_proto_rawDescOnce.Do(func() {
+		file_events_post_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_post_proto_rawDesc), len(file_events_post_proto_rawDesc)))
+	})
+	return file_events_post_proto_rawDescData
+}
+
+var file_events_post_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_events_post_proto_goTypes = []any{
+	(*PostViewed)(nil),            // 0: events.PostViewed
+	(*PostLiked)(nil),             // 1: events.PostLiked
+	(*PostUnliked)(nil),           // 2: events.PostUnliked
+	(*CommentAdded)(nil),          // 3: events.CommentAdded
+	(*ReplyAdded)(nil),            // 4: events.ReplyAdded
+	(*CommentDeleted)(nil),        // 5: events.CommentDeleted
+	(*PostSnapshot)(nil),          // 6: events.PostSnapshot
+	(*PostCreated)(nil),           // 7: events.PostCreated
+	(*PostUpdated)(nil),           // 8: events.PostUpdated
+	(*PostDeleted)(nil),           // 9: events.PostDeleted
+	(*PostHidden)(nil),            // 10: events.PostHidden
+	(*PostUnhidden)(nil),          // 11: events.PostUnhidden
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_events_post_proto_depIdxs = []int32{
+	12, // 0: events.PostSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	12, // 1: events.PostSnapshot.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: events.PostCreated.post:type_name -> events.PostSnapshot
+	6,  // 3: events.PostUpdated.post:type_name -> events.PostSnapshot
+	6,  // 4: events.PostDeleted.post:type_name -> events.PostSnapshot
+	6,  // 5: events.PostHidden.post:type_name -> events.PostSnapshot
+	6,  // 6: events.PostUnhidden.post:type_name -> events.PostSnapshot
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_events_post_proto_init() }
+func file_events_post_proto_init() {
+	if File_events_post_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_post_proto_rawDesc), len(file_events_post_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_post_proto_goTypes,
+		DependencyIndexes: file_events_post_proto_depIdxs,
+		MessageInfos:      file_events_post_proto_msgTypes,
+	}.Build()
+	File_events_post_proto = out.File
+	file_events_post_proto_goTypes = nil
+	file_events_post_proto_depIdxs = nil
+}
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: events/user.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Topic user-registrations, key: user ID.
+type UserRegistered struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRegistered) Reset() {
+	*x = UserRegistered{}
+	mi := &file_events_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRegistered) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRegistered) ProtoMessage() {}
+
+func (x *UserRegistered) ProtoReflect() protoreflect.Message {
+	mi := &file_events_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRegistered.ProtoReflect.Descriptor instead.
+func (*UserRegistered) Descriptor() ([]byte, []int) {
+	return file_events_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserRegistered) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserRegistered) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Topic user-relations, key: follower ID.
+type UserFollowed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FolloweeId    string                 `protobuf:"bytes,2,opt,name=followee_id,json=followeeId,proto3" json:"followee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserFollowed) Reset() {
+	*x = UserFollowed{}
+	mi := &file_events_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserFollowed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFollowed) ProtoMessage() {}
+
+func (x *UserFollowed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFollowed.ProtoReflect.Descriptor instead.
+func (*UserFollowed) Descriptor() ([]byte, []int) {
+	return file_events_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserFollowed) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *UserFollowed) GetFolloweeId() string {
+	if x != nil {
+		return x.FolloweeId
+	}
+	return ""
+}
+
+// Topic user-relations, key: follower ID.
+type UserUnfollowed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FolloweeId    string                 `protobuf:"bytes,2,opt,name=followee_id,json=followeeId,proto3" json:"followee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUnfollowed) Reset() {
+	*x = UserUnfollowed{}
+	mi := &file_events_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUnfollowed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUnfollowed) ProtoMessage() {}
+
+func (x *UserUnfollowed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUnfollowed.ProtoReflect.Descriptor instead.
+func (*UserUnfollowed) Descriptor() ([]byte, []int) {
+	return file_events_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserUnfollowed) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *UserUnfollowed) GetFolloweeId() string {
+	if x != nil {
+		return x.FolloweeId
+	}
+	return ""
+}
+
+var File_events_user_proto protoreflect.FileDescriptor
+
+const file_events_user_proto_rawDesc = "" +
+	"\n" +
+	"\x11events/user.proto\x12\x06events\"?\n" +
+	"\x0eUserRegistered\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"P\n" +
+	"\fUserFollowed\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1f\n" +
+	"\vfollowee_id\x18\x02 \x01(\tR\n" +
+	"followeeId\"R\n" +
+	"\x0eUserUnfollowed\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1f\n" +
+	"\vfollowee_id\x18\x02 \x01(\tR\n" +
+	"followeeIdB5Z3github.com/zahartd/social-network/src/gen/go/eventsb\x06proto3"
+
+var (
+	file_events_user_proto_rawDescOnce sync.Once
+	file_events_user_proto_rawDescData []byte
+)
+
+func file_events_user_proto_rawDescGZIP() []byte {
+	file_events_user_proto_rawDescOnce.Do(func() {
+		file_events_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_user_proto_rawDesc), len(file_events_user_proto_rawDesc)))
+	})
+	return file_events_user_proto_rawDescData
+}
+
+var file_events_user_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_user_proto_goTypes = []any{
+	(*UserRegistered)(nil), // 0: events.UserRegistered
+	(*UserFollowed)(nil),   // 1: events.UserFollowed
+	(*UserUnfollowed)(nil), // 2: events.UserUnfollowed
+}
+var file_events_user_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_events_user_proto_init() }
+func file_events_user_proto_init() {
+	if File_events_user_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_user_proto_rawDesc), len(file_events_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_user_proto_goTypes,
+		DependencyIndexes: file_events_user_proto_depIdxs,
+		MessageInfos:      file_events_user_proto_msgTypes,
+	}.Build()
+	File_events_user_proto = out.File
+	file_events_user_proto_goTypes = nil
+	file_events_user_proto_depIdxs = nil
+}
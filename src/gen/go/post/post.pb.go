@@ -23,16 +23,180 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Who besides the author can see a post.
+type Visibility int32
+
+const (
+	// Not set: requests fall back to is_private.
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	Visibility_VISIBILITY_PUBLIC      Visibility = 1
+	// Users who follow the author.
+	Visibility_VISIBILITY_FOLLOWERS Visibility = 2
+	// Users on the author's close friends list.
+	Visibility_VISIBILITY_CLOSE_FRIENDS Visibility = 3
+	Visibility_VISIBILITY_ONLY_ME       Visibility = 4
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PUBLIC",
+		2: "VISIBILITY_FOLLOWERS",
+		3: "VISIBILITY_CLOSE_FRIENDS",
+		4: "VISIBILITY_ONLY_ME",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED":   0,
+		"VISIBILITY_PUBLIC":        1,
+		"VISIBILITY_FOLLOWERS":     2,
+		"VISIBILITY_CLOSE_FRIENDS": 3,
+		"VISIBILITY_ONLY_ME":       4,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_post_post_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_post_post_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{0}
+}
+
+type SearchOrder int32
+
+const (
+	// Best matches first. Without a query, the same as recent.
+	SearchOrder_SEARCH_ORDER_RELEVANCE SearchOrder = 0
+	SearchOrder_SEARCH_ORDER_RECENT    SearchOrder = 1
+)
+
+// Enum value maps for SearchOrder.
+var (
+	SearchOrder_name = map[int32]string{
+		0: "SEARCH_ORDER_RELEVANCE",
+		1: "SEARCH_ORDER_RECENT",
+	}
+	SearchOrder_value = map[string]int32{
+		"SEARCH_ORDER_RELEVANCE": 0,
+		"SEARCH_ORDER_RECENT":    1,
+	}
+)
+
+func (x SearchOrder) Enum() *SearchOrder {
+	p := new(SearchOrder)
+	*p = x
+	return p
+}
+
+func (x SearchOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_post_post_proto_enumTypes[1].Descriptor()
+}
+
+func (SearchOrder) Type() protoreflect.EnumType {
+	return &file_post_post_proto_enumTypes[1]
+}
+
+func (x SearchOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchOrder.Descriptor instead.
+func (SearchOrder) EnumDescriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{1}
+}
+
+type ModerationActionType int32
+
+const (
+	ModerationActionType_MODERATION_ACTION_UNSPECIFIED ModerationActionType = 0
+	ModerationActionType_MODERATION_ACTION_HIDE        ModerationActionType = 1
+	ModerationActionType_MODERATION_ACTION_UNHIDE      ModerationActionType = 2
+	ModerationActionType_MODERATION_ACTION_DELETE      ModerationActionType = 3
+)
+
+// Enum value maps for ModerationActionType.
+var (
+	ModerationActionType_name = map[int32]string{
+		0: "MODERATION_ACTION_UNSPECIFIED",
+		1: "MODERATION_ACTION_HIDE",
+		2: "MODERATION_ACTION_UNHIDE",
+		3: "MODERATION_ACTION_DELETE",
+	}
+	ModerationActionType_value = map[string]int32{
+		"MODERATION_ACTION_UNSPECIFIED": 0,
+		"MODERATION_ACTION_HIDE":        1,
+		"MODERATION_ACTION_UNHIDE":      2,
+		"MODERATION_ACTION_DELETE":      3,
+	}
+)
+
+func (x ModerationActionType) Enum() *ModerationActionType {
+	p := new(ModerationActionType)
+	*p = x
+	return p
+}
+
+func (x ModerationActionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModerationActionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_post_post_proto_enumTypes[2].Descriptor()
+}
+
+func (ModerationActionType) Type() protoreflect.EnumType {
+	return &file_post_post_proto_enumTypes[2]
+}
+
+func (x ModerationActionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModerationActionType.Descriptor instead.
+func (ModerationActionType) EnumDescriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{2}
+}
+
 type Post struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,7,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	IsPrivate   bool                   `protobuf:"varint,7,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Tags        []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Set by a moderator; hidden posts are visible only to the author and
+	// moderators.
+	Hidden bool `protobuf:"varint,9,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	// is_private is kept for older clients and is true for every visibility
+	// but public.
+	Visibility    Visibility `protobuf:"varint,10,opt,name=visibility,proto3,enum=post.Visibility" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,12 +287,28 @@ func (x *Post) GetTags() []string {
 	return nil
 }
 
+func (x *Post) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *Post) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
 type CreatePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Used only when visibility is unspecified: true means only me.
+	IsPrivate     bool       `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Tags          []string   `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Visibility    Visibility `protobuf:"varint,5,opt,name=visibility,proto3,enum=post.Visibility" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -191,6 +371,13 @@ func (x *CreatePostRequest) GetTags() []string {
 	return nil
 }
 
+func (x *CreatePostRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
 type PostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Post          *Post                  `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
@@ -280,12 +467,14 @@ func (x *GetPostRequest) GetPostId() string {
 }
 
 type UpdatePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PostId      string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Used only when visibility is unspecified: true means only me.
+	IsPrivate     bool       `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Tags          []string   `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Visibility    Visibility `protobuf:"varint,6,opt,name=visibility,proto3,enum=post.Visibility" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -355,6 +544,13 @@ func (x *UpdatePostRequest) GetTags() []string {
 	return nil
 }
 
+func (x *UpdatePostRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
 type DeletePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
@@ -579,27 +775,29 @@ func (x *ListPostsResponse) GetPageSize() int32 {
 	return 0
 }
 
-type ViewPostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+type GetFeedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// next_cursor of the previous page; empty for the first page.
+	Cursor        string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ViewPostRequest) Reset() {
-	*x = ViewPostRequest{}
+func (x *GetFeedRequest) Reset() {
+	*x = GetFeedRequest{}
 	mi := &file_post_post_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ViewPostRequest) String() string {
+func (x *GetFeedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ViewPostRequest) ProtoMessage() {}
+func (*GetFeedRequest) ProtoMessage() {}
 
-func (x *ViewPostRequest) ProtoReflect() protoreflect.Message {
+func (x *GetFeedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_post_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -611,39 +809,48 @@ func (x *ViewPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ViewPostRequest.ProtoReflect.Descriptor instead.
-func (*ViewPostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetFeedRequest) Descriptor() ([]byte, []int) {
 	return file_post_post_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ViewPostRequest) GetPostId() string {
+func (x *GetFeedRequest) GetCursor() string {
 	if x != nil {
-		return x.PostId
+		return x.Cursor
 	}
 	return ""
 }
 
-type LikePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+func (x *GetFeedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetFeedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Posts []*Post                `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikePostRequest) Reset() {
-	*x = LikePostRequest{}
+func (x *GetFeedResponse) Reset() {
+	*x = GetFeedResponse{}
 	mi := &file_post_post_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikePostRequest) String() string {
+func (x *GetFeedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikePostRequest) ProtoMessage() {}
+func (*GetFeedResponse) ProtoMessage() {}
 
-func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
+func (x *GetFeedResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_post_post_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -655,39 +862,58 @@ func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikePostRequest.ProtoReflect.Descriptor instead.
-func (*LikePostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetFeedResponse) Descriptor() ([]byte, []int) {
 	return file_post_post_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *LikePostRequest) GetPostId() string {
+func (x *GetFeedResponse) GetPosts() []*Post {
 	if x != nil {
-		return x.PostId
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *GetFeedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
 	}
 	return ""
 }
 
-type UnlikePostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+type SearchPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Web search syntax: words, "quoted phrases", OR and -excluded words.
+	// May be empty to list posts by the filters alone.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Only posts that have all of these tags.
+	Tags     []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	AuthorId *string  `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	// Inclusive range of creation dates in YYYY-MM-DD (UTC); either end may
+	// be empty.
+	From          string      `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            string      `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Order         SearchOrder `protobuf:"varint,6,opt,name=order,proto3,enum=post.SearchOrder" json:"order,omitempty"`
+	Page          int32       `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32       `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnlikePostRequest) Reset() {
-	*x = UnlikePostRequest{}
+func (x *SearchPostsRequest) Reset() {
+	*x = SearchPostsRequest{}
 	mi := &file_post_post_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnlikePostRequest) String() string {
+func (x *SearchPostsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnlikePostRequest) ProtoMessage() {}
+func (*SearchPostsRequest) ProtoMessage() {}
 
-func (x *UnlikePostRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchPostsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_post_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -699,40 +925,88 @@ func (x *UnlikePostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnlikePostRequest.ProtoReflect.Descriptor instead.
-func (*UnlikePostRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPostsRequest) Descriptor() ([]byte, []int) {
 	return file_post_post_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UnlikePostRequest) GetPostId() string {
+func (x *SearchPostsRequest) GetQuery() string {
 	if x != nil {
-		return x.PostId
+		return x.Query
 	}
 	return ""
 }
 
-type AddCommentRequest struct {
+func (x *SearchPostsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchPostsRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetOrder() SearchOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SearchOrder_SEARCH_ORDER_RELEVANCE
+}
+
+func (x *SearchPostsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ViewPostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddCommentRequest) Reset() {
-	*x = AddCommentRequest{}
+func (x *ViewPostRequest) Reset() {
+	*x = ViewPostRequest{}
 	mi := &file_post_post_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddCommentRequest) String() string {
+func (x *ViewPostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddCommentRequest) ProtoMessage() {}
+func (*ViewPostRequest) ProtoMessage() {}
 
-func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *ViewPostRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_post_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -744,50 +1018,39 @@ func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
-func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ViewPostRequest.ProtoReflect.Descriptor instead.
+func (*ViewPostRequest) Descriptor() ([]byte, []int) {
 	return file_post_post_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AddCommentRequest) GetPostId() string {
+func (x *ViewPostRequest) GetPostId() string {
 	if x != nil {
 		return x.PostId
 	}
 	return ""
 }
 
-func (x *AddCommentRequest) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
-}
-
-type Comment struct {
+type LikePostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
+func (x *LikePostRequest) Reset() {
+	*x = LikePostRequest{}
 	mi := &file_post_post_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *LikePostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*LikePostRequest) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
+func (x *LikePostRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_post_post_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -799,47 +1062,244 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
+// Deprecated: Use LikePostRequest.ProtoReflect.Descriptor instead.
+func (*LikePostRequest) Descriptor() ([]byte, []int) {
 	return file_post_post_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Comment) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Comment) GetPostId() string {
+func (x *LikePostRequest) GetPostId() string {
 	if x != nil {
 		return x.PostId
 	}
 	return ""
 }
 
-func (x *Comment) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+type UnlikePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
+func (x *UnlikePostRequest) Reset() {
+	*x = UnlikePostRequest{}
+	mi := &file_post_post_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+func (x *UnlikePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type CommentResponse struct {
+func (*UnlikePostRequest) ProtoMessage() {}
+
+func (x *UnlikePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlikePostRequest.ProtoReflect.Descriptor instead.
+func (*UnlikePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnlikePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Like state of the post for the caller after LikePost or UnlikePost.
+type LikeStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Liked         bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	LikesCount    int64                  `protobuf:"varint,2,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeStateResponse) Reset() {
+	*x = LikeStateResponse{}
+	mi := &file_post_post_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeStateResponse) ProtoMessage() {}
+
+func (x *LikeStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeStateResponse.ProtoReflect.Descriptor instead.
+func (*LikeStateResponse) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LikeStateResponse) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *LikeStateResponse) GetLikesCount() int64 {
+	if x != nil {
+		return x.LikesCount
+	}
+	return 0
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_post_post_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddCommentRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_post_post_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *Comment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CommentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -848,7 +1308,7 @@ type CommentResponse struct {
 
 func (x *CommentResponse) Reset() {
 	*x = CommentResponse{}
-	mi := &file_post_post_proto_msgTypes[14]
+	mi := &file_post_post_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -860,7 +1320,7 @@ func (x *CommentResponse) String() string {
 func (*CommentResponse) ProtoMessage() {}
 
 func (x *CommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[14]
+	mi := &file_post_post_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -873,7 +1333,7 @@ func (x *CommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentResponse.ProtoReflect.Descriptor instead.
 func (*CommentResponse) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{14}
+	return file_post_post_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CommentResponse) GetComment() *Comment {
@@ -894,7 +1354,7 @@ type ListCommentsRequest struct {
 
 func (x *ListCommentsRequest) Reset() {
 	*x = ListCommentsRequest{}
-	mi := &file_post_post_proto_msgTypes[15]
+	mi := &file_post_post_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -906,7 +1366,7 @@ func (x *ListCommentsRequest) String() string {
 func (*ListCommentsRequest) ProtoMessage() {}
 
 func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[15]
+	mi := &file_post_post_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +1379,7 @@ func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
 func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{15}
+	return file_post_post_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ListCommentsRequest) GetPostId() string {
@@ -954,7 +1414,7 @@ type AddReplyRequest struct {
 
 func (x *AddReplyRequest) Reset() {
 	*x = AddReplyRequest{}
-	mi := &file_post_post_proto_msgTypes[16]
+	mi := &file_post_post_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1426,7 @@ func (x *AddReplyRequest) String() string {
 func (*AddReplyRequest) ProtoMessage() {}
 
 func (x *AddReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[16]
+	mi := &file_post_post_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1439,7 @@ func (x *AddReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddReplyRequest.ProtoReflect.Descriptor instead.
 func (*AddReplyRequest) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{16}
+	return file_post_post_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AddReplyRequest) GetPostId() string {
@@ -1017,7 +1477,7 @@ type Reply struct {
 
 func (x *Reply) Reset() {
 	*x = Reply{}
-	mi := &file_post_post_proto_msgTypes[17]
+	mi := &file_post_post_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1029,7 +1489,7 @@ func (x *Reply) String() string {
 func (*Reply) ProtoMessage() {}
 
 func (x *Reply) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[17]
+	mi := &file_post_post_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1042,7 +1502,7 @@ func (x *Reply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Reply.ProtoReflect.Descriptor instead.
 func (*Reply) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{17}
+	return file_post_post_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Reply) GetId() string {
@@ -1096,7 +1556,7 @@ type ReplyResponse struct {
 
 func (x *ReplyResponse) Reset() {
 	*x = ReplyResponse{}
-	mi := &file_post_post_proto_msgTypes[18]
+	mi := &file_post_post_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1108,7 +1568,7 @@ func (x *ReplyResponse) String() string {
 func (*ReplyResponse) ProtoMessage() {}
 
 func (x *ReplyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[18]
+	mi := &file_post_post_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1121,7 +1581,7 @@ func (x *ReplyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplyResponse.ProtoReflect.Descriptor instead.
 func (*ReplyResponse) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{18}
+	return file_post_post_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ReplyResponse) GetReply() *Reply {
@@ -1134,13 +1594,14 @@ func (x *ReplyResponse) GetReply() *Reply {
 type ListRepliesRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ParentCommentId string                 `protobuf:"bytes,1,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"`
+	PostId          string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListRepliesRequest) Reset() {
 	*x = ListRepliesRequest{}
-	mi := &file_post_post_proto_msgTypes[19]
+	mi := &file_post_post_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1152,7 +1613,7 @@ func (x *ListRepliesRequest) String() string {
 func (*ListRepliesRequest) ProtoMessage() {}
 
 func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[19]
+	mi := &file_post_post_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1165,7 +1626,7 @@ func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesRequest.ProtoReflect.Descriptor instead.
 func (*ListRepliesRequest) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{19}
+	return file_post_post_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListRepliesRequest) GetParentCommentId() string {
@@ -1175,6 +1636,13 @@ func (x *ListRepliesRequest) GetParentCommentId() string {
 	return ""
 }
 
+func (x *ListRepliesRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
 type ListCommentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
@@ -1187,7 +1655,7 @@ type ListCommentsResponse struct {
 
 func (x *ListCommentsResponse) Reset() {
 	*x = ListCommentsResponse{}
-	mi := &file_post_post_proto_msgTypes[20]
+	mi := &file_post_post_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1199,7 +1667,7 @@ func (x *ListCommentsResponse) String() string {
 func (*ListCommentsResponse) ProtoMessage() {}
 
 func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[20]
+	mi := &file_post_post_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1212,7 +1680,7 @@ func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
 func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{20}
+	return file_post_post_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListCommentsResponse) GetComments() []*Comment {
@@ -1255,7 +1723,7 @@ type ListRepliesResponse struct {
 
 func (x *ListRepliesResponse) Reset() {
 	*x = ListRepliesResponse{}
-	mi := &file_post_post_proto_msgTypes[21]
+	mi := &file_post_post_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1267,7 +1735,7 @@ func (x *ListRepliesResponse) String() string {
 func (*ListRepliesResponse) ProtoMessage() {}
 
 func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_post_post_proto_msgTypes[21]
+	mi := &file_post_post_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1280,7 +1748,7 @@ func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesResponse.ProtoReflect.Descriptor instead.
 func (*ListRepliesResponse) Descriptor() ([]byte, []int) {
-	return file_post_post_proto_rawDescGZIP(), []int{21}
+	return file_post_post_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListRepliesResponse) GetReplies() []*Reply {
@@ -1311,11 +1779,368 @@ func (x *ListRepliesResponse) GetPageSize() int32 {
 	return 0
 }
 
+type ModeratePostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Action        ModerationActionType   `protobuf:"varint,2,opt,name=action,proto3,enum=post.ModerationActionType" json:"action,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeratePostRequest) Reset() {
+	*x = ModeratePostRequest{}
+	mi := &file_post_post_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeratePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeratePostRequest) ProtoMessage() {}
+
+func (x *ModeratePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeratePostRequest.ProtoReflect.Descriptor instead.
+func (*ModeratePostRequest) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ModeratePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ModeratePostRequest) GetAction() ModerationActionType {
+	if x != nil {
+		return x.Action
+	}
+	return ModerationActionType_MODERATION_ACTION_UNSPECIFIED
+}
+
+func (x *ModeratePostRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ModerateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Action        ModerationActionType   `protobuf:"varint,3,opt,name=action,proto3,enum=post.ModerationActionType" json:"action,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateCommentRequest) Reset() {
+	*x = ModerateCommentRequest{}
+	mi := &file_post_post_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateCommentRequest) ProtoMessage() {}
+
+func (x *ModerateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateCommentRequest.ProtoReflect.Descriptor instead.
+func (*ModerateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ModerateCommentRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ModerateCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ModerateCommentRequest) GetAction() ModerationActionType {
+	if x != nil {
+		return x.Action
+	}
+	return ModerationActionType_MODERATION_ACTION_UNSPECIFIED
+}
+
+func (x *ModerateCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Audit record of a moderation action. target_type is "post" or "comment".
+type ModerationAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	PostId        string                 `protobuf:"bytes,4,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Action        ModerationActionType   `protobuf:"varint,5,opt,name=action,proto3,enum=post.ModerationActionType" json:"action,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ModeratorId   string                 `protobuf:"bytes,7,opt,name=moderator_id,json=moderatorId,proto3" json:"moderator_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerationAction) Reset() {
+	*x = ModerationAction{}
+	mi := &file_post_post_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationAction) ProtoMessage() {}
+
+func (x *ModerationAction) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationAction.ProtoReflect.Descriptor instead.
+func (*ModerationAction) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ModerationAction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetAction() ModerationActionType {
+	if x != nil {
+		return x.Action
+	}
+	return ModerationActionType_MODERATION_ACTION_UNSPECIFIED
+}
+
+func (x *ModerationAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetModeratorId() string {
+	if x != nil {
+		return x.ModeratorId
+	}
+	return ""
+}
+
+func (x *ModerationAction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListModerationActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationActionsRequest) Reset() {
+	*x = ListModerationActionsRequest{}
+	mi := &file_post_post_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationActionsRequest) ProtoMessage() {}
+
+func (x *ListModerationActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListModerationActionsRequest) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListModerationActionsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ListModerationActionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationActionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListModerationActionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*ModerationAction    `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationActionsResponse) Reset() {
+	*x = ListModerationActionsResponse{}
+	mi := &file_post_post_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationActionsResponse) ProtoMessage() {}
+
+func (x *ListModerationActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_post_post_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationActionsResponse.ProtoReflect.Descriptor instead.
+func (*ListModerationActionsResponse) Descriptor() ([]byte, []int) {
+	return file_post_post_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListModerationActionsResponse) GetActions() []*ModerationAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ListModerationActionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListModerationActionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationActionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 var File_post_post_proto protoreflect.FileDescriptor
 
 const file_post_post_proto_rawDesc = "" +
 	"\n" +
-	"\x0fpost/post.proto\x12\x04post\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x90\x02\n" +
+	"\x0fpost/post.proto\x12\x04post\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xda\x02\n" +
 	"\x04Post\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
@@ -1327,25 +2152,36 @@ const file_post_post_proto_rawDesc = "" +
 	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
 	"\n" +
 	"is_private\x18\a \x01(\bR\tisPrivate\x12\x12\n" +
-	"\x04tags\x18\b \x03(\tR\x04tags\"~\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x16\n" +
+	"\x06hidden\x18\t \x01(\bR\x06hidden\x120\n" +
+	"\n" +
+	"visibility\x18\n" +
+	" \x01(\x0e2\x10.post.VisibilityR\n" +
+	"visibility\"\xb0\x01\n" +
 	"\x11CreatePostRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1d\n" +
 	"\n" +
 	"is_private\x18\x03 \x01(\bR\tisPrivate\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\".\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x120\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x10.post.VisibilityR\n" +
+	"visibility\".\n" +
 	"\fPostResponse\x12\x1e\n" +
 	"\x04post\x18\x01 \x01(\v2\n" +
 	".post.PostR\x04post\")\n" +
 	"\x0eGetPostRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"\x97\x01\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"\xc9\x01\n" +
 	"\x11UpdatePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
 	"\n" +
 	"is_private\x18\x04 \x01(\bR\tisPrivate\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\",\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x120\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\x0e2\x10.post.VisibilityR\n" +
+	"visibility\",\n" +
 	"\x11DeletePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\"E\n" +
 	"\x12ListMyPostsRequest\x12\x12\n" +
@@ -1363,13 +2199,36 @@ const file_post_post_proto_rawDesc = "" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"*\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"E\n" +
+	"\x0eGetFeedRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"T\n" +
+	"\x0fGetFeedResponse\x12 \n" +
+	"\x05posts\x18\x01 \x03(\v2\n" +
+	".post.PostR\x05posts\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xec\x01\n" +
+	"\x12SearchPostsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12 \n" +
+	"\tauthor_id\x18\x03 \x01(\tH\x00R\bauthorId\x88\x01\x01\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12'\n" +
+	"\x05order\x18\x06 \x01(\x0e2\x11.post.SearchOrderR\x05order\x12\x12\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSizeB\f\n" +
+	"\n" +
+	"_author_id\"*\n" +
 	"\x0fViewPostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\"*\n" +
 	"\x0fLikePostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\",\n" +
 	"\x11UnlikePostRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"@\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"J\n" +
+	"\x11LikeStateResponse\x12\x14\n" +
+	"\x05liked\x18\x01 \x01(\bR\x05liked\x12\x1f\n" +
+	"\vlikes_count\x18\x02 \x01(\x03R\n" +
+	"likesCount\"@\n" +
 	"\x11AddCommentRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x12\n" +
 	"\x04text\x18\x02 \x01(\tR\x04text\"\x9a\x01\n" +
@@ -1399,9 +2258,10 @@ const file_post_post_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"2\n" +
 	"\rReplyResponse\x12!\n" +
-	"\x05reply\x18\x01 \x01(\v2\v.post.ReplyR\x05reply\"@\n" +
+	"\x05reply\x18\x01 \x01(\v2\v.post.ReplyR\x05reply\"Y\n" +
 	"\x12ListRepliesRequest\x12*\n" +
-	"\x11parent_comment_id\x18\x01 \x01(\tR\x0fparentCommentId\"\x93\x01\n" +
+	"\x11parent_comment_id\x18\x01 \x01(\tR\x0fparentCommentId\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\tR\x06postId\"\x93\x01\n" +
 	"\x14ListCommentsResponse\x12)\n" +
 	"\bcomments\x18\x01 \x03(\v2\r.post.CommentR\bcomments\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
@@ -1413,7 +2273,53 @@ const file_post_post_proto_rawDesc = "" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xb9\x06\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"z\n" +
+	"\x13ModeratePostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x122\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x1a.post.ModerationActionTypeR\x06action\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x9c\x01\n" +
+	"\x16ModerateCommentRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x122\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x1a.post.ModerationActionTypeR\x06action\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xa3\x02\n" +
+	"\x10ModerationAction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x17\n" +
+	"\apost_id\x18\x04 \x01(\tR\x06postId\x122\n" +
+	"\x06action\x18\x05 \x01(\x0e2\x1a.post.ModerationActionTypeR\x06action\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12!\n" +
+	"\fmoderator_id\x18\a \x01(\tR\vmoderatorId\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"h\n" +
+	"\x1cListModerationActionsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xa3\x01\n" +
+	"\x1dListModerationActionsResponse\x120\n" +
+	"\aactions\x18\x01 \x03(\v2\x16.post.ModerationActionR\aactions\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize*\x8f\x01\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x01\x12\x18\n" +
+	"\x14VISIBILITY_FOLLOWERS\x10\x02\x12\x1c\n" +
+	"\x18VISIBILITY_CLOSE_FRIENDS\x10\x03\x12\x16\n" +
+	"\x12VISIBILITY_ONLY_ME\x10\x04*B\n" +
+	"\vSearchOrder\x12\x1a\n" +
+	"\x16SEARCH_ORDER_RELEVANCE\x10\x00\x12\x17\n" +
+	"\x13SEARCH_ORDER_RECENT\x10\x01*\x91\x01\n" +
+	"\x14ModerationActionType\x12!\n" +
+	"\x1dMODERATION_ACTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16MODERATION_ACTION_HIDE\x10\x01\x12\x1c\n" +
+	"\x18MODERATION_ACTION_UNHIDE\x10\x02\x12\x1c\n" +
+	"\x18MODERATION_ACTION_DELETE\x10\x032\xa3\t\n" +
 	"\vPostService\x129\n" +
 	"\n" +
 	"CreatePost\x12\x17.post.CreatePostRequest\x1a\x12.post.PostResponse\x123\n" +
@@ -1423,16 +2329,21 @@ const file_post_post_proto_rawDesc = "" +
 	"\n" +
 	"DeletePost\x12\x17.post.DeletePostRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
 	"\vListMyPosts\x12\x18.post.ListMyPostsRequest\x1a\x17.post.ListPostsResponse\x12H\n" +
-	"\x0fListPublicPosts\x12\x1c.post.ListPublicPostsRequest\x1a\x17.post.ListPostsResponse\x129\n" +
-	"\bViewPost\x12\x15.post.ViewPostRequest\x1a\x16.google.protobuf.Empty\x129\n" +
-	"\bLikePost\x12\x15.post.LikePostRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\x0fListPublicPosts\x12\x1c.post.ListPublicPostsRequest\x1a\x17.post.ListPostsResponse\x126\n" +
+	"\aGetFeed\x12\x14.post.GetFeedRequest\x1a\x15.post.GetFeedResponse\x12@\n" +
+	"\vSearchPosts\x12\x18.post.SearchPostsRequest\x1a\x17.post.ListPostsResponse\x129\n" +
+	"\bViewPost\x12\x15.post.ViewPostRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\bLikePost\x12\x15.post.LikePostRequest\x1a\x17.post.LikeStateResponse\x12>\n" +
 	"\n" +
-	"UnlikePost\x12\x17.post.UnlikePostRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"UnlikePost\x12\x17.post.UnlikePostRequest\x1a\x17.post.LikeStateResponse\x12<\n" +
 	"\n" +
 	"AddComment\x12\x17.post.AddCommentRequest\x1a\x15.post.CommentResponse\x126\n" +
 	"\bAddReply\x12\x15.post.AddReplyRequest\x1a\x13.post.ReplyResponse\x12E\n" +
 	"\fListComments\x12\x19.post.ListCommentsRequest\x1a\x1a.post.ListCommentsResponse\x12B\n" +
-	"\vListReplies\x12\x18.post.ListRepliesRequest\x1a\x19.post.ListRepliesResponseB3Z1github.com/zahartd/social-network/src/gen/go/postb\x06proto3"
+	"\vListReplies\x12\x18.post.ListRepliesRequest\x1a\x19.post.ListRepliesResponse\x12A\n" +
+	"\fModeratePost\x12\x19.post.ModeratePostRequest\x1a\x16.post.ModerationAction\x12G\n" +
+	"\x0fModerateComment\x12\x1c.post.ModerateCommentRequest\x1a\x16.post.ModerationAction\x12`\n" +
+	"\x15ListModerationActions\x12\".post.ListModerationActionsRequest\x1a#.post.ListModerationActionsResponseB3Z1github.com/zahartd/social-network/src/gen/go/postb\x06proto3"
 
 var (
 	file_post_post_proto_rawDescOnce sync.Once
@@ -1446,75 +2357,108 @@ func file_post_post_proto_rawDescGZIP() []byte {
 	return file_post_post_proto_rawDescData
 }
 
-var file_post_post_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_post_post_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_post_post_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_post_post_proto_goTypes = []any{
-	(*Post)(nil),                   // 0: post.Post
-	(*CreatePostRequest)(nil),      // 1: post.CreatePostRequest
-	(*PostResponse)(nil),           // 2: post.PostResponse
-	(*GetPostRequest)(nil),         // 3: post.GetPostRequest
-	(*UpdatePostRequest)(nil),      // 4: post.UpdatePostRequest
-	(*DeletePostRequest)(nil),      // 5: post.DeletePostRequest
-	(*ListMyPostsRequest)(nil),     // 6: post.ListMyPostsRequest
-	(*ListPublicPostsRequest)(nil), // 7: post.ListPublicPostsRequest
-	(*ListPostsResponse)(nil),      // 8: post.ListPostsResponse
-	(*ViewPostRequest)(nil),        // 9: post.ViewPostRequest
-	(*LikePostRequest)(nil),        // 10: post.LikePostRequest
-	(*UnlikePostRequest)(nil),      // 11: post.UnlikePostRequest
-	(*AddCommentRequest)(nil),      // 12: post.AddCommentRequest
-	(*Comment)(nil),                // 13: post.Comment
-	(*CommentResponse)(nil),        // 14: post.CommentResponse
-	(*ListCommentsRequest)(nil),    // 15: post.ListCommentsRequest
-	(*AddReplyRequest)(nil),        // 16: post.AddReplyRequest
-	(*Reply)(nil),                  // 17: post.Reply
-	(*ReplyResponse)(nil),          // 18: post.ReplyResponse
-	(*ListRepliesRequest)(nil),     // 19: post.ListRepliesRequest
-	(*ListCommentsResponse)(nil),   // 20: post.ListCommentsResponse
-	(*ListRepliesResponse)(nil),    // 21: post.ListRepliesResponse
-	(*timestamppb.Timestamp)(nil),  // 22: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),          // 23: google.protobuf.Empty
+	(Visibility)(0),                       // 0: post.Visibility
+	(SearchOrder)(0),                      // 1: post.SearchOrder
+	(ModerationActionType)(0),             // 2: post.ModerationActionType
+	(*Post)(nil),                          // 3: post.Post
+	(*CreatePostRequest)(nil),             // 4: post.CreatePostRequest
+	(*PostResponse)(nil),                  // 5: post.PostResponse
+	(*GetPostRequest)(nil),                // 6: post.GetPostRequest
+	(*UpdatePostRequest)(nil),             // 7: post.UpdatePostRequest
+	(*DeletePostRequest)(nil),             // 8: post.DeletePostRequest
+	(*ListMyPostsRequest)(nil),            // 9: post.ListMyPostsRequest
+	(*ListPublicPostsRequest)(nil),        // 10: post.ListPublicPostsRequest
+	(*ListPostsResponse)(nil),             // 11: post.ListPostsResponse
+	(*GetFeedRequest)(nil),                // 12: post.GetFeedRequest
+	(*GetFeedResponse)(nil),               // 13: post.GetFeedResponse
+	(*SearchPostsRequest)(nil),            // 14: post.SearchPostsRequest
+	(*ViewPostRequest)(nil),               // 15: post.ViewPostRequest
+	(*LikePostRequest)(nil),               // 16: post.LikePostRequest
+	(*UnlikePostRequest)(nil),             // 17: post.UnlikePostRequest
+	(*LikeStateResponse)(nil),             // 18: post.LikeStateResponse
+	(*AddCommentRequest)(nil),             // 19: post.AddCommentRequest
+	(*Comment)(nil),                       // 20: post.Comment
+	(*CommentResponse)(nil),               // 21: post.CommentResponse
+	(*ListCommentsRequest)(nil),           // 22: post.ListCommentsRequest
+	(*AddReplyRequest)(nil),               // 23: post.AddReplyRequest
+	(*Reply)(nil),                         // 24: post.Reply
+	(*ReplyResponse)(nil),                 // 25: post.ReplyResponse
+	(*ListRepliesRequest)(nil),            // 26: post.ListRepliesRequest
+	(*ListCommentsResponse)(nil),          // 27: post.ListCommentsResponse
+	(*ListRepliesResponse)(nil),           // 28: post.ListRepliesResponse
+	(*ModeratePostRequest)(nil),           // 29: post.ModeratePostRequest
+	(*ModerateCommentRequest)(nil),        // 30: post.ModerateCommentRequest
+	(*ModerationAction)(nil),              // 31: post.ModerationAction
+	(*ListModerationActionsRequest)(nil),  // 32: post.ListModerationActionsRequest
+	(*ListModerationActionsResponse)(nil), // 33: post.ListModerationActionsResponse
+	(*timestamppb.Timestamp)(nil),         // 34: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                 // 35: google.protobuf.Empty
 }
 var file_post_post_proto_depIdxs = []int32{
-	22, // 0: post.Post.created_at:type_name -> google.protobuf.Timestamp
-	22, // 1: post.Post.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: post.PostResponse.post:type_name -> post.Post
-	0,  // 3: post.ListPostsResponse.posts:type_name -> post.Post
-	22, // 4: post.Comment.created_at:type_name -> google.protobuf.Timestamp
-	13, // 5: post.CommentResponse.comment:type_name -> post.Comment
-	22, // 6: post.Reply.created_at:type_name -> google.protobuf.Timestamp
-	17, // 7: post.ReplyResponse.reply:type_name -> post.Reply
-	13, // 8: post.ListCommentsResponse.comments:type_name -> post.Comment
-	17, // 9: post.ListRepliesResponse.replies:type_name -> post.Reply
-	1,  // 10: post.PostService.CreatePost:input_type -> post.CreatePostRequest
-	3,  // 11: post.PostService.GetPost:input_type -> post.GetPostRequest
-	4,  // 12: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
-	5,  // 13: post.PostService.DeletePost:input_type -> post.DeletePostRequest
-	6,  // 14: post.PostService.ListMyPosts:input_type -> post.ListMyPostsRequest
-	7,  // 15: post.PostService.ListPublicPosts:input_type -> post.ListPublicPostsRequest
-	9,  // 16: post.PostService.ViewPost:input_type -> post.ViewPostRequest
-	10, // 17: post.PostService.LikePost:input_type -> post.LikePostRequest
-	11, // 18: post.PostService.UnlikePost:input_type -> post.UnlikePostRequest
-	12, // 19: post.PostService.AddComment:input_type -> post.AddCommentRequest
-	16, // 20: post.PostService.AddReply:input_type -> post.AddReplyRequest
-	15, // 21: post.PostService.ListComments:input_type -> post.ListCommentsRequest
-	19, // 22: post.PostService.ListReplies:input_type -> post.ListRepliesRequest
-	2,  // 23: post.PostService.CreatePost:output_type -> post.PostResponse
-	2,  // 24: post.PostService.GetPost:output_type -> post.PostResponse
-	2,  // 25: post.PostService.UpdatePost:output_type -> post.PostResponse
-	23, // 26: post.PostService.DeletePost:output_type -> google.protobuf.Empty
-	8,  // 27: post.PostService.ListMyPosts:output_type -> post.ListPostsResponse
-	8,  // 28: post.PostService.ListPublicPosts:output_type -> post.ListPostsResponse
-	23, // 29: post.PostService.ViewPost:output_type -> google.protobuf.Empty
-	23, // 30: post.PostService.LikePost:output_type -> google.protobuf.Empty
-	23, // 31: post.PostService.UnlikePost:output_type -> google.protobuf.Empty
-	14, // 32: post.PostService.AddComment:output_type -> post.CommentResponse
-	18, // 33: post.PostService.AddReply:output_type -> post.ReplyResponse
-	20, // 34: post.PostService.ListComments:output_type -> post.ListCommentsResponse
-	21, // 35: post.PostService.ListReplies:output_type -> post.ListRepliesResponse
-	23, // [23:36] is the sub-list for method output_type
-	10, // [10:23] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	34, // 0: post.Post.created_at:type_name -> google.protobuf.Timestamp
+	34, // 1: post.Post.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: post.Post.visibility:type_name -> post.Visibility
+	0,  // 3: post.CreatePostRequest.visibility:type_name -> post.Visibility
+	3,  // 4: post.PostResponse.post:type_name -> post.Post
+	0,  // 5: post.UpdatePostRequest.visibility:type_name -> post.Visibility
+	3,  // 6: post.ListPostsResponse.posts:type_name -> post.Post
+	3,  // 7: post.GetFeedResponse.posts:type_name -> post.Post
+	1,  // 8: post.SearchPostsRequest.order:type_name -> post.SearchOrder
+	34, // 9: post.Comment.created_at:type_name -> google.protobuf.Timestamp
+	20, // 10: post.CommentResponse.comment:type_name -> post.Comment
+	34, // 11: post.Reply.created_at:type_name -> google.protobuf.Timestamp
+	24, // 12: post.ReplyResponse.reply:type_name -> post.Reply
+	20, // 13: post.ListCommentsResponse.comments:type_name -> post.Comment
+	24, // 14: post.ListRepliesResponse.replies:type_name -> post.Reply
+	2,  // 15: post.ModeratePostRequest.action:type_name -> post.ModerationActionType
+	2,  // 16: post.ModerateCommentRequest.action:type_name -> post.ModerationActionType
+	2,  // 17: post.ModerationAction.action:type_name -> post.ModerationActionType
+	34, // 18: post.ModerationAction.created_at:type_name -> google.protobuf.Timestamp
+	31, // 19: post.ListModerationActionsResponse.actions:type_name -> post.ModerationAction
+	4,  // 20: post.PostService.CreatePost:input_type -> post.CreatePostRequest
+	6,  // 21: post.PostService.GetPost:input_type -> post.GetPostRequest
+	7,  // 22: post.PostService.UpdatePost:input_type -> post.UpdatePostRequest
+	8,  // 23: post.PostService.DeletePost:input_type -> post.DeletePostRequest
+	9,  // 24: post.PostService.ListMyPosts:input_type -> post.ListMyPostsRequest
+	10, // 25: post.PostService.ListPublicPosts:input_type -> post.ListPublicPostsRequest
+	12, // 26: post.PostService.GetFeed:input_type -> post.GetFeedRequest
+	14, // 27: post.PostService.SearchPosts:input_type -> post.SearchPostsRequest
+	15, // 28: post.PostService.ViewPost:input_type -> post.ViewPostRequest
+	16, // 29: post.PostService.LikePost:input_type -> post.LikePostRequest
+	17, // 30: post.PostService.UnlikePost:input_type -> post.UnlikePostRequest
+	19, // 31: post.PostService.AddComment:input_type -> post.AddCommentRequest
+	23, // 32: post.PostService.AddReply:input_type -> post.AddReplyRequest
+	22, // 33: post.PostService.ListComments:input_type -> post.ListCommentsRequest
+	26, // 34: post.PostService.ListReplies:input_type -> post.ListRepliesRequest
+	29, // 35: post.PostService.ModeratePost:input_type -> post.ModeratePostRequest
+	30, // 36: post.PostService.ModerateComment:input_type -> post.ModerateCommentRequest
+	32, // 37: post.PostService.ListModerationActions:input_type -> post.ListModerationActionsRequest
+	5,  // 38: post.PostService.CreatePost:output_type -> post.PostResponse
+	5,  // 39: post.PostService.GetPost:output_type -> post.PostResponse
+	5,  // 40: post.PostService.UpdatePost:output_type -> post.PostResponse
+	35, // 41: post.PostService.DeletePost:output_type -> google.protobuf.Empty
+	11, // 42: post.PostService.ListMyPosts:output_type -> post.ListPostsResponse
+	11, // 43: post.PostService.ListPublicPosts:output_type -> post.ListPostsResponse
+	13, // 44: post.PostService.GetFeed:output_type -> post.GetFeedResponse
+	11, // 45: post.PostService.SearchPosts:output_type -> post.ListPostsResponse
+	35, // 46: post.PostService.ViewPost:output_type -> google.protobuf.Empty
+	18, // 47: post.PostService.LikePost:output_type -> post.LikeStateResponse
+	18, // 48: post.PostService.UnlikePost:output_type -> post.LikeStateResponse
+	21, // 49: post.PostService.AddComment:output_type -> post.CommentResponse
+	25, // 50: post.PostService.AddReply:output_type -> post.ReplyResponse
+	27, // 51: post.PostService.ListComments:output_type -> post.ListCommentsResponse
+	28, // 52: post.PostService.ListReplies:output_type -> post.ListRepliesResponse
+	31, // 53: post.PostService.ModeratePost:output_type -> post.ModerationAction
+	31, // 54: post.PostService.ModerateComment:output_type -> post.ModerationAction
+	33, // 55: post.PostService.ListModerationActions:output_type -> post.ListModerationActionsResponse
+	38, // [38:56] is the sub-list for method output_type
+	20, // [20:38] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_post_post_proto_init() }
@@ -1523,18 +2467,20 @@ func file_post_post_proto_init() {
 		return
 	}
 	file_post_post_proto_msgTypes[7].OneofWrappers = []any{}
+	file_post_post_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_post_post_proto_rawDesc), len(file_post_post_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   22,
+			NumEnums:      3,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_post_post_proto_goTypes,
 		DependencyIndexes: file_post_post_proto_depIdxs,
+		EnumInfos:         file_post_post_proto_enumTypes,
 		MessageInfos:      file_post_post_proto_msgTypes,
 	}.Build()
 	File_post_post_proto = out.File
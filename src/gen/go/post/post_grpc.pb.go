@@ -20,19 +20,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PostService_CreatePost_FullMethodName      = "/post.PostService/CreatePost"
-	PostService_GetPost_FullMethodName         = "/post.PostService/GetPost"
-	PostService_UpdatePost_FullMethodName      = "/post.PostService/UpdatePost"
-	PostService_DeletePost_FullMethodName      = "/post.PostService/DeletePost"
-	PostService_ListMyPosts_FullMethodName     = "/post.PostService/ListMyPosts"
-	PostService_ListPublicPosts_FullMethodName = "/post.PostService/ListPublicPosts"
-	PostService_ViewPost_FullMethodName        = "/post.PostService/ViewPost"
-	PostService_LikePost_FullMethodName        = "/post.PostService/LikePost"
-	PostService_UnlikePost_FullMethodName      = "/post.PostService/UnlikePost"
-	PostService_AddComment_FullMethodName      = "/post.PostService/AddComment"
-	PostService_AddReply_FullMethodName        = "/post.PostService/AddReply"
-	PostService_ListComments_FullMethodName    = "/post.PostService/ListComments"
-	PostService_ListReplies_FullMethodName     = "/post.PostService/ListReplies"
+	PostService_CreatePost_FullMethodName            = "/post.PostService/CreatePost"
+	PostService_GetPost_FullMethodName               = "/post.PostService/GetPost"
+	PostService_UpdatePost_FullMethodName            = "/post.PostService/UpdatePost"
+	PostService_DeletePost_FullMethodName            = "/post.PostService/DeletePost"
+	PostService_ListMyPosts_FullMethodName           = "/post.PostService/ListMyPosts"
+	PostService_ListPublicPosts_FullMethodName       = "/post.PostService/ListPublicPosts"
+	PostService_GetFeed_FullMethodName               = "/post.PostService/GetFeed"
+	PostService_SearchPosts_FullMethodName           = "/post.PostService/SearchPosts"
+	PostService_ViewPost_FullMethodName              = "/post.PostService/ViewPost"
+	PostService_LikePost_FullMethodName              = "/post.PostService/LikePost"
+	PostService_UnlikePost_FullMethodName            = "/post.PostService/UnlikePost"
+	PostService_AddComment_FullMethodName            = "/post.PostService/AddComment"
+	PostService_AddReply_FullMethodName              = "/post.PostService/AddReply"
+	PostService_ListComments_FullMethodName          = "/post.PostService/ListComments"
+	PostService_ListReplies_FullMethodName           = "/post.PostService/ListReplies"
+	PostService_ModeratePost_FullMethodName          = "/post.PostService/ModeratePost"
+	PostService_ModerateComment_FullMethodName       = "/post.PostService/ModerateComment"
+	PostService_ListModerationActions_FullMethodName = "/post.PostService/ListModerationActions"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -45,13 +50,22 @@ type PostServiceClient interface {
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListMyPosts(ctx context.Context, in *ListMyPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
 	ListPublicPosts(ctx context.Context, in *ListPublicPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
+	// Recent posts of the authors the caller follows, newest first.
+	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error)
+	// Full-text search over the titles and descriptions of the posts the
+	// caller can see.
+	SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
 	ViewPost(ctx context.Context, in *ViewPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	UnlikePost(ctx context.Context, in *UnlikePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*LikeStateResponse, error)
+	UnlikePost(ctx context.Context, in *UnlikePostRequest, opts ...grpc.CallOption) (*LikeStateResponse, error)
 	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	AddReply(ctx context.Context, in *AddReplyRequest, opts ...grpc.CallOption) (*ReplyResponse, error)
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
 	ListReplies(ctx context.Context, in *ListRepliesRequest, opts ...grpc.CallOption) (*ListRepliesResponse, error)
+	// Moderation, available to moderators and admins only.
+	ModeratePost(ctx context.Context, in *ModeratePostRequest, opts ...grpc.CallOption) (*ModerationAction, error)
+	ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*ModerationAction, error)
+	ListModerationActions(ctx context.Context, in *ListModerationActionsRequest, opts ...grpc.CallOption) (*ListModerationActionsResponse, error)
 }
 
 type postServiceClient struct {
@@ -122,6 +136,26 @@ func (c *postServiceClient) ListPublicPosts(ctx context.Context, in *ListPublicP
 	return out, nil
 }
 
+func (c *postServiceClient) GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeedResponse)
+	err := c.cc.Invoke(ctx, PostService_GetFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostsResponse)
+	err := c.cc.Invoke(ctx, PostService_SearchPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) ViewPost(ctx context.Context, in *ViewPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -132,9 +166,9 @@ func (c *postServiceClient) ViewPost(ctx context.Context, in *ViewPostRequest, o
 	return out, nil
 }
 
-func (c *postServiceClient) LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *postServiceClient) LikePost(ctx context.Context, in *LikePostRequest, opts ...grpc.CallOption) (*LikeStateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(LikeStateResponse)
 	err := c.cc.Invoke(ctx, PostService_LikePost_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -142,9 +176,9 @@ func (c *postServiceClient) LikePost(ctx context.Context, in *LikePostRequest, o
 	return out, nil
 }
 
-func (c *postServiceClient) UnlikePost(ctx context.Context, in *UnlikePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *postServiceClient) UnlikePost(ctx context.Context, in *UnlikePostRequest, opts ...grpc.CallOption) (*LikeStateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(LikeStateResponse)
 	err := c.cc.Invoke(ctx, PostService_UnlikePost_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -192,6 +226,36 @@ func (c *postServiceClient) ListReplies(ctx context.Context, in *ListRepliesRequ
 	return out, nil
 }
 
+func (c *postServiceClient) ModeratePost(ctx context.Context, in *ModeratePostRequest, opts ...grpc.CallOption) (*ModerationAction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerationAction)
+	err := c.cc.Invoke(ctx, PostService_ModeratePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*ModerationAction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerationAction)
+	err := c.cc.Invoke(ctx, PostService_ModerateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ListModerationActions(ctx context.Context, in *ListModerationActionsRequest, opts ...grpc.CallOption) (*ListModerationActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModerationActionsResponse)
+	err := c.cc.Invoke(ctx, PostService_ListModerationActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility.
@@ -202,13 +266,22 @@ type PostServiceServer interface {
 	DeletePost(context.Context, *DeletePostRequest) (*emptypb.Empty, error)
 	ListMyPosts(context.Context, *ListMyPostsRequest) (*ListPostsResponse, error)
 	ListPublicPosts(context.Context, *ListPublicPostsRequest) (*ListPostsResponse, error)
+	// Recent posts of the authors the caller follows, newest first.
+	GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error)
+	// Full-text search over the titles and descriptions of the posts the
+	// caller can see.
+	SearchPosts(context.Context, *SearchPostsRequest) (*ListPostsResponse, error)
 	ViewPost(context.Context, *ViewPostRequest) (*emptypb.Empty, error)
-	LikePost(context.Context, *LikePostRequest) (*emptypb.Empty, error)
-	UnlikePost(context.Context, *UnlikePostRequest) (*emptypb.Empty, error)
+	LikePost(context.Context, *LikePostRequest) (*LikeStateResponse, error)
+	UnlikePost(context.Context, *UnlikePostRequest) (*LikeStateResponse, error)
 	AddComment(context.Context, *AddCommentRequest) (*CommentResponse, error)
 	AddReply(context.Context, *AddReplyRequest) (*ReplyResponse, error)
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
 	ListReplies(context.Context, *ListRepliesRequest) (*ListRepliesResponse, error)
+	// Moderation, available to moderators and admins only.
+	ModeratePost(context.Context, *ModeratePostRequest) (*ModerationAction, error)
+	ModerateComment(context.Context, *ModerateCommentRequest) (*ModerationAction, error)
+	ListModerationActions(context.Context, *ListModerationActionsRequest) (*ListModerationActionsResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -237,13 +310,19 @@ func (UnimplementedPostServiceServer) ListMyPosts(context.Context, *ListMyPostsR
 func (UnimplementedPostServiceServer) ListPublicPosts(context.Context, *ListPublicPostsRequest) (*ListPostsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPublicPosts not implemented")
 }
+func (UnimplementedPostServiceServer) GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedPostServiceServer) SearchPosts(context.Context, *SearchPostsRequest) (*ListPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPosts not implemented")
+}
 func (UnimplementedPostServiceServer) ViewPost(context.Context, *ViewPostRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ViewPost not implemented")
 }
-func (UnimplementedPostServiceServer) LikePost(context.Context, *LikePostRequest) (*emptypb.Empty, error) {
+func (UnimplementedPostServiceServer) LikePost(context.Context, *LikePostRequest) (*LikeStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LikePost not implemented")
 }
-func (UnimplementedPostServiceServer) UnlikePost(context.Context, *UnlikePostRequest) (*emptypb.Empty, error) {
+func (UnimplementedPostServiceServer) UnlikePost(context.Context, *UnlikePostRequest) (*LikeStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnlikePost not implemented")
 }
 func (UnimplementedPostServiceServer) AddComment(context.Context, *AddCommentRequest) (*CommentResponse, error) {
@@ -258,6 +337,15 @@ func (UnimplementedPostServiceServer) ListComments(context.Context, *ListComment
 func (UnimplementedPostServiceServer) ListReplies(context.Context, *ListRepliesRequest) (*ListRepliesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListReplies not implemented")
 }
+func (UnimplementedPostServiceServer) ModeratePost(context.Context, *ModeratePostRequest) (*ModerationAction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModeratePost not implemented")
+}
+func (UnimplementedPostServiceServer) ModerateComment(context.Context, *ModerateCommentRequest) (*ModerationAction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModerateComment not implemented")
+}
+func (UnimplementedPostServiceServer) ListModerationActions(context.Context, *ListModerationActionsRequest) (*ListModerationActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModerationActions not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
 
@@ -387,6 +475,42 @@ func _PostService_ListPublicPosts_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetFeed(ctx, req.(*GetFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SearchPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SearchPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SearchPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SearchPosts(ctx, req.(*SearchPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_ViewPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ViewPostRequest)
 	if err := dec(in); err != nil {
@@ -513,6 +637,60 @@ func _PostService_ListReplies_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_ModeratePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModeratePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ModeratePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ModeratePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ModeratePost(ctx, req.(*ModeratePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ModerateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ModerateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ModerateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ModerateComment(ctx, req.(*ModerateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ListModerationActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModerationActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ListModerationActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ListModerationActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ListModerationActions(ctx, req.(*ListModerationActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -544,6 +722,14 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPublicPosts",
 			Handler:    _PostService_ListPublicPosts_Handler,
 		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _PostService_GetFeed_Handler,
+		},
+		{
+			MethodName: "SearchPosts",
+			Handler:    _PostService_SearchPosts_Handler,
+		},
 		{
 			MethodName: "ViewPost",
 			Handler:    _PostService_ViewPost_Handler,
@@ -572,6 +758,18 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListReplies",
 			Handler:    _PostService_ListReplies_Handler,
 		},
+		{
+			MethodName: "ModeratePost",
+			Handler:    _PostService_ModeratePost_Handler,
+		},
+		{
+			MethodName: "ModerateComment",
+			Handler:    _PostService_ModerateComment_Handler,
+		},
+		{
+			MethodName: "ListModerationActions",
+			Handler:    _PostService_ListModerationActions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post/post.proto",
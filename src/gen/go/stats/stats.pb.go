@@ -0,0 +1,901 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: stats/stats.proto
+
+package stats
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Metric int32
+
+const (
+	Metric_METRIC_UNSPECIFIED Metric = 0
+	Metric_METRIC_VIEWS       Metric = 1
+	Metric_METRIC_LIKES       Metric = 2
+	Metric_METRIC_COMMENTS    Metric = 3
+)
+
+// Enum value maps for Metric.
+var (
+	Metric_name = map[int32]string{
+		0: "METRIC_UNSPECIFIED",
+		1: "METRIC_VIEWS",
+		2: "METRIC_LIKES",
+		3: "METRIC_COMMENTS",
+	}
+	Metric_value = map[string]int32{
+		"METRIC_UNSPECIFIED": 0,
+		"METRIC_VIEWS":       1,
+		"METRIC_LIKES":       2,
+		"METRIC_COMMENTS":    3,
+	}
+)
+
+func (x Metric) Enum() *Metric {
+	p := new(Metric)
+	*p = x
+	return p
+}
+
+func (x Metric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Metric) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_stats_proto_enumTypes[0].Descriptor()
+}
+
+func (Metric) Type() protoreflect.EnumType {
+	return &file_stats_stats_proto_enumTypes[0]
+}
+
+func (x Metric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Metric.Descriptor instead.
+func (Metric) EnumDescriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{0}
+}
+
+type GetPostStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostStatsRequest) Reset() {
+	*x = GetPostStatsRequest{}
+	mi := &file_stats_stats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostStatsRequest) ProtoMessage() {}
+
+func (x *GetPostStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPostStatsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type PostStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Views         int64                  `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	Likes         int64                  `protobuf:"varint,3,opt,name=likes,proto3" json:"likes,omitempty"`
+	Comments      int64                  `protobuf:"varint,4,opt,name=comments,proto3" json:"comments,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStats) Reset() {
+	*x = PostStats{}
+	mi := &file_stats_stats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStats) ProtoMessage() {}
+
+func (x *PostStats) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStats.ProtoReflect.Descriptor instead.
+func (*PostStats) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostStats) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostStats) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *PostStats) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *PostStats) GetComments() int64 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+func (x *PostStats) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type PostStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *PostStats             `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStatsResponse) Reset() {
+	*x = PostStatsResponse{}
+	mi := &file_stats_stats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStatsResponse) ProtoMessage() {}
+
+func (x *PostStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStatsResponse.ProtoReflect.Descriptor instead.
+func (*PostStatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PostStatsResponse) GetStats() *PostStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetPostTimelineRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Metric Metric                 `protobuf:"varint,2,opt,name=metric,proto3,enum=stats.Metric" json:"metric,omitempty"`
+	// Inclusive date range in YYYY-MM-DD (UTC). Defaults to the last 30 days.
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostTimelineRequest) Reset() {
+	*x = GetPostTimelineRequest{}
+	mi := &file_stats_stats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostTimelineRequest) ProtoMessage() {}
+
+func (x *GetPostTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetPostTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPostTimelineRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPostTimelineRequest) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *GetPostTimelineRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetPostTimelineRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type DailyCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyCount) Reset() {
+	*x = DailyCount{}
+	mi := &file_stats_stats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyCount) ProtoMessage() {}
+
+func (x *DailyCount) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyCount.ProtoReflect.Descriptor instead.
+func (*DailyCount) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DailyCount) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PostTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Metric        Metric                 `protobuf:"varint,2,opt,name=metric,proto3,enum=stats.Metric" json:"metric,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Points        []*DailyCount          `protobuf:"bytes,5,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostTimelineResponse) Reset() {
+	*x = PostTimelineResponse{}
+	mi := &file_stats_stats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostTimelineResponse) ProtoMessage() {}
+
+func (x *PostTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostTimelineResponse.ProtoReflect.Descriptor instead.
+func (*PostTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PostTimelineResponse) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostTimelineResponse) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *PostTimelineResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *PostTimelineResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *PostTimelineResponse) GetPoints() []*DailyCount {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type TopRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Metric Metric                 `protobuf:"varint,1,opt,name=metric,proto3,enum=stats.Metric" json:"metric,omitempty"`
+	// Inclusive date range in YYYY-MM-DD (UTC). Defaults to the last 30 days.
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Defaults to 10, at most 100.
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRequest) Reset() {
+	*x = TopRequest{}
+	mi := &file_stats_stats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRequest) ProtoMessage() {}
+
+func (x *TopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRequest.ProtoReflect.Descriptor instead.
+func (*TopRequest) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TopRequest) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *TopRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TopRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TopRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopPost struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// Empty if the author of the post is not known to the stats service yet.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Count         int64  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPost) Reset() {
+	*x = TopPost{}
+	mi := &file_stats_stats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPost) ProtoMessage() {}
+
+func (x *TopPost) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPost.ProtoReflect.Descriptor instead.
+func (*TopPost) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TopPost) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *TopPost) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TopPost) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TopPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        Metric                 `protobuf:"varint,1,opt,name=metric,proto3,enum=stats.Metric" json:"metric,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Posts         []*TopPost             `protobuf:"bytes,4,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPostsResponse) Reset() {
+	*x = TopPostsResponse{}
+	mi := &file_stats_stats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPostsResponse) ProtoMessage() {}
+
+func (x *TopPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPostsResponse.ProtoReflect.Descriptor instead.
+func (*TopPostsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TopPostsResponse) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *TopPostsResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TopPostsResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TopPostsResponse) GetPosts() []*TopPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type TopUser struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Number of events received by all posts of the user.
+	Count         int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopUser) Reset() {
+	*x = TopUser{}
+	mi := &file_stats_stats_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopUser) ProtoMessage() {}
+
+func (x *TopUser) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopUser.ProtoReflect.Descriptor instead.
+func (*TopUser) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TopUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TopUser) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TopUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        Metric                 `protobuf:"varint,1,opt,name=metric,proto3,enum=stats.Metric" json:"metric,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Users         []*TopUser             `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopUsersResponse) Reset() {
+	*x = TopUsersResponse{}
+	mi := &file_stats_stats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopUsersResponse) ProtoMessage() {}
+
+func (x *TopUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_stats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopUsersResponse.ProtoReflect.Descriptor instead.
+func (*TopUsersResponse) Descriptor() ([]byte, []int) {
+	return file_stats_stats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopUsersResponse) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *TopUsersResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TopUsersResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TopUsersResponse) GetUsers() []*TopUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+var File_stats_stats_proto protoreflect.FileDescriptor
+
+const file_stats_stats_proto_rawDesc = "" +
+	"\n" +
+	"\x11stats/stats.proto\x12\x05stats\x1a\x1fgoogle/protobuf/timestamp.proto\".\n" +
+	"\x13GetPostStatsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"\xa7\x01\n" +
+	"\tPostStats\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05views\x18\x02 \x01(\x03R\x05views\x12\x14\n" +
+	"\x05likes\x18\x03 \x01(\x03R\x05likes\x12\x1a\n" +
+	"\bcomments\x18\x04 \x01(\x03R\bcomments\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\";\n" +
+	"\x11PostStatsResponse\x12&\n" +
+	"\x05stats\x18\x01 \x01(\v2\x10.stats.PostStatsR\x05stats\"|\n" +
+	"\x16GetPostTimelineRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12%\n" +
+	"\x06metric\x18\x02 \x01(\x0e2\r.stats.MetricR\x06metric\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"6\n" +
+	"\n" +
+	"DailyCount\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xa5\x01\n" +
+	"\x14PostTimelineResponse\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12%\n" +
+	"\x06metric\x18\x02 \x01(\x0e2\r.stats.MetricR\x06metric\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12)\n" +
+	"\x06points\x18\x05 \x03(\v2\x11.stats.DailyCountR\x06points\"m\n" +
+	"\n" +
+	"TopRequest\x12%\n" +
+	"\x06metric\x18\x01 \x01(\x0e2\r.stats.MetricR\x06metric\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"Q\n" +
+	"\aTopPost\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"\x83\x01\n" +
+	"\x10TopPostsResponse\x12%\n" +
+	"\x06metric\x18\x01 \x01(\x0e2\r.stats.MetricR\x06metric\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12$\n" +
+	"\x05posts\x18\x04 \x03(\v2\x0e.stats.TopPostR\x05posts\"8\n" +
+	"\aTopUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\x83\x01\n" +
+	"\x10TopUsersResponse\x12%\n" +
+	"\x06metric\x18\x01 \x01(\x0e2\r.stats.MetricR\x06metric\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12$\n" +
+	"\x05users\x18\x04 \x03(\v2\x0e.stats.TopUserR\x05users*Y\n" +
+	"\x06Metric\x12\x16\n" +
+	"\x12METRIC_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fMETRIC_VIEWS\x10\x01\x12\x10\n" +
+	"\fMETRIC_LIKES\x10\x02\x12\x13\n" +
+	"\x0fMETRIC_COMMENTS\x10\x032\x93\x02\n" +
+	"\fStatsService\x12D\n" +
+	"\fGetPostStats\x12\x1a.stats.GetPostStatsRequest\x1a\x18.stats.PostStatsResponse\x12M\n" +
+	"\x0fGetPostTimeline\x12\x1d.stats.GetPostTimelineRequest\x1a\x1b.stats.PostTimelineResponse\x126\n" +
+	"\bTopPosts\x12\x11.stats.TopRequest\x1a\x17.stats.TopPostsResponse\x126\n" +
+	"\bTopUsers\x12\x11.stats.TopRequest\x1a\x17.stats.TopUsersResponseB4Z2github.com/zahartd/social-network/src/gen/go/statsb\x06proto3"
+
+var (
+	file_stats_stats_proto_rawDescOnce sync.Once
+	file_stats_stats_proto_rawDescData []byte
+)
+
+func file_stats_stats_proto_rawDescGZIP() []byte {
+	file_stats_stats_proto_rawDescOnce.Do(func() {
+		file_stats_stats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stats_stats_proto_rawDesc), len(file_stats_stats_proto_rawDesc)))
+	})
+	return file_stats_stats_proto_rawDescData
+}
+
+var file_stats_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_stats_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_stats_stats_proto_goTypes = []any{
+	(Metric)(0),                    // 0: stats.Metric
+	(*GetPostStatsRequest)(nil),    // 1: stats.GetPostStatsRequest
+	(*PostStats)(nil),              // 2: stats.PostStats
+	(*PostStatsResponse)(nil),      // 3: stats.PostStatsResponse
+	(*GetPostTimelineRequest)(nil), // 4: stats.GetPostTimelineRequest
+	(*DailyCount)(nil),             // 5: stats.DailyCount
+	(*PostTimelineResponse)(nil),   // 6: stats.PostTimelineResponse
+	(*TopRequest)(nil),             // 7: stats.TopRequest
+	(*TopPost)(nil),                // 8: stats.TopPost
+	(*TopPostsResponse)(nil),       // 9: stats.TopPostsResponse
+	(*TopUser)(nil),                // 10: stats.TopUser
+	(*TopUsersResponse)(nil),       // 11: stats.TopUsersResponse
+	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+}
+var file_stats_stats_proto_depIdxs = []int32{
+	12, // 0: stats.PostStats.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: stats.PostStatsResponse.stats:type_name -> stats.PostStats
+	0,  // 2: stats.GetPostTimelineRequest.metric:type_name -> stats.Metric
+	0,  // 3: stats.PostTimelineResponse.metric:type_name -> stats.Metric
+	5,  // 4: stats.PostTimelineResponse.points:type_name -> stats.DailyCount
+	0,  // 5: stats.TopRequest.metric:type_name -> stats.Metric
+	0,  // 6: stats.TopPostsResponse.metric:type_name -> stats.Metric
+	8,  // 7: stats.TopPostsResponse.posts:type_name -> stats.TopPost
+	0,  // 8: stats.TopUsersResponse.metric:type_name -> stats.Metric
+	10, // 9: stats.TopUsersResponse.users:type_name -> stats.TopUser
+	1,  // 10: stats.StatsService.GetPostStats:input_type -> stats.GetPostStatsRequest
+	4,  // 11: stats.StatsService.GetPostTimeline:input_type -> stats.GetPostTimelineRequest
+	7,  // 12: stats.StatsService.TopPosts:input_type -> stats.TopRequest
+	7,  // 13: stats.StatsService.TopUsers:input_type -> stats.TopRequest
+	3,  // 14: stats.StatsService.GetPostStats:output_type -> stats.PostStatsResponse
+	6,  // 15: stats.StatsService.GetPostTimeline:output_type -> stats.PostTimelineResponse
+	9,  // 16: stats.StatsService.TopPosts:output_type -> stats.TopPostsResponse
+	11, // 17: stats.StatsService.TopUsers:output_type -> stats.TopUsersResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_stats_stats_proto_init() }
+func file_stats_stats_proto_init() {
+	if File_stats_stats_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_stats_proto_rawDesc), len(file_stats_stats_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stats_stats_proto_goTypes,
+		DependencyIndexes: file_stats_stats_proto_depIdxs,
+		EnumInfos:         file_stats_stats_proto_enumTypes,
+		MessageInfos:      file_stats_stats_proto_msgTypes,
+	}.Build()
+	File_stats_stats_proto = out.File
+	file_stats_stats_proto_goTypes = nil
+	file_stats_stats_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: stats/stats.proto
+
+package stats
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatsService_GetPostStats_FullMethodName    = "/stats.StatsService/GetPostStats"
+	StatsService_GetPostTimeline_FullMethodName = "/stats.StatsService/GetPostTimeline"
+	StatsService_TopPosts_FullMethodName        = "/stats.StatsService/TopPosts"
+	StatsService_TopUsers_FullMethodName        = "/stats.StatsService/TopUsers"
+)
+
+// StatsServiceClient is the client API for StatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StatsServiceClient interface {
+	GetPostStats(ctx context.Context, in *GetPostStatsRequest, opts ...grpc.CallOption) (*PostStatsResponse, error)
+	GetPostTimeline(ctx context.Context, in *GetPostTimelineRequest, opts ...grpc.CallOption) (*PostTimelineResponse, error)
+	TopPosts(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopPostsResponse, error)
+	TopUsers(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopUsersResponse, error)
+}
+
+type statsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsServiceClient(cc grpc.ClientConnInterface) StatsServiceClient {
+	return &statsServiceClient{cc}
+}
+
+func (c *statsServiceClient) GetPostStats(ctx context.Context, in *GetPostStatsRequest, opts ...grpc.CallOption) (*PostStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostStatsResponse)
+	err := c.cc.Invoke(ctx, StatsService_GetPostStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetPostTimeline(ctx context.Context, in *GetPostTimelineRequest, opts ...grpc.CallOption) (*PostTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostTimelineResponse)
+	err := c.cc.Invoke(ctx, StatsService_GetPostTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) TopPosts(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopPostsResponse)
+	err := c.cc.Invoke(ctx, StatsService_TopPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) TopUsers(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopUsersResponse)
+	err := c.cc.Invoke(ctx, StatsService_TopUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatsServiceServer is the server API for StatsService service.
+// All implementations must embed UnimplementedStatsServiceServer
+// for forward compatibility.
+type StatsServiceServer interface {
+	GetPostStats(context.Context, *GetPostStatsRequest) (*PostStatsResponse, error)
+	GetPostTimeline(context.Context, *GetPostTimelineRequest) (*PostTimelineResponse, error)
+	TopPosts(context.Context, *TopRequest) (*TopPostsResponse, error)
+	TopUsers(context.Context, *TopRequest) (*TopUsersResponse, error)
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+// UnimplementedStatsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatsServiceServer struct{}
+
+func (UnimplementedStatsServiceServer) GetPostStats(context.Context, *GetPostStatsRequest) (*PostStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostStats not implemented")
+}
+func (UnimplementedStatsServiceServer) GetPostTimeline(context.Context, *GetPostTimelineRequest) (*PostTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostTimeline not implemented")
+}
+func (UnimplementedStatsServiceServer) TopPosts(context.Context, *TopRequest) (*TopPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopPosts not implemented")
+}
+func (UnimplementedStatsServiceServer) TopUsers(context.Context, *TopRequest) (*TopUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopUsers not implemented")
+}
+func (UnimplementedStatsServiceServer) mustEmbedUnimplementedStatsServiceServer() {}
+func (UnimplementedStatsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsServiceServer will
+// result in compilation errors.
+type UnsafeStatsServiceServer interface {
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+func RegisterStatsServiceServer(s grpc.ServiceRegistrar, srv StatsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatsService_ServiceDesc, srv)
+}
+
+func _StatsService_GetPostStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetPostStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetPostStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetPostStats(ctx, req.(*GetPostStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetPostTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetPostTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetPostTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetPostTimeline(ctx, req.(*GetPostTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_TopPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).TopPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_TopPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).TopPosts(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_TopUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).TopUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_TopUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).TopUsers(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatsService_ServiceDesc is the grpc.ServiceDesc for StatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stats.StatsService",
+	HandlerType: (*StatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPostStats",
+			Handler:    _StatsService_GetPostStats_Handler,
+		},
+		{
+			MethodName: "GetPostTimeline",
+			Handler:    _StatsService_GetPostTimeline_Handler,
+		},
+		{
+			MethodName: "TopPosts",
+			Handler:    _StatsService_TopPosts_Handler,
+		},
+		{
+			MethodName: "TopUsers",
+			Handler:    _StatsService_TopUsers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stats/stats.proto",
+}
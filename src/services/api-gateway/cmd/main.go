@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/zahartd/social-network/src/services/api-gateway/internal/auth"
 	"github.com/zahartd/social-network/src/services/api-gateway/internal/client"
@@ -16,6 +17,7 @@ func main() {
 	}
 
 	postClient := client.InitPostServiceClient()
+	statsClient := client.InitStatsServiceClient()
 
 	userServiceURLStr := os.Getenv("USER_SERVICE_URL")
 	if userServiceURLStr == "" {
@@ -26,7 +28,16 @@ func main() {
 		log.Fatalf("Invalid USER_SERVICE_URL: %v", err)
 	}
 
-	r := router.SetupRouter(postClient, userServiceURL)
+	sessionCacheTTL := 5 * time.Second
+	if ttl := os.Getenv("SESSION_CACHE_TTL"); ttl != "" {
+		sessionCacheTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatalf("Invalid SESSION_CACHE_TTL: %v", err)
+		}
+	}
+	sessions := auth.NewIntrospector(userServiceURL, sessionCacheTTL)
+
+	r := router.SetupRouter(postClient, statsClient, userServiceURL, sessions)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -2,14 +2,19 @@ package auth
 
 import (
 	"errors"
+	"log"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func Middleware() gin.HandlerFunc {
+// Middleware accepts requests with a valid access token whose session is
+// still active. If sessions cannot be checked the request is refused with
+// 503 rather than let through.
+func Middleware(sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr := c.GetHeader("Authorization")
 		if tokenStr == "" {
@@ -42,8 +47,45 @@ func Middleware() gin.HandlerFunc {
 			return
 		}
 
+		active, err := sessions.Active(c.Request.Context(), claims.UserID, bearerToken(tokenStr))
+		if err != nil {
+			log.Printf("Could not check session of %s: %v", claims.UserID, err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Could not check session"})
+			return
+		}
+		if !active {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			return
+		}
+
 		c.Set("userID", claims.UserID)
+		c.Set("roles", claims.Roles)
+		c.Set("emailVerified", claims.EmailVerified)
 
 		c.Next()
 	}
 }
+
+// HasRole reports whether the authenticated user's token carries one of
+// roles.
+func HasRole(c *gin.Context, roles ...string) bool {
+	held := c.GetStringSlice("roles")
+	for _, role := range roles {
+		if slices.Contains(held, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole rejects requests whose token carries none of roles. Must run
+// after Middleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasRole(c, roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+			return
+		}
+		c.Next()
+	}
+}
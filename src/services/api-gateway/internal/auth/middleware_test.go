@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name       string
+		roles      []string
+		wantStatus int
+	}{
+		{"admin", []string{RoleUser, RoleAdmin}, http.StatusOK},
+		{"plain user", []string{RoleUser}, http.StatusForbidden},
+		{"no roles", nil, http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/",
+				func(c *gin.Context) { c.Set("roles", tc.roles) },
+				RequireRole(RoleAdmin),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
+
+type stubSessions struct {
+	active bool
+	err    error
+}
+
+func (s stubSessions) Active(context.Context, string, string) (bool, error) {
+	return s.active, s.err
+}
+
+func TestMiddleware_Sessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	privKey := generateTestKeys(t)
+	setupTestPublicKey(t, privKey)
+	token := createTestToken(t, privKey, &UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-12345",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+
+	testCases := []struct {
+		name       string
+		sessions   stubSessions
+		wantStatus int
+	}{
+		{"active", stubSessions{active: true}, http.StatusOK},
+		{"revoked", stubSessions{active: false}, http.StatusUnauthorized},
+		{"check failed", stubSessions{err: errors.New("connection refused")}, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", Middleware(tc.sessions), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCachedSessions bounds the introspection cache; past it expired entries
+// are dropped, and if that is not enough the whole cache is.
+const maxCachedSessions = 10000
+
+// SessionChecker reports whether the session behind an access token is
+// still active.
+type SessionChecker interface {
+	Active(ctx context.Context, userID, token string) (bool, error)
+}
+
+type cachedSession struct {
+	userID    string
+	active    bool
+	expiresAt time.Time
+}
+
+// Introspector asks user-service whether a token's session is alive and
+// caches the answer for ttl, so a logout reaches other gateway instances
+// within ttl. Tokens logged out through this instance are dropped at once
+// with Forget, and all tokens of a user whose sessions were revoked through
+// it with ForgetUser.
+type Introspector struct {
+	endpoint string
+	client   *http.Client
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedSession
+}
+
+func NewIntrospector(userServiceURL *url.URL, ttl time.Duration) *Introspector {
+	return &Introspector{
+		endpoint: userServiceURL.JoinPath("/internal/token/introspect").String(),
+		client:   &http.Client{Timeout: 2 * time.Second},
+		ttl:      ttl,
+		cache:    make(map[string]cachedSession),
+	}
+}
+
+func (i *Introspector) Active(ctx context.Context, userID, token string) (bool, error) {
+	now := time.Now()
+	i.mu.Lock()
+	entry, ok := i.cache[token]
+	i.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.active, nil
+	}
+
+	active, err := i.introspect(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.cache) >= maxCachedSessions {
+		for key, e := range i.cache {
+			if !now.Before(e.expiresAt) {
+				delete(i.cache, key)
+			}
+		}
+		if len(i.cache) >= maxCachedSessions {
+			clear(i.cache)
+		}
+	}
+	i.cache[token] = cachedSession{userID: userID, active: active, expiresAt: now.Add(i.ttl)}
+	return active, nil
+}
+
+func (i *Introspector) introspect(ctx context.Context, token string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, i.endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("session introspection failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("session introspection failed: user-service returned %s", resp.Status)
+	}
+	var body struct {
+		Active bool `json:"active"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, fmt.Errorf("session introspection failed: %w", err)
+	}
+	return body.Active, nil
+}
+
+// Forget drops the cached answer for token.
+func (i *Introspector) Forget(token string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.cache, token)
+}
+
+// ForgetUser drops the cached answers for all tokens of userID.
+func (i *Introspector) ForgetUser(userID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for key, e := range i.cache {
+		if e.userID == userID {
+			delete(i.cache, key)
+		}
+	}
+}
+
+// ForgetSessionOnSuccess drops the request's token from the cache once the
+// wrapped handler succeeds. It wraps the logout and account deletion routes.
+func ForgetSessionOnSuccess(i *Introspector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if status := c.Writer.Status(); status >= 200 && status < 300 {
+			i.Forget(bearerToken(c.GetHeader("Authorization")))
+		}
+	}
+}
+
+// ForgetUserSessionsOnSuccess drops the cached tokens of the authenticated
+// user once the wrapped handler succeeds. It wraps the routes that revoke
+// sessions other than the caller's, whose tokens the gateway cannot tell
+// apart. Must run after Middleware.
+func ForgetUserSessionsOnSuccess(i *Introspector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if status := c.Writer.Status(); status >= 200 && status < 300 {
+			i.ForgetUser(c.GetString("userID"))
+		}
+	}
+}
+
+func bearerToken(header string) string {
+	return strings.TrimSpace(strings.TrimPrefix(header, "Bearer"))
+}
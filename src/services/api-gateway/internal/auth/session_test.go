@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIntrospector(t *testing.T, ttl time.Duration, handler http.HandlerFunc) (*Introspector, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "/internal/token/introspect", r.URL.Path)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return NewIntrospector(target, ttl), &calls
+}
+
+func TestIntrospector_CachesAnswer(t *testing.T) {
+	var active atomic.Bool
+	active.Store(true)
+	i, calls := newTestIntrospector(t, time.Minute, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		if active.Load() {
+			w.Write([]byte(`{"active":true}`))
+		} else {
+			w.Write([]byte(`{"active":false}`))
+		}
+	})
+
+	for range 3 {
+		ok, err := i.Active(context.Background(), "user-1", "tok")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	active.Store(false)
+	i.Forget("tok")
+	ok, err := i.Active(context.Background(), "user-1", "tok")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIntrospector_ForgetUser(t *testing.T) {
+	i, calls := newTestIntrospector(t, time.Minute, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"active":true}`))
+	})
+
+	for _, call := range []struct{ userID, token string }{{"user-1", "tok"}, {"user-1", "other"}, {"user-2", "tok2"}} {
+		_, err := i.Active(context.Background(), call.userID, call.token)
+		require.NoError(t, err)
+	}
+	i.ForgetUser("user-1")
+	for _, call := range []struct{ userID, token string }{{"user-1", "tok"}, {"user-1", "other"}, {"user-2", "tok2"}} {
+		_, err := i.Active(context.Background(), call.userID, call.token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestIntrospector_ExpiresCache(t *testing.T) {
+	i, calls := newTestIntrospector(t, time.Nanosecond, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"active":true}`))
+	})
+
+	for range 2 {
+		_, err := i.Active(context.Background(), "user-1", "tok")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIntrospector_ServerError(t *testing.T) {
+	i, _ := newTestIntrospector(t, time.Minute, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ok, err := i.Active(context.Background(), "user-1", "tok")
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
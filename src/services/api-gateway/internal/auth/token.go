@@ -5,15 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var rsaPublicKey *rsa.PublicKey
 
+// Roles known to user-service.
+const (
+	RoleUser         = "user"
+	RoleApprovedUser = "approved_user"
+	RoleModerator    = "moderator"
+	RoleAdmin        = "admin"
+)
+
 type UserClaims struct {
-	UserID string `json:"-"`
+	UserID        string   `json:"-"`
+	Roles         []string `json:"roles,omitempty"`
+	EmailVerified bool     `json:"email_verified"`
 	jwt.RegisteredClaims
 }
 
@@ -38,7 +47,7 @@ func LoadRSAPublicKey() error {
 }
 
 func validateJWT(tokenStr string) (*UserClaims, error) {
-	tokenStr = strings.TrimSpace(strings.TrimPrefix(tokenStr, "Bearer"))
+	tokenStr = bearerToken(tokenStr)
 	if tokenStr == "" {
 		return nil, errors.New("token string is empty after trimming Bearer prefix")
 	}
@@ -250,3 +250,20 @@ func TestValidateJWT_KeyNotLoaded(t *testing.T) {
 	assert.EqualError(t, err, "authentication service is not properly configured (missing public key)")
 	assert.Nil(t, parsedClaims)
 }
+
+func TestValidateJWT_Roles(t *testing.T) {
+	privKey := generateTestKeys(t)
+	setupTestPublicKey(t, privKey)
+
+	mapClaims := jwt.MapClaims{
+		"sub":   "user-roles",
+		"roles": []string{RoleUser, RoleModerator},
+		"exp":   time.Now().Add(1 * time.Hour).Unix(),
+	}
+	tokenStr := createTestToken(t, privKey, mapClaims)
+
+	parsedClaims, err := validateJWT("Bearer " + tokenStr)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{RoleUser, RoleModerator}, parsedClaims.Roles)
+}
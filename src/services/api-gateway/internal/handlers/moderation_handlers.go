@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/api-gateway/internal/utils"
+)
+
+var moderationActionsByName = map[string]postpb.ModerationActionType{
+	"hide":   postpb.ModerationActionType_MODERATION_ACTION_HIDE,
+	"unhide": postpb.ModerationActionType_MODERATION_ACTION_UNHIDE,
+	"delete": postpb.ModerationActionType_MODERATION_ACTION_DELETE,
+}
+
+type moderationRequest struct {
+	Action string `json:"action" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+func moderationActionJSON(a *postpb.ModerationAction) gin.H {
+	action := ""
+	for name, value := range moderationActionsByName {
+		if value == a.GetAction() {
+			action = name
+		}
+	}
+	return gin.H{
+		"id":           a.GetId(),
+		"target_type":  a.GetTargetType(),
+		"target_id":    a.GetTargetId(),
+		"post_id":      a.GetPostId(),
+		"action":       action,
+		"reason":       a.GetReason(),
+		"moderator_id": a.GetModeratorId(),
+		"created_at":   a.GetCreatedAt().AsTime(),
+	}
+}
+
+func bindModerationRequest(c *gin.Context) (postpb.ModerationActionType, string, bool) {
+	var req moderationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return 0, "", false
+	}
+	action, ok := moderationActionsByName[req.Action]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action must be one of: hide, unhide, delete"})
+		return 0, "", false
+	}
+	return action, req.Reason, true
+}
+
+func (h *PostHandler) ModeratePost(c *gin.Context) {
+	targetPostID := c.Param("postID")
+	if err := utils.ValidatePostID(targetPostID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	action, reason, ok := bindModerationRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.postClient.ModeratePost(ctx, &postpb.ModeratePostRequest{
+		PostId: targetPostID,
+		Action: action,
+		Reason: reason,
+	})
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, moderationActionJSON(res))
+}
+
+func (h *PostHandler) ModerateComment(c *gin.Context) {
+	targetPostID := c.Param("postID")
+	if err := utils.ValidatePostID(targetPostID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	targetCommentID := c.Param("commentID")
+	if err := utils.ValidateCommentID(targetCommentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	action, reason, ok := bindModerationRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.postClient.ModerateComment(ctx, &postpb.ModerateCommentRequest{
+		PostId:    targetPostID,
+		CommentId: targetCommentID,
+		Action:    action,
+		Reason:    reason,
+	})
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, moderationActionJSON(res))
+}
+
+func (h *PostHandler) ListModerationActions(c *gin.Context) {
+	targetPostID := c.Param("postID")
+	if err := utils.ValidatePostID(targetPostID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, pageSize, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.postClient.ListModerationActions(ctx, &postpb.ListModerationActionsRequest{
+		PostId:   targetPostID,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	})
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+	actions := make([]gin.H, 0, len(res.GetActions()))
+	for _, a := range res.GetActions() {
+		actions = append(actions, moderationActionJSON(a))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"actions":     actions,
+		"total_count": res.GetTotalCount(),
+		"page":        res.GetPage(),
+		"page_size":   res.GetPageSize(),
+	})
+}
@@ -16,7 +16,11 @@ import (
 	"github.com/zahartd/social-network/src/services/api-gateway/internal/utils"
 )
 
-const UserIDMetadataKey = "x-user-id"
+const (
+	UserIDMetadataKey        = "x-user-id"
+	UserRolesMetadataKey     = "x-user-roles"
+	EmailVerifiedMetadataKey = "x-email-verified"
+)
 
 type PostHandler struct {
 	postClient postpb.PostServiceClient
@@ -29,6 +33,62 @@ func NewPostHandler(client postpb.PostServiceClient) *PostHandler {
 	return &PostHandler{postClient: client}
 }
 
+var visibilitiesByName = map[string]postpb.Visibility{
+	"public":        postpb.Visibility_VISIBILITY_PUBLIC,
+	"followers":     postpb.Visibility_VISIBILITY_FOLLOWERS,
+	"close_friends": postpb.Visibility_VISIBILITY_CLOSE_FRIENDS,
+	"only_me":       postpb.Visibility_VISIBILITY_ONLY_ME,
+}
+
+// parseVisibility maps the visibility name of a request body to the enum.
+// An empty name leaves it unspecified, so is_private decides.
+func parseVisibility(c *gin.Context, name string) (postpb.Visibility, bool) {
+	if name == "" {
+		return postpb.Visibility_VISIBILITY_UNSPECIFIED, true
+	}
+	visibility, ok := visibilitiesByName[name]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "visibility must be one of: public, followers, close_friends, only_me"})
+		return 0, false
+	}
+	return visibility, true
+}
+
+// postJSON renders a post with its visibility by name, the way requests
+// send it, instead of the enum number.
+type postJSON struct {
+	*postpb.Post
+	Visibility string `json:"visibility"`
+}
+
+func newPostJSON(p *postpb.Post) postJSON {
+	view := postJSON{Post: p}
+	for name, value := range visibilitiesByName {
+		if value == p.GetVisibility() {
+			view.Visibility = name
+		}
+	}
+	return view
+}
+
+func postsJSON(posts []*postpb.Post) []postJSON {
+	views := make([]postJSON, 0, len(posts))
+	for _, p := range posts {
+		views = append(views, newPostJSON(p))
+	}
+	return views
+}
+
+type postListJSON struct {
+	*postpb.ListPostsResponse
+	Posts []postJSON `json:"posts"`
+}
+
+type feedJSON struct {
+	*postpb.GetFeedResponse
+	Posts []postJSON `json:"posts"`
+}
+
 func createAuthContext(c *gin.Context) (context.Context, error) {
 	userIDValue, exists := c.Get("userID")
 	if !exists {
@@ -39,7 +99,11 @@ func createAuthContext(c *gin.Context) (context.Context, error) {
 		return nil, err
 	}
 
-	md := metadata.New(map[string]string{UserIDMetadataKey: userIDValue.(string)})
+	md := metadata.New(map[string]string{
+		UserIDMetadataKey:        userIDValue.(string),
+		UserRolesMetadataKey:     strings.Join(c.GetStringSlice("roles"), ","),
+		EmailVerifiedMetadataKey: strconv.FormatBool(c.GetBool("emailVerified")),
+	})
 	ctx := metadata.NewOutgoingContext(c.Request.Context(), md)
 	return ctx, nil
 }
@@ -64,11 +128,28 @@ func parsePagination(c *gin.Context) (page, pageSize int, err error) {
 }
 
 func (h *PostHandler) CreatePost(c *gin.Context) {
-	var req postpb.CreatePostRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var reqBody struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		IsPrivate   bool     `json:"is_private"`
+		Tags        []string `json:"tags"`
+		Visibility  string   `json:"visibility"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
 		return
 	}
+	visibility, ok := parseVisibility(c, reqBody.Visibility)
+	if !ok {
+		return
+	}
+	req := postpb.CreatePostRequest{
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		IsPrivate:   reqBody.IsPrivate,
+		Tags:        reqBody.Tags,
+		Visibility:  visibility,
+	}
 
 	if req.Title == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "title is required"})
@@ -91,7 +172,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, res.Post)
+	c.JSON(http.StatusCreated, newPostJSON(res.Post))
 }
 
 func (h *PostHandler) GetPost(c *gin.Context) {
@@ -117,7 +198,7 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, res.Post)
+	c.JSON(http.StatusOK, newPostJSON(res.Post))
 }
 
 func (h *PostHandler) UpdatePost(c *gin.Context) {
@@ -136,6 +217,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		Description *string  `json:"description"`
 		IsPrivate   *bool    `json:"is_private"`
 		Tags        []string `json:"tags"`
+		Visibility  string   `json:"visibility"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -165,6 +247,11 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 	if reqBody.IsPrivate != nil {
 		grpcReq.IsPrivate = *reqBody.IsPrivate
 	}
+	visibility, ok := parseVisibility(c, reqBody.Visibility)
+	if !ok {
+		return
+	}
+	grpcReq.Visibility = visibility
 
 	ctx, err := createAuthContext(c)
 	if err != nil {
@@ -178,7 +265,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, res.Post)
+	c.JSON(http.StatusOK, newPostJSON(res.Post))
 }
 
 func (h *PostHandler) DeletePost(c *gin.Context) {
@@ -230,7 +317,7 @@ func (h *PostHandler) GetMyPosts(c *gin.Context) {
 		MapGrpcError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, res)
+	c.JSON(http.StatusOK, postListJSON{ListPostsResponse: res, Posts: postsJSON(res.GetPosts())})
 }
 
 func (h *PostHandler) GetAllPublicPosts(c *gin.Context) {
@@ -256,7 +343,7 @@ func (h *PostHandler) GetAllPublicPosts(c *gin.Context) {
 		MapGrpcError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, res)
+	c.JSON(http.StatusOK, postListJSON{ListPostsResponse: res, Posts: postsJSON(res.GetPosts())})
 }
 
 func (h *PostHandler) GetUserPublicPosts(c *gin.Context) {
@@ -292,7 +379,89 @@ func (h *PostHandler) GetUserPublicPosts(c *gin.Context) {
 		MapGrpcError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, res)
+	c.JSON(http.StatusOK, postListJSON{ListPostsResponse: res, Posts: postsJSON(res.GetPosts())})
+}
+
+func (h *PostHandler) GetFeed(c *gin.Context) {
+	var pageSize int
+	if v := c.Query("page_size"); v != "" {
+		var err error
+		pageSize, err = utils.ValidatePageSize(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	grpcReq := &postpb.GetFeedRequest{
+		Cursor:   c.Query("cursor"),
+		PageSize: int32(pageSize),
+	}
+
+	res, err := h.postClient.GetFeed(ctx, grpcReq)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, feedJSON{GetFeedResponse: res, Posts: postsJSON(res.GetPosts())})
+}
+
+var searchOrdersByName = map[string]postpb.SearchOrder{
+	"relevance": postpb.SearchOrder_SEARCH_ORDER_RELEVANCE,
+	"recent":    postpb.SearchOrder_SEARCH_ORDER_RECENT,
+}
+
+// SearchPosts takes the query in q and tags either comma separated or as
+// repeated parameters.
+func (h *PostHandler) SearchPosts(c *gin.Context) {
+	page, pageSize, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	order, ok := searchOrdersByName[c.DefaultQuery("order", "relevance")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be one of: relevance, recent"})
+		return
+	}
+
+	grpcReq := &postpb.SearchPostsRequest{
+		Query:    c.Query("q"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Order:    order,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, v := range c.QueryArray("tags") {
+		grpcReq.Tags = append(grpcReq.Tags, strings.Split(v, ",")...)
+	}
+	if authorID, ok := c.GetQuery("author_id"); ok {
+		if err := utils.ValidateUserID(authorID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid author_id format"})
+			return
+		}
+		grpcReq.AuthorId = &authorID
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.postClient.SearchPosts(ctx, grpcReq)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, postListJSON{ListPostsResponse: res, Posts: postsJSON(res.GetPosts())})
 }
 
 func (h *PostHandler) ViewPost(c *gin.Context) {
@@ -324,6 +493,12 @@ func (h *PostHandler) ViewPost(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// likeStateJSON spells out both fields, which the generated JSON tags would
+// drop when false or zero.
+func likeStateJSON(res *postpb.LikeStateResponse) gin.H {
+	return gin.H{"liked": res.GetLiked(), "likes_count": res.GetLikesCount()}
+}
+
 func (h *PostHandler) LikePost(c *gin.Context) {
 	targetPostID := c.Param("postID")
 	if targetPostID == "" {
@@ -333,6 +508,7 @@ func (h *PostHandler) LikePost(c *gin.Context) {
 	err := utils.ValidatePostID(targetPostID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	ctx, err := createAuthContext(c)
@@ -345,12 +521,12 @@ func (h *PostHandler) LikePost(c *gin.Context) {
 		PostId: targetPostID,
 	}
 
-	_, err = h.postClient.LikePost(ctx, grpcReq)
+	res, err := h.postClient.LikePost(ctx, grpcReq)
 	if err != nil {
 		MapGrpcError(c, err)
 		return
 	}
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, likeStateJSON(res))
 }
 
 func (h *PostHandler) UnlikePost(c *gin.Context) {
@@ -362,6 +538,7 @@ func (h *PostHandler) UnlikePost(c *gin.Context) {
 	err := utils.ValidatePostID(targetPostID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	ctx, err := createAuthContext(c)
@@ -374,12 +551,12 @@ func (h *PostHandler) UnlikePost(c *gin.Context) {
 		PostId: targetPostID,
 	}
 
-	_, err = h.postClient.UnlikePost(ctx, grpcReq)
+	res, err := h.postClient.UnlikePost(ctx, grpcReq)
 	if err != nil {
 		MapGrpcError(c, err)
 		return
 	}
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, likeStateJSON(res))
 }
 
 func (h *PostHandler) AddComment(c *gin.Context) {
@@ -511,6 +688,11 @@ func (h *PostHandler) ListComments(c *gin.Context) {
 }
 
 func (h *PostHandler) ListReplies(c *gin.Context) {
+	targetPostID := c.Param("postID")
+	if err := utils.ValidatePostID(targetPostID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	targetCommentID := c.Param("commentID")
 	if targetCommentID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Comment ID parameter (:commentID) is required"})
@@ -529,6 +711,7 @@ func (h *PostHandler) ListReplies(c *gin.Context) {
 
 	grpcReq := &postpb.ListRepliesRequest{
 		ParentCommentId: targetCommentID,
+		PostId:          targetPostID,
 	}
 
 	res, err := h.postClient.ListReplies(ctx, grpcReq)
@@ -8,11 +8,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// ProxyHandler forwards requests to target. The client's own X-Forwarded-*
+// and X-Real-IP headers are dropped and X-Forwarded-For is set to the
+// address the gateway sees, so services behind it can trust the header.
 func ProxyHandler(target *url.URL) gin.HandlerFunc {
 	if target == nil {
 		log.Fatal("ProxyHandler: target URL cannot be nil")
 	}
-	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.Out.Host = r.In.Host
+			r.Out.Header.Del("X-Real-IP")
+			r.SetXForwarded()
+		},
+	}
 	return func(c *gin.Context) {
 		proxy.ServeHTTP(c.Writer, c.Request)
 	}
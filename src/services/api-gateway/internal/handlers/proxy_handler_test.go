@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProxyHandlerOverwritesForwardedFor(t *testing.T) {
+	var forwardedFor, realIP string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardedFor = r.Header.Get("X-Forwarded-For")
+		realIP = r.Header.Get("X-Real-IP")
+	}))
+	defer backend.Close()
+	target, err := url.Parse(backend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Any("/user/login", ProxyHandler(target))
+	gateway := httptest.NewServer(router)
+	defer gateway.Close()
+
+	req, err := http.NewRequest(http.MethodPost, gateway.URL+"/user/login", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set("X-Real-IP", "203.0.113.8")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if forwardedFor != "127.0.0.1" {
+		t.Errorf("X-Forwarded-For = %q, want the gateway's peer 127.0.0.1", forwardedFor)
+	}
+	if realIP != "" {
+		t.Errorf("X-Real-IP = %q, want it dropped", realIP)
+	}
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/api-gateway/internal/utils"
+)
+
+var metricsByName = map[string]statspb.Metric{
+	"views":    statspb.Metric_METRIC_VIEWS,
+	"likes":    statspb.Metric_METRIC_LIKES,
+	"comments": statspb.Metric_METRIC_COMMENTS,
+}
+
+type StatsHandler struct {
+	statsClient statspb.StatsServiceClient
+	postClient  postpb.PostServiceClient
+}
+
+func NewStatsHandler(client statspb.StatsServiceClient, postClient postpb.PostServiceClient) *StatsHandler {
+	if client == nil {
+		log.Fatal("StatsHandler: statsClient cannot be nil")
+	}
+	if postClient == nil {
+		log.Fatal("StatsHandler: postClient cannot be nil")
+	}
+	return &StatsHandler{statsClient: client, postClient: postClient}
+}
+
+// checkCanViewPost lets the stats of a post through only to callers who can
+// see the post itself, as post-service decides for GetPost.
+func (h *StatsHandler) checkCanViewPost(c *gin.Context, ctx context.Context, postID string) bool {
+	if _, err := h.postClient.GetPost(ctx, &postpb.GetPostRequest{PostId: postID}); err != nil {
+		MapGrpcError(c, err)
+		return false
+	}
+	return true
+}
+
+func (h *StatsHandler) GetPostStats(c *gin.Context) {
+	postID := c.Param("postID")
+	if postID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID parameter (:postID) is required"})
+		return
+	}
+	if err := utils.ValidatePostID(postID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	if !h.checkCanViewPost(c, ctx, postID) {
+		return
+	}
+
+	res, err := h.statsClient.GetPostStats(ctx, &statspb.GetPostStatsRequest{PostId: postID})
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	stats := res.GetStats()
+	c.JSON(http.StatusOK, gin.H{
+		"post_id":  stats.GetPostId(),
+		"views":    stats.GetViews(),
+		"likes":    stats.GetLikes(),
+		"comments": stats.GetComments(),
+	})
+}
+
+func (h *StatsHandler) GetPostTimeline(c *gin.Context) {
+	postID := c.Param("postID")
+	if postID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID parameter (:postID) is required"})
+		return
+	}
+	if err := utils.ValidatePostID(postID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	metricName := c.Query("metric")
+	metric, ok := metricsByName[metricName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric must be one of: views, likes, comments"})
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	if !h.checkCanViewPost(c, ctx, postID) {
+		return
+	}
+
+	grpcReq := &statspb.GetPostTimelineRequest{
+		PostId: postID,
+		Metric: metric,
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+	}
+
+	res, err := h.statsClient.GetPostTimeline(ctx, grpcReq)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	points := make([]gin.H, 0, len(res.GetPoints()))
+	for _, p := range res.GetPoints() {
+		points = append(points, gin.H{"date": p.GetDate(), "count": p.GetCount()})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"post_id": res.GetPostId(),
+		"metric":  metricName,
+		"from":    res.GetFrom(),
+		"to":      res.GetTo(),
+		"points":  points,
+	})
+}
+
+// parseTopRequest reads the query parameters shared by the leaderboard
+// endpoints. Range and limit bounds are enforced by the stats service.
+func parseTopRequest(c *gin.Context) (*statspb.TopRequest, string, bool) {
+	metricName := c.Query("metric")
+	metric, ok := metricsByName[metricName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric must be one of: views, likes, comments"})
+		return nil, "", false
+	}
+
+	var limit int
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return nil, "", false
+		}
+	}
+
+	return &statspb.TopRequest{
+		Metric: metric,
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+		Limit:  int32(limit),
+	}, metricName, true
+}
+
+func (h *StatsHandler) TopPosts(c *gin.Context) {
+	grpcReq, metricName, ok := parseTopRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.statsClient.TopPosts(ctx, grpcReq)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	posts := make([]gin.H, 0, len(res.GetPosts()))
+	for _, p := range res.GetPosts() {
+		posts = append(posts, gin.H{"post_id": p.GetPostId(), "user_id": p.GetUserId(), "count": p.GetCount()})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"metric": metricName,
+		"from":   res.GetFrom(),
+		"to":     res.GetTo(),
+		"posts":  posts,
+	})
+}
+
+func (h *StatsHandler) TopUsers(c *gin.Context) {
+	grpcReq, metricName, ok := parseTopRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, err := createAuthContext(c)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	res, err := h.statsClient.TopUsers(ctx, grpcReq)
+	if err != nil {
+		MapGrpcError(c, err)
+		return
+	}
+
+	users := make([]gin.H, 0, len(res.GetUsers()))
+	for _, u := range res.GetUsers() {
+		users = append(users, gin.H{"user_id": u.GetUserId(), "count": u.GetCount()})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"metric": metricName,
+		"from":   res.GetFrom(),
+		"to":     res.GetTo(),
+		"users":  users,
+	})
+}
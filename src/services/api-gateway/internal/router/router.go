@@ -6,11 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
 	"github.com/zahartd/social-network/src/services/api-gateway/internal/auth"
 	"github.com/zahartd/social-network/src/services/api-gateway/internal/handlers"
 )
 
-func SetupRouter(postClient postpb.PostServiceClient, userServiceURL *url.URL) *gin.Engine {
+func SetupRouter(postClient postpb.PostServiceClient, statsClient statspb.StatsServiceClient, userServiceURL *url.URL, sessions *auth.Introspector) *gin.Engine {
 	router := gin.Default()
 
 	router.Use(gin.Logger())
@@ -18,19 +19,56 @@ func SetupRouter(postClient postpb.PostServiceClient, userServiceURL *url.URL) *
 
 	proxyHandlerFunc := handlers.ProxyHandler(userServiceURL)
 	router.POST("/user", proxyHandlerFunc)
+	router.POST("/user/login", proxyHandlerFunc)
+	// Deprecated: credentials in the query string end up in access logs.
 	router.GET("/user/login", proxyHandlerFunc)
+	router.POST("/user/login/mfa", proxyHandlerFunc)
+	router.POST("/user/token/refresh", proxyHandlerFunc)
+	router.POST("/user/password/forgot", proxyHandlerFunc)
+	router.POST("/user/password/reset", proxyHandlerFunc)
+	router.POST("/user/verify-email", proxyHandlerFunc)
 	userProtected := router.Group("/user")
-	userProtected.Use(auth.Middleware())
+	userProtected.Use(auth.Middleware(sessions))
 	{
-		userProtected.GET("/logout", proxyHandlerFunc)
+		userProtected.GET("/logout", auth.ForgetSessionOnSuccess(sessions), proxyHandlerFunc)
+		userProtected.POST("/verify-email/resend", proxyHandlerFunc)
+		userProtected.GET("/sessions", proxyHandlerFunc)
+		userProtected.DELETE("/sessions", auth.ForgetUserSessionsOnSuccess(sessions), proxyHandlerFunc)
+		userProtected.DELETE("/sessions/:sessionID", auth.ForgetUserSessionsOnSuccess(sessions), proxyHandlerFunc)
+		userProtected.POST("/mfa/totp", proxyHandlerFunc)
+		userProtected.POST("/mfa/totp/confirm", proxyHandlerFunc)
+		userProtected.DELETE("/mfa/totp", proxyHandlerFunc)
+		userProtected.GET("/close-friends", proxyHandlerFunc)
+		userProtected.POST("/close-friends/:identifier", proxyHandlerFunc)
+		userProtected.DELETE("/close-friends/:identifier", proxyHandlerFunc)
+		userProtected.GET("/blocks", proxyHandlerFunc)
+		userProtected.POST("/blocks/:identifier", proxyHandlerFunc)
+		userProtected.DELETE("/blocks/:identifier", proxyHandlerFunc)
+		userProtected.GET("/mutes", proxyHandlerFunc)
+		userProtected.POST("/mutes/:identifier", proxyHandlerFunc)
+		userProtected.DELETE("/mutes/:identifier", proxyHandlerFunc)
 		userProtected.GET("/:identifier", proxyHandlerFunc)
 		userProtected.PUT("/:identifier", proxyHandlerFunc)
-		userProtected.DELETE("/:identifier", proxyHandlerFunc)
+		userProtected.DELETE("/:identifier", auth.ForgetSessionOnSuccess(sessions), proxyHandlerFunc)
+		userProtected.PUT("/:identifier/password", proxyHandlerFunc)
+		userProtected.POST("/:identifier/follow", proxyHandlerFunc)
+		userProtected.DELETE("/:identifier/follow", proxyHandlerFunc)
+		userProtected.GET("/:identifier/followers", proxyHandlerFunc)
+		userProtected.GET("/:identifier/following", proxyHandlerFunc)
 	}
+	userAdmin := userProtected.Group("/:identifier/roles")
+	userAdmin.Use(auth.RequireRole(auth.RoleAdmin))
+	{
+		userAdmin.GET("", proxyHandlerFunc)
+		userAdmin.POST("", proxyHandlerFunc)
+		userAdmin.DELETE("/:role", proxyHandlerFunc)
+	}
+	userProtected.DELETE("/:identifier/lockout", auth.RequireRole(auth.RoleAdmin), proxyHandlerFunc)
 
 	postHandlers := handlers.NewPostHandler(postClient)
+	statsHandlers := handlers.NewStatsHandler(statsClient, postClient)
 	postProtected := router.Group("/posts")
-	postProtected.Use(auth.Middleware())
+	postProtected.Use(auth.Middleware(sessions))
 	{
 		postProtected.POST("", postHandlers.CreatePost)
 		postProtected.GET("/:postID", postHandlers.GetPost)
@@ -39,6 +77,8 @@ func SetupRouter(postClient postpb.PostServiceClient, userServiceURL *url.URL) *
 		postProtected.GET("/list/my", postHandlers.GetMyPosts)
 		postProtected.GET("/list/public", postHandlers.GetAllPublicPosts)
 		postProtected.GET("/list/public/:userID", postHandlers.GetUserPublicPosts)
+		postProtected.GET("/feed", postHandlers.GetFeed)
+		postProtected.GET("/search", postHandlers.SearchPosts)
 		postProtected.POST("/:postID/view", postHandlers.ViewPost)
 		postProtected.POST("/:postID/like", postHandlers.LikePost)
 		postProtected.DELETE("/:postID/like", postHandlers.UnlikePost)
@@ -46,6 +86,23 @@ func SetupRouter(postClient postpb.PostServiceClient, userServiceURL *url.URL) *
 		postProtected.POST("/:postID/comments", postHandlers.AddComment)
 		postProtected.POST("/:postID/comments/:commentID/replies", postHandlers.AddReply)
 		postProtected.GET("/:postID/comments/:commentID/replies", postHandlers.ListReplies)
+		postProtected.GET("/:postID/stats", statsHandlers.GetPostStats)
+		postProtected.GET("/:postID/stats/timeline", statsHandlers.GetPostTimeline)
+	}
+
+	postModeration := postProtected.Group("")
+	postModeration.Use(auth.RequireRole(auth.RoleModerator, auth.RoleAdmin))
+	{
+		postModeration.POST("/:postID/moderation", postHandlers.ModeratePost)
+		postModeration.GET("/:postID/moderation", postHandlers.ListModerationActions)
+		postModeration.POST("/:postID/comments/:commentID/moderation", postHandlers.ModerateComment)
+	}
+
+	statsProtected := router.Group("/stats")
+	statsProtected.Use(auth.Middleware(sessions))
+	{
+		statsProtected.GET("/top/posts", statsHandlers.TopPosts)
+		statsProtected.GET("/top/users", statsHandlers.TopUsers)
 	}
 
 	router.GET("/ping", func(c *gin.Context) {
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -13,10 +15,12 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/zahartd/social-network/src/events"
 	postpb "github.com/zahartd/social-network/src/gen/go/post"
 	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
 	"github.com/zahartd/social-network/src/services/post-service/internal/config"
 	"github.com/zahartd/social-network/src/services/post-service/internal/handlers"
+	"github.com/zahartd/social-network/src/services/post-service/internal/relations"
 	"github.com/zahartd/social-network/src/services/post-service/internal/repository"
 	"github.com/zahartd/social-network/src/services/post-service/internal/service"
 )
@@ -32,42 +36,51 @@ func main() {
 
 	postRepo := repository.NewPostgresPostRepository(db)
 
-	viewWriter := &kafka.Writer{
-		Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
-		Topic:                  "post-views",
-		Async:                  true,
-		AllowAutoTopicCreation: true,
-	}
-	defer func() {
-		if err := viewWriter.Close(); err != nil {
-			log.Fatal("failed to close writer:", err)
-		}
-	}()
-	likeWriter := &kafka.Writer{
-		Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
-		Topic:                  "post-likes",
-		Async:                  true,
-		AllowAutoTopicCreation: true,
-	}
+	outboxRepo := repository.NewPostgresOutboxRepository(db)
+
+	// Synchronous writer without a fixed topic: the relay marks a message as
+	// sent only after Kafka has acknowledged it, and each outbox row names its
+	// own topic. Hash keeps messages with the same key in one partition.
+	publisher := events.NewKafkaPublisher(
+		&kafka.Writer{
+			Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			BatchTimeout:           10 * time.Millisecond,
+			AllowAutoTopicCreation: true,
+		},
+		events.WithHooks(events.Hooks{
+			OnRetry: func(topic string, attempt int, err error) {
+				log.Printf("retrying publish to %s (attempt %d): %v", topic, attempt, err)
+			},
+		}),
+	)
 	defer func() {
-		if err := likeWriter.Close(); err != nil {
-			log.Fatal("failed to close writer:", err)
+		if err := publisher.Close(); err != nil {
+			log.Fatal("failed to close publisher:", err)
 		}
 	}()
-	commentWriter := &kafka.Writer{
-		Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
-		Topic:                  "post-comments",
-		Async:                  true,
-		AllowAutoTopicCreation: true,
-	}
-	defer func() {
-		if err := commentWriter.Close(); err != nil {
-			log.Fatal("failed to close writer:", err)
-		}
+
+	relay := events.NewRelay(outboxRepo, publisher)
+	ctx, cancel := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
 	}()
 
-	postService := service.NewPostService(postRepo, viewWriter, likeWriter, commentWriter)
+	codec, err := events.NewCodec(cfg.EventsEncoding)
+	if err != nil {
+		log.Fatalf("Invalid EVENTS_ENCODING: %v", err)
+	}
+	postService := service.NewPostService(postRepo, codec, cfg.RequireVerifiedEmail,
+		relations.NewClient(cfg.UserServiceURL), cfg.FeedFanoutMaxFollowers)
 	postHandler := handlers.NewPostGRPCHandler(postService)
+	fanOutDone := make(chan struct{})
+	go func() {
+		defer close(fanOutDone)
+		postService.RunFanOut(ctx)
+	}()
 
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(auth.AuthInterceptor),
@@ -92,4 +105,7 @@ func main() {
 	<-quit
 
 	grpcServer.GracefulStop()
+	cancel()
+	<-relayDone
+	<-fanOutDone
 }
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -9,11 +11,27 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const UserIDMetadataKey = "x-user-id"
+const (
+	UserIDMetadataKey = "x-user-id"
+	// UserRolesMetadataKey carries the caller's roles, comma-separated, as
+	// read by the gateway from the access token.
+	UserRolesMetadataKey = "x-user-roles"
+	// EmailVerifiedMetadataKey is "true" if the caller's email is verified.
+	EmailVerifiedMetadataKey = "x-email-verified"
+)
+
+const (
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
 
 type contextKey string
 
-const userIDKey contextKey = "userID"
+const (
+	userIDKey    contextKey = "userID"
+	userRolesKey contextKey = "userRoles"
+	verifiedKey  contextKey = "emailVerified"
+)
 
 func AuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -32,9 +50,44 @@ func AuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, h
 	}
 
 	newCtx := context.WithValue(ctx, userIDKey, userID)
+
+	var roles []string
+	for _, v := range md.Get(UserRolesMetadataKey) {
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	newCtx = context.WithValue(newCtx, userRolesKey, roles)
+
+	verified := md.Get(EmailVerifiedMetadataKey)
+	newCtx = context.WithValue(newCtx, verifiedKey, len(verified) > 0 && verified[0] == "true")
 	return handler(newCtx, req)
 }
 
+// HasRole reports whether the caller holds one of roles.
+func HasRole(ctx context.Context, roles ...string) bool {
+	held, _ := ctx.Value(userRolesKey).([]string)
+	for _, role := range roles {
+		if slices.Contains(held, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModerator reports whether the caller may moderate content.
+func IsModerator(ctx context.Context) bool {
+	return HasRole(ctx, RoleModerator, RoleAdmin)
+}
+
+// EmailVerified reports whether the caller's email is verified.
+func EmailVerified(ctx context.Context) bool {
+	verified, _ := ctx.Value(verifiedKey).(bool)
+	return verified
+}
+
 func GetUserIDFromContext(ctx context.Context) (string, error) {
 	userID, ok := ctx.Value(userIDKey).(string)
 	if !ok || userID == "" {
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthInterceptorRoles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		roles         []string
+		wantModerator bool
+	}{
+		{"no roles header", nil, false},
+		{"plain user", []string{"user"}, false},
+		{"moderator", []string{"user,moderator"}, true},
+		{"admin", []string{"user, admin"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.Pairs(UserIDMetadataKey, "user-1")
+			for _, v := range tc.roles {
+				md.Append(UserRolesMetadataKey, v)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+
+			var got bool
+			_, err := AuthInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+				got = IsModerator(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("AuthInterceptor returned error: %v", err)
+			}
+			if got != tc.wantModerator {
+				t.Errorf("IsModerator = %v, want %v", got, tc.wantModerator)
+			}
+		})
+	}
+}
@@ -2,13 +2,24 @@ package config
 
 import (
 	"log"
+	"net/url"
 	"os"
+	"strconv"
 )
 
 type Config struct {
 	GRPCPort       string
 	DB_DSN         string
 	KafkaBrokerURL string
+	// EventsEncoding is the wire format of published events: json or protobuf.
+	EventsEncoding string
+	// RequireVerifiedEmail lets only users with a verified email publish.
+	RequireVerifiedEmail bool
+	// UserServiceURL is where the follow graph is looked up.
+	UserServiceURL *url.URL
+	// FeedFanoutMaxFollowers is the most followers an author may have for
+	// their posts to be precomputed into follower feeds.
+	FeedFanoutMaxFollowers int
 }
 
 func Load() *Config {
@@ -22,9 +33,35 @@ func Load() *Config {
 		log.Fatal("DB_DSN environment variable is not set")
 	}
 
+	var requireVerifiedEmail bool
+	if v := os.Getenv("REQUIRE_VERIFIED_EMAIL"); v != "" {
+		var err error
+		requireVerifiedEmail, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatalf("Invalid REQUIRE_VERIFIED_EMAIL: %v", err)
+		}
+	}
+
+	userServiceURL, err := url.Parse(os.Getenv("USER_SERVICE_URL"))
+	if err != nil || userServiceURL.Host == "" {
+		log.Fatal("USER_SERVICE_URL environment variable is not set or invalid")
+	}
+
+	fanoutMaxFollowers := 1000
+	if v := os.Getenv("FEED_FANOUT_MAX_FOLLOWERS"); v != "" {
+		fanoutMaxFollowers, err = strconv.Atoi(v)
+		if err != nil || fanoutMaxFollowers < 0 {
+			log.Fatalf("Invalid FEED_FANOUT_MAX_FOLLOWERS: %q", v)
+		}
+	}
+
 	return &Config{
-		GRPCPort:       port,
-		DB_DSN:         dbDSN,
-		KafkaBrokerURL: os.Getenv("KAFKA_BROKER_URL"),
+		GRPCPort:               port,
+		DB_DSN:                 dbDSN,
+		KafkaBrokerURL:         os.Getenv("KAFKA_BROKER_URL"),
+		EventsEncoding:         os.Getenv("EVENTS_ENCODING"),
+		RequireVerifiedEmail:   requireVerifiedEmail,
+		UserServiceURL:         userServiceURL,
+		FeedFanoutMaxFollowers: fanoutMaxFollowers,
 	}
 }
@@ -77,16 +77,48 @@ func (h *PostGRPCHandler) ListPublicPosts(ctx context.Context, req *postpb.ListP
 	}, nil
 }
 
+func (h *PostGRPCHandler) GetFeed(ctx context.Context, req *postpb.GetFeedRequest) (*postpb.GetFeedResponse, error) {
+	posts, nextCursor, err := h.postService.GetFeed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &postpb.GetFeedResponse{
+		Posts:      posts,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (h *PostGRPCHandler) SearchPosts(ctx context.Context, req *postpb.SearchPostsRequest) (*postpb.ListPostsResponse, error) {
+	posts, totalCount, err := h.postService.SearchPosts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &postpb.ListPostsResponse{
+		Posts:      posts,
+		TotalCount: int32(totalCount),
+		Page:       req.GetPage(),
+		PageSize:   req.GetPageSize(),
+	}, nil
+}
+
 func (h *PostGRPCHandler) ViewPost(ctx context.Context, req *postpb.ViewPostRequest) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, h.postService.ViewPost(ctx, req)
 }
 
-func (h *PostGRPCHandler) LikePost(ctx context.Context, req *postpb.LikePostRequest) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, h.postService.LikePost(ctx, req)
+func (h *PostGRPCHandler) LikePost(ctx context.Context, req *postpb.LikePostRequest) (*postpb.LikeStateResponse, error) {
+	state, err := h.postService.LikePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoLikeState(state), nil
 }
 
-func (h *PostGRPCHandler) UnlikePost(ctx context.Context, req *postpb.UnlikePostRequest) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, h.postService.UnlikePost(ctx, req)
+func (h *PostGRPCHandler) UnlikePost(ctx context.Context, req *postpb.UnlikePostRequest) (*postpb.LikeStateResponse, error) {
+	state, err := h.postService.UnlikePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoLikeState(state), nil
 }
 
 func (h *PostGRPCHandler) AddComment(ctx context.Context, req *postpb.AddCommentRequest) (*postpb.CommentResponse, error) {
@@ -106,11 +138,50 @@ func (h *PostGRPCHandler) AddReply(ctx context.Context, req *postpb.AddReplyRequ
 }
 
 func (h *PostGRPCHandler) ListComments(ctx context.Context, req *postpb.ListCommentsRequest) (*postpb.ListCommentsResponse, error) {
-	cms, total, _ := h.postService.ListComments(ctx, req)
+	cms, total, err := h.postService.ListComments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	return &postpb.ListCommentsResponse{Comments: cms, TotalCount: int32(total), Page: req.Page, PageSize: req.PageSize}, nil
 }
 
 func (h *PostGRPCHandler) ListReplies(ctx context.Context, req *postpb.ListRepliesRequest) (*postpb.ListRepliesResponse, error) {
-	reps, _ := h.postService.ListReplies(ctx, req)
+	reps, err := h.postService.ListReplies(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	return &postpb.ListRepliesResponse{Replies: reps, TotalCount: int32(len(reps)), Page: 1, PageSize: int32(len(reps))}, nil
 }
+
+func (h *PostGRPCHandler) ModeratePost(ctx context.Context, req *postpb.ModeratePostRequest) (*postpb.ModerationAction, error) {
+	action, err := h.postService.ModeratePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoModerationAction(action), nil
+}
+
+func (h *PostGRPCHandler) ModerateComment(ctx context.Context, req *postpb.ModerateCommentRequest) (*postpb.ModerationAction, error) {
+	action, err := h.postService.ModerateComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoModerationAction(action), nil
+}
+
+func (h *PostGRPCHandler) ListModerationActions(ctx context.Context, req *postpb.ListModerationActionsRequest) (*postpb.ListModerationActionsResponse, error) {
+	actions, total, err := h.postService.ListModerationActions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	protoActions := make([]*postpb.ModerationAction, 0, len(actions))
+	for _, a := range actions {
+		protoActions = append(protoActions, service.ToProtoModerationAction(&a))
+	}
+	return &postpb.ListModerationActionsResponse{
+		Actions:    protoActions,
+		TotalCount: int32(total),
+		Page:       req.GetPage(),
+		PageSize:   req.GetPageSize(),
+	}, nil
+}
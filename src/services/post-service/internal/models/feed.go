@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Follow is an author the reader follows and since when.
+type Follow struct {
+	AuthorID   string    `json:"user_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FeedCursor points at the last post of a feed page; the next page starts
+// right after it.
+type FeedCursor struct {
+	CreatedAt time.Time
+	PostID    string
+}
+
+// FanOutJob is a new post waiting to be written into the timelines of the
+// author's followers.
+type FanOutJob struct {
+	PostID     string     `db:"post_id"`
+	AuthorID   string     `db:"user_id"`
+	CreatedAt  time.Time  `db:"created_at"`
+	Visibility Visibility `db:"visibility"`
+	Attempts   int        `db:"attempts"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// LikeState is whether a user likes a post, along with the post's total
+// number of likes.
+type LikeState struct {
+	Liked      bool
+	LikesCount int64
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type ModerationTarget string
+
+const (
+	ModerationTargetPost    ModerationTarget = "post"
+	ModerationTargetComment ModerationTarget = "comment"
+)
+
+type ModerationActionType string
+
+const (
+	ModerationHide   ModerationActionType = "hide"
+	ModerationUnhide ModerationActionType = "unhide"
+	ModerationDelete ModerationActionType = "delete"
+)
+
+type ModerationAction struct {
+	ID          string               `db:"id"`
+	TargetType  ModerationTarget     `db:"target_type"`
+	TargetID    string               `db:"target_id"`
+	PostID      string               `db:"post_id"`
+	Action      ModerationActionType `db:"action"`
+	Reason      string               `db:"reason"`
+	ModeratorID string               `db:"moderator_id"`
+	CreatedAt   time.Time            `db:"created_at"`
+}
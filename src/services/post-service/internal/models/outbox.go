@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type OutboxMessage struct {
+	ID          int64      `db:"id"`
+	EventID     string     `db:"event_id"`
+	Topic       string     `db:"topic"`
+	Key         string     `db:"msg_key"`
+	Payload     []byte     `db:"payload"`
+	ContentType string     `db:"content_type"`
+	CreatedAt   time.Time  `db:"created_at"`
+	SentAt      *time.Time `db:"sent_at"`
+}
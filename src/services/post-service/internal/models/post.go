@@ -6,13 +6,69 @@ import (
 	"github.com/lib/pq"
 )
 
+// Visibility says who besides the author can see a post.
+type Visibility string
+
+const (
+	VisibilityPublic       Visibility = "public"
+	VisibilityFollowers    Visibility = "followers"
+	VisibilityCloseFriends Visibility = "close_friends"
+	VisibilityOnlyMe       Visibility = "only_me"
+)
+
 type Post struct {
-	ID          string         `db:"id"`
-	UserID      string         `db:"user_id"`
-	Title       string         `db:"title"`
-	Description string         `db:"description"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   time.Time      `db:"updated_at"`
-	IsPrivate   bool           `db:"is_private"`
-	Tags        pq.StringArray `db:"tags"`
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	// IsPrivate is derived from Visibility: it is set for every visibility
+	// but public.
+	IsPrivate  bool           `db:"is_private"`
+	Visibility Visibility     `db:"visibility"`
+	Tags       pq.StringArray `db:"tags"`
+	HiddenAt   *time.Time     `db:"hidden_at"`
+}
+
+// Audience is what a reader's access to non-public posts depends on.
+type Audience struct {
+	ReaderID string
+	// Following holds the authors the reader follows.
+	Following []string
+	// CloseFriendOf holds the authors who have the reader on their close
+	// friends list.
+	CloseFriendOf []string
+	// Hidden holds the authors left out of the reader's listings: those
+	// blocked either way and those the reader muted.
+	Hidden []string
+}
+
+// SearchOrder is the order of search results.
+type SearchOrder string
+
+const (
+	SearchOrderRelevance SearchOrder = "relevance"
+	SearchOrderRecent    SearchOrder = "recent"
+)
+
+// PostSearch holds the criteria of a post search. Empty fields do not
+// filter.
+type PostSearch struct {
+	// Query is in web search syntax.
+	Query    string
+	Tags     []string
+	AuthorID string
+	// From and To bound the creation time: From inclusive, To exclusive.
+	From  *time.Time
+	To    *time.Time
+	Order SearchOrder
+}
+
+// Restrictions are the blocks and mutes of a user.
+type Restrictions struct {
+	// Blocked holds the users the user blocked or was blocked by.
+	Blocked []string `json:"blocked"`
+	// Muted holds the users the user muted.
+	Muted []string `json:"muted"`
 }
@@ -0,0 +1,103 @@
+// Package relations looks up the user graph, which lives in user-service.
+package relations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+type Lookup interface {
+	// Following returns every author userID follows.
+	Following(ctx context.Context, userID string) ([]models.Follow, error)
+	// Followers returns up to limit followers of userID.
+	Followers(ctx context.Context, userID string, limit int) ([]string, error)
+	// CloseFriendOf returns the authors who have userID on their close
+	// friends list.
+	CloseFriendOf(ctx context.Context, userID string) ([]string, error)
+	// Restrictions returns the users userID blocked or was blocked by, and
+	// the users userID muted.
+	Restrictions(ctx context.Context, userID string) (*models.Restrictions, error)
+}
+
+// Client calls the internal routes of user-service, which the gateway does
+// not expose.
+type Client struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+func NewClient(userServiceURL *url.URL) *Client {
+	return &Client{
+		baseURL: userServiceURL,
+		client:  &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+func (c *Client) Following(ctx context.Context, userID string) ([]models.Follow, error) {
+	var body struct {
+		Following []models.Follow `json:"following"`
+	}
+	endpoint := c.baseURL.JoinPath("/internal/users", userID, "following")
+	if err := c.get(ctx, endpoint, &body); err != nil {
+		return nil, err
+	}
+	return body.Following, nil
+}
+
+func (c *Client) Followers(ctx context.Context, userID string, limit int) ([]string, error) {
+	var body struct {
+		Followers []string `json:"followers"`
+	}
+	endpoint := c.baseURL.JoinPath("/internal/users", userID, "followers")
+	endpoint.RawQuery = url.Values{"limit": {strconv.Itoa(limit)}}.Encode()
+	if err := c.get(ctx, endpoint, &body); err != nil {
+		return nil, err
+	}
+	return body.Followers, nil
+}
+
+func (c *Client) CloseFriendOf(ctx context.Context, userID string) ([]string, error) {
+	var body struct {
+		Authors []string `json:"authors"`
+	}
+	endpoint := c.baseURL.JoinPath("/internal/users", userID, "close-friend-of")
+	if err := c.get(ctx, endpoint, &body); err != nil {
+		return nil, err
+	}
+	return body.Authors, nil
+}
+
+func (c *Client) Restrictions(ctx context.Context, userID string) (*models.Restrictions, error) {
+	var restrictions models.Restrictions
+	endpoint := c.baseURL.JoinPath("/internal/users", userID, "restrictions")
+	if err := c.get(ctx, endpoint, &restrictions); err != nil {
+		return nil, err
+	}
+	return &restrictions, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint *url.URL, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("relations lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("relations lookup failed: user-service returned %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("relations lookup failed: %w", err)
+	}
+	return nil
+}
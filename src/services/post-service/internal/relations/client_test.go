@@ -0,0 +1,98 @@
+package relations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(target)
+}
+
+func TestClientFollowing(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/users/u1/following" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"following":[{"user_id":"a1","followed_at":"2025-01-02T03:04:05Z"}]}`))
+	})
+
+	follows, err := c.Following(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("Following returned error: %v", err)
+	}
+	if len(follows) != 1 || follows[0].AuthorID != "a1" || !follows[0].FollowedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected follows %+v", follows)
+	}
+}
+
+func TestClientFollowers(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/users/a1/followers" || r.URL.Query().Get("limit") != "11" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"followers":["u1","u2"]}`))
+	})
+
+	ids, err := c.Followers(context.Background(), "a1", 11)
+	if err != nil {
+		t.Fatalf("Followers returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "u1" || ids[1] != "u2" {
+		t.Errorf("unexpected followers %v", ids)
+	}
+}
+
+func TestClientCloseFriendOf(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/users/u1/close-friend-of" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"authors":["a1"]}`))
+	})
+
+	authors, err := c.CloseFriendOf(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("CloseFriendOf returned error: %v", err)
+	}
+	if len(authors) != 1 || authors[0] != "a1" {
+		t.Errorf("unexpected authors %v", authors)
+	}
+}
+
+func TestClientRestrictions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/users/u1/restrictions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"blocked":["b1"],"muted":["m1","m2"]}`))
+	})
+
+	r, err := c.Restrictions(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("Restrictions returned error: %v", err)
+	}
+	if len(r.Blocked) != 1 || r.Blocked[0] != "b1" || len(r.Muted) != 2 {
+		t.Errorf("unexpected restrictions %+v", r)
+	}
+}
+
+func TestClientReportsFailures(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	if _, err := c.Following(context.Background(), "u1"); err == nil {
+		t.Error("Following succeeded on a 500 answer")
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+// EnqueueFanOut schedules the post for the fan-out worker. Called through
+// RunInTx it is committed together with the post.
+func (r *postgresPostRepository) EnqueueFanOut(ctx context.Context, postID string) error {
+	if _, err := r.db.ExecContext(ctx, `INSERT INTO feed_fanout_jobs (post_id) VALUES ($1)`, postID); err != nil {
+		return fmt.Errorf("could not enqueue fan-out: %w", err)
+	}
+	return nil
+}
+
+// ClaimFanOutJobs skips the jobs locked by other workers and postpones the
+// claimed ones until leaseUntil in the same statement, so no other worker
+// fans out the same post while the lease holds and no lock outlives it.
+func (r *postgresPostRepository) ClaimFanOutJobs(ctx context.Context, limit int, leaseUntil time.Time) ([]models.FanOutJob, error) {
+	query := `
+	WITH due AS (
+		SELECT post_id FROM feed_fanout_jobs
+		WHERE next_attempt_at <= now()
+		ORDER BY next_attempt_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	), leased AS (
+		UPDATE feed_fanout_jobs j SET next_attempt_at = $2
+		FROM due WHERE j.post_id = due.post_id
+		RETURNING j.post_id, j.attempts
+	)
+	SELECT l.post_id, p.user_id, p.created_at, p.visibility, l.attempts
+	FROM leased l
+	JOIN posts p ON p.id = l.post_id`
+	jobs := []models.FanOutJob{}
+	if err := r.db.SelectContext(ctx, &jobs, query, limit, leaseUntil); err != nil {
+		return nil, fmt.Errorf("could not claim fan-out jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// FanOutPost adds the post to the timelines of followerIDs, marks it as
+// fanned out and drops its job, in one statement.
+func (r *postgresPostRepository) FanOutPost(ctx context.Context, postID string, createdAt time.Time, followerIDs []string) error {
+	query := `
+	WITH timeline AS (
+		INSERT INTO feed_timeline (user_id, post_id, created_at)
+		SELECT unnest($3::uuid[]), $1, $2
+		ON CONFLICT DO NOTHING
+	), job AS (
+		DELETE FROM feed_fanout_jobs WHERE post_id = $1
+	)
+	UPDATE posts SET fanned_out = TRUE WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, postID, createdAt, idArray(followerIDs)); err != nil {
+		return fmt.Errorf("could not fan out post: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresPostRepository) SkipFanOut(ctx context.Context, postID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM feed_fanout_jobs WHERE post_id = $1`, postID); err != nil {
+		return fmt.Errorf("could not skip fan-out: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresPostRepository) RetryFanOut(ctx context.Context, postID string, at time.Time) error {
+	query := `UPDATE feed_fanout_jobs SET attempts = attempts + 1, next_attempt_at = $2 WHERE post_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, postID, at); err != nil {
+		return fmt.Errorf("could not postpone fan-out: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresPostRepository) PruneTimeline(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM feed_timeline WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("could not prune timelines: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetFeed returns up to limit posts of the followed authors that the
+// audience may see, newest first, starting after the cursor if one is given.
+//
+// A post of a followed author comes from the reader's timeline if it was
+// fanned out after the follow started and not before timelineSince, and is
+// read from posts otherwise: posts of authors too popular to fan out, posts
+// still waiting for the fan-out, posts written before the follow and posts
+// whose timeline entries were pruned. The two branches never return the
+// same post.
+func (r *postgresPostRepository) GetFeed(ctx context.Context, audience *models.Audience, follows []models.Follow, after *models.FeedCursor, timelineSince time.Time, limit int) ([]models.Post, error) {
+	authorIDs := make(pq.StringArray, len(follows))
+	followedAt := make([]string, len(follows))
+	for i, f := range follows {
+		authorIDs[i] = f.AuthorID
+		followedAt[i] = f.FollowedAt.Format(time.RFC3339Nano)
+	}
+	// The zero cursor sorts after every real post.
+	cursor := models.FeedCursor{CreatedAt: time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), PostID: "ffffffff-ffff-ffff-ffff-ffffffffffff"}
+	if after != nil {
+		cursor = *after
+	}
+
+	query := `
+	WITH follows AS (
+		SELECT * FROM unnest($2::uuid[], $3::timestamptz[]) AS f(author_id, followed_at)
+	)
+	SELECT * FROM (
+		(SELECT p.id, p.user_id, p.title, p.description, p.created_at, p.updated_at, p.is_private, p.visibility, p.tags
+		FROM feed_timeline t
+		JOIN posts p ON p.id = t.post_id
+		JOIN follows f ON f.author_id = p.user_id
+		WHERE t.user_id = $1 AND p.created_at >= f.followed_at AND t.created_at >= $8
+			AND p.hidden_at IS NULL AND ` + visibleTo("p.", 1, 2, 7) + `
+			AND (t.created_at, t.post_id) < ($4, $5)
+		ORDER BY t.created_at DESC, t.post_id DESC
+		LIMIT $6)
+		UNION ALL
+		(SELECT p.id, p.user_id, p.title, p.description, p.created_at, p.updated_at, p.is_private, p.visibility, p.tags
+		FROM posts p
+		JOIN follows f ON f.author_id = p.user_id
+		WHERE (NOT p.fanned_out OR p.created_at < f.followed_at OR p.created_at < $8)
+			AND p.hidden_at IS NULL AND ` + visibleTo("p.", 1, 2, 7) + `
+			AND (p.created_at, p.id) < ($4, $5)
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $6)
+	) feed
+	ORDER BY created_at DESC, id DESC
+	LIMIT $6`
+
+	posts := []models.Post{}
+	err := r.db.SelectContext(ctx, &posts, query, audience.ReaderID, authorIDs, pq.StringArray(followedAt), cursor.CreatedAt, cursor.PostID, limit, idArray(audience.CloseFriendOf), timelineSince)
+	if err != nil {
+		return nil, fmt.Errorf("could not get feed: %w", err)
+	}
+	return posts, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/zahartd/social-network/src/events"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+type postgresOutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresOutboxRepository(db *sqlx.DB) events.OutboxStore {
+	return &postgresOutboxRepository{db: db}
+}
+
+// PublishPending holds the row locks while publishing, so concurrent relays
+// skip each other's batches. A crash after publish but before commit leaves
+// the rows unsent and they are published again: delivery is at least once.
+func (r *postgresOutboxRepository) PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, msgs []events.OutboxMessage) error) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	msgs := []models.OutboxMessage{}
+	query := `SELECT id, event_id, topic, msg_key, payload, content_type, created_at, sent_at
+              FROM outbox
+              WHERE sent_at IS NULL
+              ORDER BY id
+              LIMIT $1
+              FOR UPDATE SKIP LOCKED`
+	if err := tx.SelectContext(ctx, &msgs, query, limit); err != nil {
+		return 0, fmt.Errorf("could not fetch pending outbox messages: %w", err)
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	pending := make([]events.OutboxMessage, 0, len(msgs))
+	ids := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		pending = append(pending, events.OutboxMessage{
+			EventID: msg.EventID, Topic: msg.Topic, Key: msg.Key, Payload: msg.Payload, ContentType: msg.ContentType,
+		})
+		ids = append(ids, msg.ID)
+	}
+	if err := publish(ctx, pending); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE outbox SET sent_at = NOW() WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return 0, fmt.Errorf("could not mark outbox messages sent: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit outbox batch: %w", err)
+	}
+	return len(msgs), nil
+}
+
+func (r *postgresOutboxRepository) DeleteSent(ctx context.Context, sentBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE sent_at < $1`, sentBefore)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete sent outbox messages: %w", err)
+	}
+	return result.RowsAffected()
+}
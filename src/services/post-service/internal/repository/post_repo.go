@@ -5,44 +5,139 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/zahartd/social-network/src/services/post-service/internal/models"
 )
 
 var ErrPostNotFound = errors.New("post not found")
+var ErrCommentNotFound = errors.New("comment not found")
 var ErrForbidden = errors.New("forbidden")
 
+const pgForeignKeyViolation = "23503"
+
+// queryer is the subset of sqlx shared by *sqlx.DB and *sqlx.Tx, so the same
+// repository code runs both inside and outside a transaction.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
 type PostRepository interface {
+	// RunInTx calls fn with a repository bound to a single transaction that
+	// is committed if fn returns nil and rolled back otherwise.
+	RunInTx(ctx context.Context, fn func(repo PostRepository) error) error
+	EnqueueEvent(ctx context.Context, msg *models.OutboxMessage) error
 	CreatePost(ctx context.Context, post *models.Post) (string, error)
 	GetPostByID(ctx context.Context, postID string) (*models.Post, error)
 	UpdatePost(ctx context.Context, post *models.Post) error
-	DeletePost(ctx context.Context, postID string, userID string) error
+	DeletePost(ctx context.Context, postID string, userID string) (*models.Post, error)
 	GetUserPosts(ctx context.Context, userID string, page, pageSize int) ([]models.Post, int, error)
-	GetPublicPosts(ctx context.Context, filterUserID *string, page, pageSize int) ([]models.Post, int, error)
+	// GetPublicPosts lists the reader's own posts and those shared with the
+	// audience, newest first.
+	GetPublicPosts(ctx context.Context, audience *models.Audience, filterUserID *string, page, pageSize int) ([]models.Post, int, error)
+	// SearchPosts lists the posts matching search among the reader's own
+	// posts and those shared with the audience.
+	SearchPosts(ctx context.Context, audience *models.Audience, search *models.PostSearch, page, pageSize int) ([]models.Post, int, error)
+	EnqueueFanOut(ctx context.Context, postID string) error
+	// ClaimFanOutJobs leases up to limit due jobs, oldest first, by
+	// postponing them until leaseUntil. A job that is neither finished nor
+	// retried before then is claimed again.
+	ClaimFanOutJobs(ctx context.Context, limit int, leaseUntil time.Time) ([]models.FanOutJob, error)
+	FanOutPost(ctx context.Context, postID string, createdAt time.Time, followerIDs []string) error
+	// SkipFanOut drops the job of a post that stays out of the timelines.
+	SkipFanOut(ctx context.Context, postID string) error
+	// RetryFanOut counts a failed attempt and postpones the job until at.
+	RetryFanOut(ctx context.Context, postID string, at time.Time) error
+	// PruneTimeline deletes timeline entries of posts created before before.
+	PruneTimeline(ctx context.Context, before time.Time) (int64, error)
+	// GetFeed reads timeline entries no older than timelineSince; older
+	// posts come from posts.
+	GetFeed(ctx context.Context, audience *models.Audience, follows []models.Follow, after *models.FeedCursor, timelineSince time.Time, limit int) ([]models.Post, error)
 	GetPostAuthorID(ctx context.Context, postID string) (string, error)
+	GetCommentAuthorID(ctx context.Context, postID, commentID string) (string, error)
 	RecordView(ctx context.Context, userID, postID string) error
-	RecordLike(ctx context.Context, userID, postID string) error
-	RemoveLike(ctx context.Context, userID, postID string) error
+	RecordLike(ctx context.Context, userID, postID string) (bool, error)
+	RemoveLike(ctx context.Context, userID, postID string) (bool, error)
+	CountLikes(ctx context.Context, postID string) (int64, error)
 	CreateComment(ctx context.Context, cm *models.Comment) (string, error)
 	CreateReply(ctx context.Context, rp *models.Reply) (string, error)
-	ListComments(ctx context.Context, postID string, page, pageSize int) ([]models.Comment, int, error)
-	ListReplies(ctx context.Context, parentCommentID string) ([]models.Reply, error)
+	// ListComments and ListReplies leave out comments by excludedUserIDs.
+	// ListReplies returns nothing while the parent or a comment above it is
+	// hidden, or if the parent is not a comment of postID.
+	ListComments(ctx context.Context, postID string, excludedUserIDs []string, page, pageSize int) ([]models.Comment, int, error)
+	ListReplies(ctx context.Context, postID, parentCommentID string, excludedUserIDs []string) ([]models.Reply, error)
+	SetPostHidden(ctx context.Context, postID string, hidden bool) error
+	// DeleteAnyPost removes a post regardless of its author.
+	DeleteAnyPost(ctx context.Context, postID string) (*models.Post, error)
+	SetCommentHidden(ctx context.Context, postID, commentID string, hidden bool) error
+	// DeleteComment returns the deleted comment and every reply deleted
+	// along with it.
+	DeleteComment(ctx context.Context, postID, commentID string) ([]models.Comment, error)
+	RecordModerationAction(ctx context.Context, action *models.ModerationAction) error
+	ListModerationActions(ctx context.Context, postID string, page, pageSize int) ([]models.ModerationAction, int, error)
 }
 
 type postgresPostRepository struct {
-	db *sqlx.DB
+	db queryer
+	// pool is nil for a repository bound to a transaction.
+	pool *sqlx.DB
 }
 
 func NewPostgresPostRepository(db *sqlx.DB) PostRepository {
-	return &postgresPostRepository{db: db}
+	return &postgresPostRepository{db: db, pool: db}
+}
+
+func (r *postgresPostRepository) RunInTx(ctx context.Context, fn func(repo PostRepository) error) error {
+	if r.pool == nil {
+		return fn(r)
+	}
+
+	tx, err := r.pool.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(&postgresPostRepository{db: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// EnqueueEvent stores a message for the outbox relay. Called through RunInTx
+// it becomes visible to the relay only if the transaction commits.
+func (r *postgresPostRepository) EnqueueEvent(ctx context.Context, msg *models.OutboxMessage) error {
+	query := `INSERT INTO outbox (event_id, topic, msg_key, payload, content_type) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, query, msg.EventID, msg.Topic, msg.Key, msg.Payload, msg.ContentType)
+	if err != nil {
+		return fmt.Errorf("could not enqueue event: %w", err)
+	}
+	return nil
+}
+
+// mapPostRefError turns a violated post_id foreign key into ErrPostNotFound.
+func mapPostRefError(err error, operation string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation && strings.HasSuffix(pqErr.Constraint, "_post_id_fkey") {
+		return ErrPostNotFound
+	}
+	return fmt.Errorf("could not %s: %w", operation, err)
 }
 
 func (r *postgresPostRepository) CreatePost(ctx context.Context, post *models.Post) (string, error) {
-	query := `INSERT INTO posts (user_id, title, description, is_private, tags)
+	query := `INSERT INTO posts (user_id, title, description, visibility, tags)
               VALUES ($1, $2, $3, $4, $5) RETURNING id`
 	var postID string
-	err := r.db.QueryRowContext(ctx, query, post.UserID, post.Title, post.Description, post.IsPrivate, post.Tags).Scan(&postID)
+	err := r.db.QueryRowContext(ctx, query, post.UserID, post.Title, post.Description, post.Visibility, post.Tags).Scan(&postID)
 	if err != nil {
 		return "", fmt.Errorf("could not create post: %w", err)
 	}
@@ -50,9 +145,9 @@ func (r *postgresPostRepository) CreatePost(ctx context.Context, post *models.Po
 }
 
 func (r *postgresPostRepository) GetPostByID(ctx context.Context, postID string) (*models.Post, error) {
-	query := `SELECT id, user_id, title, description, created_at, updated_at, is_private, tags FROM posts WHERE id = $1`
+	query := `SELECT id, user_id, title, description, created_at, updated_at, is_private, visibility, tags, hidden_at FROM posts WHERE id = $1`
 	var post models.Post
-	err := r.db.Get(&post, query, postID)
+	err := r.db.GetContext(ctx, &post, query, postID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrPostNotFound
@@ -75,10 +170,23 @@ func (r *postgresPostRepository) GetPostAuthorID(ctx context.Context, postID str
 	return userID, nil
 }
 
+func (r *postgresPostRepository) GetCommentAuthorID(ctx context.Context, postID, commentID string) (string, error) {
+	query := `SELECT user_id FROM comments WHERE id = $1 AND post_id = $2`
+	var userID string
+	err := r.db.GetContext(ctx, &userID, query, commentID, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrCommentNotFound
+		}
+		return "", fmt.Errorf("database error fetching comment author: %w", err)
+	}
+	return userID, nil
+}
+
 func (r *postgresPostRepository) UpdatePost(ctx context.Context, post *models.Post) error {
-	query := `UPDATE posts SET title = $1, description = $2, is_private = $3, tags = $4, updated_at = NOW()
+	query := `UPDATE posts SET title = $1, description = $2, visibility = $3, tags = $4, updated_at = NOW()
               WHERE id = $5`
-	result, err := r.db.ExecContext(ctx, query, post.Title, post.Description, post.IsPrivate, post.Tags, post.ID)
+	result, err := r.db.ExecContext(ctx, query, post.Title, post.Description, post.Visibility, post.Tags, post.ID)
 	if err != nil {
 		return fmt.Errorf("could not update post: %w", err)
 	}
@@ -92,34 +200,35 @@ func (r *postgresPostRepository) UpdatePost(ctx context.Context, post *models.Po
 	return nil
 }
 
-func (r *postgresPostRepository) DeletePost(ctx context.Context, postID string, userID string) error {
-	query := `DELETE FROM posts WHERE id = $1 AND user_id = $2`
-	result, err := r.db.ExecContext(ctx, query, postID, userID)
-	if err != nil {
-		return fmt.Errorf("could not delete post: %w", err)
+// DeletePost removes the post of the given author and returns it as it was
+// right before the deletion.
+func (r *postgresPostRepository) DeletePost(ctx context.Context, postID string, userID string) (*models.Post, error) {
+	query := `DELETE FROM posts WHERE id = $1 AND user_id = $2
+              RETURNING id, user_id, title, description, created_at, updated_at, is_private, visibility, tags`
+	var post models.Post
+	err := r.db.GetContext(ctx, &post, query, postID, userID)
+	if err == nil {
+		return &post, nil
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("could not verify post deletion: %w", err)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("could not delete post: %w", err)
 	}
-	if rowsAffected == 0 {
-		existsQuery := `SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)`
-		var exists bool
-		err := r.db.QueryRowContext(ctx, existsQuery, postID).Scan(&exists)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("could not delete post %s", postID)
-		}
-		if !exists {
-			return ErrPostNotFound
-		}
-		return ErrForbidden
+
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)`
+	var exists bool
+	err = r.db.QueryRowContext(ctx, existsQuery, postID).Scan(&exists)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("could not delete post %s", postID)
 	}
-	return nil
+	if !exists {
+		return nil, ErrPostNotFound
+	}
+	return nil, ErrForbidden
 }
 
 func (r *postgresPostRepository) GetUserPosts(ctx context.Context, userID string, page, pageSize int) ([]models.Post, int, error) {
 	offset := (page - 1) * pageSize
-	query := `SELECT id, user_id, title, description, created_at, updated_at, is_private, tags
+	query := `SELECT id, user_id, title, description, created_at, updated_at, is_private, visibility, tags, hidden_at
               FROM posts
               WHERE user_id = $1
               ORDER BY created_at DESC
@@ -141,25 +250,24 @@ func (r *postgresPostRepository) GetUserPosts(ctx context.Context, userID string
 	return posts, totalCount, nil
 }
 
-func (r *postgresPostRepository) GetPublicPosts(ctx context.Context, filterUserID *string, page, pageSize int) ([]models.Post, int, error) {
+func (r *postgresPostRepository) GetPublicPosts(ctx context.Context, audience *models.Audience, filterUserID *string, page, pageSize int) ([]models.Post, int, error) {
 	offset := (page - 1) * pageSize
-	args := []any{}
-	countArgs := []any{}
+	args := []any{idArray(audience.Following), idArray(audience.CloseFriendOf), idArray(audience.Hidden), nullableID(audience.ReaderID)}
 
-	queryBase := `SELECT id, user_id, title, description, created_at, updated_at, is_private, tags FROM posts`
+	queryBase := `SELECT id, user_id, title, description, created_at, updated_at, is_private, visibility, tags FROM posts`
 	countQueryBase := `SELECT COUNT(*) FROM posts`
-	whereClause := ` WHERE is_private = FALSE`
+	whereClause := ` WHERE hidden_at IS NULL AND NOT user_id = ANY($3::uuid[]) AND ` + visibleTo("", 4, 1, 2)
 
-	paramIndex := 1
+	paramIndex := 5
 	if filterUserID != nil && *filterUserID != "" {
 		whereClause += fmt.Sprintf(" AND user_id = $%d", paramIndex)
 		args = append(args, *filterUserID)
-		countArgs = append(countArgs, *filterUserID)
 		paramIndex++
 	}
+	countArgs := args
 
 	query := queryBase + whereClause + fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-	args = append(args, pageSize, offset)
+	args = append(args[:len(args):len(args)], pageSize, offset)
 	countQuery := countQueryBase + whereClause
 
 	posts := []models.Post{}
@@ -176,50 +284,161 @@ func (r *postgresPostRepository) GetPublicPosts(ctx context.Context, filterUserI
 	return posts, totalCount, nil
 }
 
+func (r *postgresPostRepository) SearchPosts(ctx context.Context, audience *models.Audience, search *models.PostSearch, page, pageSize int) ([]models.Post, int, error) {
+	offset := (page - 1) * pageSize
+	args := []any{idArray(audience.Following), idArray(audience.CloseFriendOf), idArray(audience.Hidden), nullableID(audience.ReaderID)}
+	whereClause := ` WHERE hidden_at IS NULL AND NOT user_id = ANY($3::uuid[]) AND ` + visibleTo("", 4, 1, 2)
+	addFilter := func(condition string, arg any) {
+		args = append(args, arg)
+		whereClause += " AND " + fmt.Sprintf(condition, len(args))
+	}
+
+	orderBy := " ORDER BY created_at DESC, id DESC"
+	if search.Query != "" {
+		addFilter("search_vector @@ websearch_to_tsquery('simple', $%d)", search.Query)
+		if search.Order == models.SearchOrderRelevance {
+			orderBy = fmt.Sprintf(" ORDER BY ts_rank_cd(search_vector, websearch_to_tsquery('simple', $%d)) DESC, created_at DESC, id DESC", len(args))
+		}
+	}
+	if len(search.Tags) > 0 {
+		addFilter("tags @> $%d::text[]", pq.StringArray(search.Tags))
+	}
+	if search.AuthorID != "" {
+		addFilter("user_id = $%d", search.AuthorID)
+	}
+	if search.From != nil {
+		addFilter("created_at >= $%d", *search.From)
+	}
+	if search.To != nil {
+		addFilter("created_at < $%d", *search.To)
+	}
+	countArgs := args
+
+	query := `SELECT id, user_id, title, description, created_at, updated_at, is_private, visibility, tags FROM posts` +
+		whereClause + orderBy + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args[:len(args):len(args)], pageSize, offset)
+
+	posts := []models.Post{}
+	if err := r.db.SelectContext(ctx, &posts, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("could not search posts: %w", err)
+	}
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, `SELECT COUNT(*) FROM posts`+whereClause, countArgs...); err != nil {
+		return nil, 0, fmt.Errorf("could not count found posts: %w", err)
+	}
+	return posts, totalCount, nil
+}
+
+// idArray passes ids as an array parameter. A nil pq.StringArray would be
+// sent as NULL, and comparisons with ANY(NULL) are never true or false.
+func idArray(ids []string) pq.StringArray {
+	if ids == nil {
+		return pq.StringArray{}
+	}
+	return ids
+}
+
+// nullableID passes an empty id as NULL, which matches no user_id.
+func nullableID(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+// visibleTo is the condition under which a post is listed for a reader: the
+// reader's own posts and the posts shared with the reader. It takes the
+// parameter numbers of the reader, of the authors the reader follows and of
+// the authors who have the reader as a close friend. alias qualifies the
+// columns, as in "p.".
+func visibleTo(alias string, readerParam, followingParam, closeFriendOfParam int) string {
+	return fmt.Sprintf(`(%[1]suser_id = $%[2]d
+		OR %[1]svisibility = 'public'
+		OR (%[1]svisibility = 'followers' AND %[1]suser_id = ANY($%[3]d::uuid[]))
+		OR (%[1]svisibility = 'close_friends' AND %[1]suser_id = ANY($%[4]d::uuid[])))`, alias, readerParam, followingParam, closeFriendOfParam)
+}
+
 func (r *postgresPostRepository) RecordView(ctx context.Context, userID, postID string) error {
 	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO post_views (user_id, post_id) VALUES ($1,$2)`, userID, postID)
-	return err
+	if err != nil {
+		return mapPostRefError(err, "record view")
+	}
+	return nil
 }
 
-func (r *postgresPostRepository) RecordLike(ctx context.Context, userID, postID string) error {
-	_, err := r.db.ExecContext(ctx,
+// RecordLike reports whether the like is new.
+func (r *postgresPostRepository) RecordLike(ctx context.Context, userID, postID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
 		`INSERT INTO post_likes (user_id, post_id) VALUES ($1,$2) ON CONFLICT DO NOTHING`, userID, postID)
-	return err
+	if err != nil {
+		return false, mapPostRefError(err, "record like")
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("could not verify like insert: %w", err)
+	}
+	return inserted > 0, nil
 }
 
-func (r *postgresPostRepository) RemoveLike(ctx context.Context, userID, postID string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM post_likes WHERE user_id=$1 AND post_id=$2`, userID, postID)
-	return err
+// RemoveLike reports whether there was a like to remove.
+func (r *postgresPostRepository) RemoveLike(ctx context.Context, userID, postID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM post_likes WHERE user_id=$1 AND post_id=$2`, userID, postID)
+	if err != nil {
+		return false, fmt.Errorf("could not remove like: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("could not verify like removal: %w", err)
+	}
+	return removed > 0, nil
+}
+
+func (r *postgresPostRepository) CountLikes(ctx context.Context, postID string) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM post_likes WHERE post_id=$1`, postID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("could not count likes: %w", err)
+	}
+	return count, nil
 }
 
 func (r *postgresPostRepository) CreateComment(ctx context.Context, cm *models.Comment) (string, error) {
 	query := `INSERT INTO comments (post_id, user_id, text) VALUES ($1,$2,$3) RETURNING id`
 	var id string
 	err := r.db.QueryRowContext(ctx, query, cm.PostID, cm.UserID, cm.Text).Scan(&id)
-	return id, err
+	if err != nil {
+		return "", mapPostRefError(err, "create comment")
+	}
+	return id, nil
 }
 
 func (r *postgresPostRepository) CreateReply(ctx context.Context, rp *models.Reply) (string, error) {
 	query := `INSERT INTO comments (post_id, parent_comment_id, user_id, text) VALUES ($1,$2,$3,$4) RETURNING id`
 	var id string
 	err := r.db.QueryRowContext(ctx, query, rp.PostID, rp.ParentCommentID, rp.UserID, rp.Text).Scan(&id)
-	return id, err
+	if err != nil {
+		return "", mapPostRefError(err, "create reply")
+	}
+	return id, nil
 }
 
-func (r *postgresPostRepository) ListComments(ctx context.Context, postID string, page, pageSize int) ([]models.Comment, int, error) {
+func (r *postgresPostRepository) ListComments(ctx context.Context, postID string, excludedUserIDs []string, page, pageSize int) ([]models.Comment, int, error) {
 	offset := (page - 1) * pageSize
 	comments := []models.Comment{}
 	err := r.db.SelectContext(
 		ctx,
 		&comments,
-		`SELECT id, post_id, parent_comment_id, user_id, text, created_at
+		`SELECT id, post_id, user_id, text, created_at
 		   FROM comments
 		  WHERE post_id = $1
 		    AND parent_comment_id IS NULL
+		    AND hidden_at IS NULL
+		    AND NOT user_id = ANY($2::uuid[])
 		  ORDER BY created_at DESC
-		  LIMIT $2 OFFSET $3`,
-		postID, pageSize, offset,
+		  LIMIT $3 OFFSET $4`,
+		postID, idArray(excludedUserIDs), pageSize, offset,
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("could not list comments: %w", err)
@@ -231,8 +450,10 @@ func (r *postgresPostRepository) ListComments(ctx context.Context, postID string
 		`SELECT COUNT(*)
 		   FROM comments
 		  WHERE post_id = $1
-		    AND parent_comment_id IS NULL`,
-		postID,
+		    AND parent_comment_id IS NULL
+		    AND hidden_at IS NULL
+		    AND NOT user_id = ANY($2::uuid[])`,
+		postID, idArray(excludedUserIDs),
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("could not count comments: %w", err)
@@ -241,19 +462,134 @@ func (r *postgresPostRepository) ListComments(ctx context.Context, postID string
 	return comments, total, nil
 }
 
-func (r *postgresPostRepository) ListReplies(ctx context.Context, parentID string) ([]models.Reply, error) {
+func (r *postgresPostRepository) ListReplies(ctx context.Context, postID, parentID string, excludedUserIDs []string) ([]models.Reply, error) {
 	replies := []models.Reply{}
 	err := r.db.SelectContext(
 		ctx,
 		&replies,
-		`SELECT id, post_id, parent_comment_id, user_id, text, created_at
+		`SELECT id, post_id, user_id, text, created_at
 		   FROM comments
 		  WHERE parent_comment_id = $1
+		    AND post_id = $3
+		    AND hidden_at IS NULL
+		    AND NOT user_id = ANY($2::uuid[])
+		    AND NOT EXISTS (
+		        WITH RECURSIVE ancestors AS (
+		            SELECT id, parent_comment_id, hidden_at FROM comments WHERE id = $1
+		            UNION ALL
+		            SELECT c.id, c.parent_comment_id, c.hidden_at
+		              FROM comments c JOIN ancestors a ON c.id = a.parent_comment_id
+		        )
+		        SELECT 1 FROM ancestors WHERE hidden_at IS NOT NULL
+		    )
 		  ORDER BY created_at`,
-		parentID,
+		parentID, idArray(excludedUserIDs), postID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not list replies: %w", err)
 	}
 	return replies, nil
 }
+
+func (r *postgresPostRepository) SetPostHidden(ctx context.Context, postID string, hidden bool) error {
+	query := `UPDATE posts SET hidden_at = CASE WHEN $2 THEN COALESCE(hidden_at, NOW()) END WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, postID, hidden)
+	if err != nil {
+		return fmt.Errorf("could not change post visibility: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify post visibility change: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+func (r *postgresPostRepository) DeleteAnyPost(ctx context.Context, postID string) (*models.Post, error) {
+	query := `DELETE FROM posts WHERE id = $1
+              RETURNING id, user_id, title, description, created_at, updated_at, is_private, visibility, tags, hidden_at`
+	var post models.Post
+	err := r.db.GetContext(ctx, &post, query, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, fmt.Errorf("could not delete post: %w", err)
+	}
+	return &post, nil
+}
+
+func (r *postgresPostRepository) SetCommentHidden(ctx context.Context, postID, commentID string, hidden bool) error {
+	query := `UPDATE comments SET hidden_at = CASE WHEN $3 THEN COALESCE(hidden_at, NOW()) END
+              WHERE id = $1 AND post_id = $2`
+	result, err := r.db.ExecContext(ctx, query, commentID, postID, hidden)
+	if err != nil {
+		return fmt.Errorf("could not change comment visibility: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify comment visibility change: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// DeleteComment removes the comment along with its replies. The replies
+// would go by the cascade anyway; deleting them explicitly reports them.
+func (r *postgresPostRepository) DeleteComment(ctx context.Context, postID, commentID string) ([]models.Comment, error) {
+	query := `WITH RECURSIVE thread AS (
+                  SELECT id FROM comments WHERE id = $1 AND post_id = $2
+                  UNION ALL
+                  SELECT c.id FROM comments c JOIN thread t ON c.parent_comment_id = t.id
+              )
+              DELETE FROM comments
+              WHERE id IN (SELECT id FROM thread)
+              RETURNING id, post_id, user_id, text, created_at`
+	deleted := []models.Comment{}
+	if err := r.db.SelectContext(ctx, &deleted, query, commentID, postID); err != nil {
+		return nil, fmt.Errorf("could not delete comment: %w", err)
+	}
+	if len(deleted) == 0 {
+		return nil, ErrCommentNotFound
+	}
+	return deleted, nil
+}
+
+func (r *postgresPostRepository) RecordModerationAction(ctx context.Context, action *models.ModerationAction) error {
+	query := `INSERT INTO moderation_actions (target_type, target_id, post_id, action, reason, moderator_id)
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+	err := r.db.QueryRowContext(ctx, query,
+		action.TargetType, action.TargetID, action.PostID, action.Action, action.Reason, action.ModeratorID,
+	).Scan(&action.ID, &action.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("could not record moderation action: %w", err)
+	}
+	return nil
+}
+
+// ListModerationActions returns the audit trail of a post and its comments,
+// newest first.
+func (r *postgresPostRepository) ListModerationActions(ctx context.Context, postID string, page, pageSize int) ([]models.ModerationAction, int, error) {
+	offset := (page - 1) * pageSize
+	query := `SELECT id, target_type, target_id, post_id, action, reason, moderator_id, created_at
+              FROM moderation_actions
+              WHERE post_id = $1
+              ORDER BY created_at DESC, id
+              LIMIT $2 OFFSET $3`
+	actions := []models.ModerationAction{}
+	err := r.db.SelectContext(ctx, &actions, query, postID, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not list moderation actions: %w", err)
+	}
+
+	var total int
+	err = r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM moderation_actions WHERE post_id = $1`, postID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not count moderation actions: %w", err)
+	}
+	return actions, total, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+// restrictions looks up the blocks and mutes of the user. Blocks protect
+// users from harassment, so a failed lookup fails the request instead of
+// letting it through.
+func (s *PostService) restrictions(ctx context.Context, userID string) (*models.Restrictions, error) {
+	if userID == "" || s.relations == nil {
+		return &models.Restrictions{}, nil
+	}
+	r, err := s.relations.Restrictions(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to check blocked users: %v", err)
+	}
+	return r, nil
+}
+
+// checkNotBlocked fails with PermissionDenied if either user blocked the
+// other.
+func (s *PostService) checkNotBlocked(ctx context.Context, userID, otherID string) error {
+	if userID == otherID {
+		return nil
+	}
+	r, err := s.restrictions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(r.Blocked, otherID) {
+		return status.Error(codes.PermissionDenied, "you cannot interact with this user")
+	}
+	return nil
+}
+
+// checkCanInteract checks that the caller can see the post and that the
+// caller and its author have not blocked each other. A post hidden by a
+// moderator or not shared with the caller is reported as not found, as if
+// it did not exist.
+func (s *PostService) checkCanInteract(ctx context.Context, userID, postID string) error {
+	post, err := s.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return handleRepoError(err, "check author of", postID)
+	}
+	if err := checkNotHidden(ctx, userID, post); err != nil {
+		return err
+	}
+	if err := s.checkCanView(ctx, userID, post); err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return status.Errorf(codes.NotFound, "post %s not found", postID)
+		}
+		return err
+	}
+	return s.checkNotBlocked(ctx, userID, post.UserID)
+}
+
+// hiddenFrom returns the users whose posts and comments are left out of
+// the listings of userID.
+func hiddenFrom(r *models.Restrictions) []string {
+	return append(slices.Clone(r.Blocked), r.Muted...)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+func TestCheckNotBlocked(t *testing.T) {
+	lookup := &fakeLookup{restrictions: models.Restrictions{Blocked: []string{"blocked"}, Muted: []string{"muted"}}}
+	tests := []struct {
+		name    string
+		otherID string
+		lookup  *fakeLookup
+		want    codes.Code
+	}{
+		{"blocked either way", "blocked", lookup, codes.PermissionDenied},
+		{"muted only", "muted", lookup, codes.OK},
+		{"unrelated", "other", lookup, codes.OK},
+		{"lookup failure", "other", &fakeLookup{err: errors.New("down")}, codes.Unavailable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewPostService(nil, events.Codec{}, false, tc.lookup, 0)
+			err := s.checkNotBlocked(context.Background(), "reader", tc.otherID)
+			if status.Code(err) != tc.want {
+				t.Errorf("checkNotBlocked error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAudienceHidesBlockedAndMutedAuthors(t *testing.T) {
+	lookup := &fakeLookup{
+		following:    []models.Follow{{AuthorID: "a1"}},
+		restrictions: models.Restrictions{Blocked: []string{"b1"}, Muted: []string{"m1"}},
+	}
+	s := NewPostService(nil, events.Codec{}, false, lookup, 0)
+	audience, err := s.audience(context.Background(), "reader")
+	if err != nil {
+		t.Fatalf("audience returned error: %v", err)
+	}
+	if !slices.Equal(audience.Hidden, []string{"b1", "m1"}) || !slices.Equal(audience.Following, []string{"a1"}) {
+		t.Errorf("unexpected audience %+v", audience)
+	}
+
+	s = NewPostService(nil, events.Codec{}, false, &fakeLookup{err: errors.New("down")}, 0)
+	if _, err := s.audience(context.Background(), "reader"); status.Code(err) != codes.Unavailable {
+		t.Errorf("audience error = %v, want Unavailable", err)
+	}
+}
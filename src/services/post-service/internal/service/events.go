@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zahartd/social-network/src/events"
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/repository"
+)
+
+const (
+	topicPostViews     = "post-views"
+	topicPostLikes     = "post-likes"
+	topicPostComments  = "post-comments"
+	topicPostLifecycle = "post-lifecycle"
+)
+
+func toEventPostSnapshot(post *models.Post) *eventspb.PostSnapshot {
+	return &eventspb.PostSnapshot{
+		PostId:    post.ID,
+		UserId:    post.UserID,
+		Tags:      post.Tags,
+		IsPrivate: post.IsPrivate,
+		CreatedAt: timestamppb.New(post.CreatedAt),
+		UpdatedAt: timestamppb.New(post.UpdatedAt),
+		Hidden:    post.HiddenAt != nil,
+	}
+}
+
+// enqueueEvent wraps payload in an envelope and adds it to the outbox of
+// repo. Lifecycle events are keyed by post ID, so all events of one post land
+// in the same partition in order.
+func (s *PostService) enqueueEvent(ctx context.Context, repo repository.PostRepository, topic, key string, payload proto.Message, occurredAt time.Time) error {
+	env, err := events.NewEnvelope(payload, occurredAt)
+	if err != nil {
+		return err
+	}
+	value, err := s.codec.Encode(env)
+	if err != nil {
+		return fmt.Errorf("could not encode %s event: %w", env.GetEventType(), err)
+	}
+	return repo.EnqueueEvent(ctx, &models.OutboxMessage{
+		EventID:     env.GetEventId(),
+		Topic:       topic,
+		Key:         key,
+		Payload:     value,
+		ContentType: s.codec.ContentType(),
+	})
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/utils"
+)
+
+const (
+	defaultFeedPageSize = 10
+	maxFeedPageSize     = 100
+
+	fanOutBatchSize     = 20
+	fanOutPollInterval  = time.Second
+	fanOutRetryDelay    = 5 * time.Second
+	maxFanOutRetryDelay = 10 * time.Minute
+	maxFanOutAttempts   = 10
+	// A claimed job is not handed out again for fanOutLease, which must
+	// outlast one batch of follower lookups.
+	fanOutLease = 5 * time.Minute
+	// Timeline entries older than timelineRetention are pruned every
+	// pruneInterval; the feed reads such posts from posts.
+	timelineRetention = 30 * 24 * time.Hour
+	pruneInterval     = time.Hour
+)
+
+// schedulesFanOut reports whether CreatePost should enqueue a fan-out job
+// for the post: only posts shared with all followers are fanned out.
+func (s *PostService) schedulesFanOut(post *models.Post) bool {
+	return s.relations != nil && s.fanoutMaxFollowers > 0 && sharedWithFollowers(post.Visibility)
+}
+
+func sharedWithFollowers(v models.Visibility) bool {
+	return v == models.VisibilityPublic || v == models.VisibilityFollowers
+}
+
+// RunFanOut writes new posts into the timelines of the authors' followers
+// until ctx is cancelled. Due jobs are drained back to back; otherwise they
+// are polled every fanOutPollInterval. It also prunes old timeline entries.
+func (s *PostService) RunFanOut(ctx context.Context) {
+	var lastPrune time.Time
+	for {
+		claimed, err := s.fanOutPending(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("feed fan-out: %v", err)
+		}
+
+		if time.Since(lastPrune) >= pruneInterval {
+			if deleted, err := s.repo.PruneTimeline(ctx, time.Now().Add(-timelineRetention)); err != nil {
+				log.Printf("feed fan-out: %v", err)
+			} else if deleted > 0 {
+				log.Printf("feed fan-out: pruned %d timeline entries", deleted)
+			}
+			lastPrune = time.Now()
+		}
+
+		delay := fanOutPollInterval
+		if claimed == fanOutBatchSize {
+			delay = 0
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// fanOutPending processes one batch of due jobs and returns its size. No
+// transaction is held while followers are looked up: every job is leased
+// when claimed and finished by a statement of its own.
+func (s *PostService) fanOutPending(ctx context.Context) (int, error) {
+	jobs, err := s.repo.ClaimFanOutJobs(ctx, fanOutBatchSize, time.Now().Add(fanOutLease))
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, job := range jobs {
+		errs = append(errs, s.fanOut(ctx, &job))
+	}
+	return len(jobs), errors.Join(errs...)
+}
+
+// fanOut writes the post of job into the timelines of the author's
+// followers, unless it is no longer shared with them or the author has more
+// than fanoutMaxFollowers of them. A failed follower lookup is retried with
+// backoff and given up after maxFanOutAttempts. Posts left out this way are
+// picked up when the feed is read, so only database errors are returned; the
+// job is then claimed again once its lease runs out.
+func (s *PostService) fanOut(ctx context.Context, job *models.FanOutJob) error {
+	if !sharedWithFollowers(job.Visibility) {
+		return s.repo.SkipFanOut(ctx, job.PostID)
+	}
+	followers, err := s.relations.Followers(ctx, job.AuthorID, s.fanoutMaxFollowers+1)
+	if err != nil {
+		if job.Attempts+1 >= maxFanOutAttempts {
+			log.Printf("giving up fan-out of post %s: %v", job.PostID, err)
+			return s.repo.SkipFanOut(ctx, job.PostID)
+		}
+		log.Printf("failed to fan out post %s, will retry: %v", job.PostID, err)
+		return s.repo.RetryFanOut(ctx, job.PostID, time.Now().Add(fanOutBackoff(job.Attempts)))
+	}
+	if len(followers) > s.fanoutMaxFollowers {
+		return s.repo.SkipFanOut(ctx, job.PostID)
+	}
+	return s.repo.FanOutPost(ctx, job.PostID, job.CreatedAt, followers)
+}
+
+// fanOutBackoff doubles the retry delay with each failed attempt.
+func fanOutBackoff(attempts int) time.Duration {
+	d := fanOutRetryDelay
+	for i := 0; i < attempts && d < maxFanOutRetryDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxFanOutRetryDelay)
+}
+
+// GetFeed returns the posts of the authors the user follows that are shared
+// with the user, newest first. The cursor of the response points at the
+// next page; it is empty on the last one.
+func (s *PostService) GetFeed(ctx context.Context, req *postpb.GetFeedRequest) ([]*postpb.Post, string, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := utils.ValidateUserID(userID); err != nil {
+		return nil, "", err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultFeedPageSize
+	}
+	if pageSize < 0 || pageSize > maxFeedPageSize {
+		return nil, "", status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxFeedPageSize)
+	}
+	var after *models.FeedCursor
+	if req.GetCursor() != "" {
+		after, err = decodeFeedCursor(req.GetCursor())
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+	}
+
+	follows, err := s.relations.Following(ctx, userID)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Unavailable, "failed to get followed users: %v", err)
+	}
+	r, err := s.restrictions(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	hidden := hiddenFrom(r)
+	follows = slices.DeleteFunc(follows, func(f models.Follow) bool { return slices.Contains(hidden, f.AuthorID) })
+	if len(follows) == 0 {
+		return []*postpb.Post{}, "", nil
+	}
+
+	audience := &models.Audience{ReaderID: userID, CloseFriendOf: s.closeFriendOf(ctx, userID)}
+	posts, err := s.repo.GetFeed(ctx, audience, follows, after, time.Now().Add(-timelineRetention), pageSize+1)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to get feed: %v", err)
+	}
+
+	var nextCursor string
+	if len(posts) > pageSize {
+		posts = posts[:pageSize]
+		last := posts[len(posts)-1]
+		nextCursor = encodeFeedCursor(&models.FeedCursor{CreatedAt: last.CreatedAt, PostID: last.ID})
+	}
+	protoPosts := make([]*postpb.Post, 0, len(posts))
+	for _, post := range posts {
+		protoPosts = append(protoPosts, ToProtoPost(&post))
+	}
+	return protoPosts, nextCursor, nil
+}
+
+// The cursor is opaque to clients; it is the creation time and ID of the
+// last post of the page.
+func encodeFeedCursor(c *models.FeedCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.PostID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (*models.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, postID, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("malformed cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePostID(postID); err != nil {
+		return nil, err
+	}
+	return &models.FeedCursor{CreatedAt: t, PostID: postID}, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+func TestFeedCursorRoundTrip(t *testing.T) {
+	want := &models.FeedCursor{
+		CreatedAt: time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC),
+		PostID:    "0b6f6b8e-9d7a-4c1e-8f3b-2a6d5e4c3b21",
+	}
+	got, err := decodeFeedCursor(encodeFeedCursor(want))
+	if err != nil {
+		t.Fatalf("decodeFeedCursor returned error: %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.PostID != want.PostID {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+}
+
+func TestGetFeedRejectsInvalidRequests(t *testing.T) {
+	s := NewPostService(nil, events.Codec{}, false, nil, 0)
+	ctx := callerContext(t, "true")
+	tests := []struct {
+		name string
+		req  *postpb.GetFeedRequest
+	}{
+		{"negative page size", &postpb.GetFeedRequest{PageSize: -1}},
+		{"page size too large", &postpb.GetFeedRequest{PageSize: maxFeedPageSize + 1}},
+		{"cursor not base64", &postpb.GetFeedRequest{Cursor: "not a cursor!"}},
+		{"cursor without post ID", &postpb.GetFeedRequest{Cursor: encodeFeedCursor(&models.FeedCursor{CreatedAt: time.Now()})}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := s.GetFeed(ctx, tc.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("GetFeed error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestFanOutBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, fanOutRetryDelay},
+		{1, 2 * fanOutRetryDelay},
+		{3, 8 * fanOutRetryDelay},
+		{maxFanOutAttempts, maxFanOutRetryDelay},
+	}
+	for _, tc := range tests {
+		if got := fanOutBackoff(tc.attempts); got != tc.want {
+			t.Errorf("fanOutBackoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/repository"
+	"github.com/zahartd/social-network/src/services/post-service/internal/utils"
+)
+
+const maxModerationReasonLength = 1000
+
+var moderationActions = map[postpb.ModerationActionType]models.ModerationActionType{
+	postpb.ModerationActionType_MODERATION_ACTION_HIDE:   models.ModerationHide,
+	postpb.ModerationActionType_MODERATION_ACTION_UNHIDE: models.ModerationUnhide,
+	postpb.ModerationActionType_MODERATION_ACTION_DELETE: models.ModerationDelete,
+}
+
+func ToProtoModerationAction(a *models.ModerationAction) *postpb.ModerationAction {
+	if a == nil {
+		return nil
+	}
+	action := postpb.ModerationActionType_MODERATION_ACTION_UNSPECIFIED
+	for pbAction, modelAction := range moderationActions {
+		if modelAction == a.Action {
+			action = pbAction
+		}
+	}
+	return &postpb.ModerationAction{
+		Id:          a.ID,
+		TargetType:  string(a.TargetType),
+		TargetId:    a.TargetID,
+		PostId:      a.PostID,
+		Action:      action,
+		Reason:      a.Reason,
+		ModeratorId: a.ModeratorID,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
+
+// requireModerator returns the caller's ID if they are a moderator or an
+// admin.
+func requireModerator(ctx context.Context) (string, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !auth.IsModerator(ctx) {
+		return "", status.Error(codes.PermissionDenied, "moderator role required")
+	}
+	return userID, nil
+}
+
+// checkNotHidden makes a post hidden by a moderator look deleted to everyone
+// but its author and moderators.
+func checkNotHidden(ctx context.Context, readerID string, post *models.Post) error {
+	if post.HiddenAt != nil && readerID != post.UserID && !auth.IsModerator(ctx) {
+		return status.Errorf(codes.NotFound, "post %s not found", post.ID)
+	}
+	return nil
+}
+
+func parseModeration(action postpb.ModerationActionType, reason string) (models.ModerationActionType, error) {
+	modelAction, ok := moderationActions[action]
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, "action must be hide, unhide or delete")
+	}
+	if reason == "" {
+		return "", status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if len(reason) > maxModerationReasonLength {
+		return "", status.Errorf(codes.InvalidArgument, "reason must be at most %d bytes", maxModerationReasonLength)
+	}
+	return modelAction, nil
+}
+
+// ModeratePost hides, unhides or deletes any post and records the action
+// with its reason. Every action is announced on the lifecycle topic, a
+// deleted post like one deleted by its author.
+func (s *PostService) ModeratePost(ctx context.Context, req *postpb.ModeratePostRequest) (*models.ModerationAction, error) {
+	moderatorID, err := requireModerator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePostID(req.GetPostId()); err != nil {
+		return nil, err
+	}
+	action, err := parseModeration(req.GetAction(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.ModerationAction{
+		TargetType:  models.ModerationTargetPost,
+		TargetID:    req.GetPostId(),
+		PostID:      req.GetPostId(),
+		Action:      action,
+		Reason:      req.GetReason(),
+		ModeratorID: moderatorID,
+	}
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		switch action {
+		case models.ModerationHide, models.ModerationUnhide:
+			if err := tx.SetPostHidden(ctx, req.GetPostId(), action == models.ModerationHide); err != nil {
+				return err
+			}
+			post, err := tx.GetPostByID(ctx, req.GetPostId())
+			if err != nil {
+				return err
+			}
+			var payload proto.Message = &eventspb.PostHidden{Post: toEventPostSnapshot(post)}
+			if action == models.ModerationUnhide {
+				payload = &eventspb.PostUnhidden{Post: toEventPostSnapshot(post)}
+			}
+			if err := s.enqueueEvent(ctx, tx, topicPostLifecycle, post.ID, payload, time.Now()); err != nil {
+				return err
+			}
+		case models.ModerationDelete:
+			deletedPost, err := tx.DeleteAnyPost(ctx, req.GetPostId())
+			if err != nil {
+				return err
+			}
+			err = s.enqueueEvent(ctx, tx, topicPostLifecycle, deletedPost.ID,
+				&eventspb.PostDeleted{Post: toEventPostSnapshot(deletedPost)}, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		return tx.RecordModerationAction(ctx, record)
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "moderate", req.GetPostId())
+	}
+	return record, nil
+}
+
+// ModerateComment hides, unhides or deletes any comment or reply of the
+// post. Hiding a comment hides its replies too; deleting it deletes them and
+// announces each deleted comment on the comments topic.
+func (s *PostService) ModerateComment(ctx context.Context, req *postpb.ModerateCommentRequest) (*models.ModerationAction, error) {
+	moderatorID, err := requireModerator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePostID(req.GetPostId()); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateCommentID(req.GetCommentId()); err != nil {
+		return nil, err
+	}
+	action, err := parseModeration(req.GetAction(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.ModerationAction{
+		TargetType:  models.ModerationTargetComment,
+		TargetID:    req.GetCommentId(),
+		PostID:      req.GetPostId(),
+		Action:      action,
+		Reason:      req.GetReason(),
+		ModeratorID: moderatorID,
+	}
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		if action != models.ModerationDelete {
+			if err := tx.SetCommentHidden(ctx, req.GetPostId(), req.GetCommentId(), action == models.ModerationHide); err != nil {
+				return err
+			}
+			return tx.RecordModerationAction(ctx, record)
+		}
+
+		deleted, err := tx.DeleteComment(ctx, req.GetPostId(), req.GetCommentId())
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, cm := range deleted {
+			err := s.enqueueEvent(ctx, tx, topicPostComments, cm.UserID,
+				&eventspb.CommentDeleted{UserId: cm.UserID, PostId: cm.PostID, CommentId: cm.ID}, now)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.RecordModerationAction(ctx, record)
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "moderate comment on", req.GetPostId())
+	}
+	return record, nil
+}
+
+// ListModerationActions returns the audit trail of a post, including
+// actions on its comments and on the post after it was deleted.
+func (s *PostService) ListModerationActions(ctx context.Context, req *postpb.ListModerationActionsRequest) ([]models.ModerationAction, int, error) {
+	if _, err := requireModerator(ctx); err != nil {
+		return nil, 0, err
+	}
+	if err := utils.ValidatePostID(req.GetPostId()); err != nil {
+		return nil, 0, err
+	}
+	page, err := utils.ValidatePage(strconv.Itoa(int(req.GetPage())))
+	if err != nil {
+		return nil, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize, err := utils.ValidatePageSize(strconv.Itoa(int(req.GetPageSize())))
+	if err != nil {
+		return nil, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	actions, total, err := s.repo.ListModerationActions(ctx, req.GetPostId(), page, pageSize)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to list moderation actions: %v", err)
+	}
+	return actions, total, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/repository"
+)
+
+const (
+	testPostID    = "0b6f6b8e-9d7a-4c1e-8f3b-2a6d5e4c3b21"
+	testCommentID = "7c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+)
+
+// postRepoStub serves a single post. The service must not get past its
+// checks in these tests: every other method panics through the nil
+// embedded interface.
+type postRepoStub struct {
+	repository.PostRepository
+	post *models.Post
+}
+
+func (r *postRepoStub) GetPostByID(ctx context.Context, postID string) (*models.Post, error) {
+	if r.post == nil || r.post.ID != postID {
+		return nil, repository.ErrPostNotFound
+	}
+	post := *r.post
+	return &post, nil
+}
+
+// roleContext is the context of a call by userID holding roles.
+func roleContext(t *testing.T, userID, roles string) context.Context {
+	t.Helper()
+	md := metadata.Pairs(auth.UserIDMetadataKey, userID, auth.UserRolesMetadataKey, roles)
+	var ctx context.Context
+	_, err := auth.AuthInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, nil,
+		func(c context.Context, req any) (any, error) {
+			ctx = c
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("AuthInterceptor returned error: %v", err)
+	}
+	return ctx
+}
+
+func hiddenPost(authorID string) *models.Post {
+	hiddenAt := time.Now()
+	return &models.Post{ID: testPostID, UserID: authorID, Visibility: models.VisibilityPublic, HiddenAt: &hiddenAt}
+}
+
+func TestParseModeration(t *testing.T) {
+	testCases := []struct {
+		name    string
+		action  postpb.ModerationActionType
+		reason  string
+		want    models.ModerationActionType
+		wantErr bool
+	}{
+		{"hide", postpb.ModerationActionType_MODERATION_ACTION_HIDE, "spam", models.ModerationHide, false},
+		{"unhide", postpb.ModerationActionType_MODERATION_ACTION_UNHIDE, "appeal", models.ModerationUnhide, false},
+		{"delete", postpb.ModerationActionType_MODERATION_ACTION_DELETE, "abuse", models.ModerationDelete, false},
+		{"unspecified", postpb.ModerationActionType_MODERATION_ACTION_UNSPECIFIED, "spam", "", true},
+		{"no reason", postpb.ModerationActionType_MODERATION_ACTION_HIDE, "", "", true},
+		{"long reason", postpb.ModerationActionType_MODERATION_ACTION_HIDE, strings.Repeat("a", maxModerationReasonLength+1), "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseModeration(tc.action, tc.reason)
+			if tc.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("parseModeration error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseModeration returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("parseModeration = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToProtoModerationActionRoundTrip(t *testing.T) {
+	for pbAction, modelAction := range moderationActions {
+		got := ToProtoModerationAction(&models.ModerationAction{Action: modelAction})
+		if got.GetAction() != pbAction {
+			t.Errorf("action %q maps to %v, want %v", modelAction, got.GetAction(), pbAction)
+		}
+	}
+}
+
+func TestCheckNotHidden(t *testing.T) {
+	const author, reader = "author", "reader"
+	visible := &models.Post{ID: testPostID, UserID: author}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		post *models.Post
+		want codes.Code
+	}{
+		{"visible post", roleContext(t, reader, ""), visible, codes.OK},
+		{"hidden from others", roleContext(t, reader, ""), hiddenPost(author), codes.NotFound},
+		{"hidden but own", roleContext(t, author, ""), hiddenPost(author), codes.OK},
+		{"hidden but moderator", roleContext(t, reader, auth.RoleModerator), hiddenPost(author), codes.OK},
+		{"hidden but admin", roleContext(t, reader, auth.RoleAdmin), hiddenPost(author), codes.OK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userID, _ := auth.GetUserIDFromContext(tc.ctx)
+			if err := checkNotHidden(tc.ctx, userID, tc.post); status.Code(err) != tc.want {
+				t.Errorf("checkNotHidden error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestHiddenPostRejectsInteractions(t *testing.T) {
+	s := NewPostService(&postRepoStub{post: hiddenPost("author")}, events.Codec{}, false, nil, 0)
+	ctx := callerContext(t, "true")
+	calls := map[string]func() error{
+		"ViewPost": func() error {
+			return s.ViewPost(ctx, &postpb.ViewPostRequest{PostId: testPostID})
+		},
+		"LikePost": func() error {
+			_, err := s.LikePost(ctx, &postpb.LikePostRequest{PostId: testPostID})
+			return err
+		},
+		"UnlikePost": func() error {
+			_, err := s.UnlikePost(ctx, &postpb.UnlikePostRequest{PostId: testPostID})
+			return err
+		},
+		"AddComment": func() error {
+			_, err := s.AddComment(ctx, &postpb.AddCommentRequest{PostId: testPostID, Text: "hi"})
+			return err
+		},
+		"AddReply": func() error {
+			_, err := s.AddReply(ctx, &postpb.AddReplyRequest{PostId: testPostID, ParentCommentId: testCommentID, Text: "hi"})
+			return err
+		},
+		"ListComments": func() error {
+			_, _, err := s.ListComments(ctx, &postpb.ListCommentsRequest{PostId: testPostID, Page: 1, PageSize: 10})
+			return err
+		},
+		"ListReplies": func() error {
+			_, err := s.ListReplies(ctx, &postpb.ListRepliesRequest{PostId: testPostID, ParentCommentId: testCommentID})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); status.Code(err) != codes.NotFound {
+				t.Errorf("%s error = %v, want NotFound", name, err)
+			}
+		})
+	}
+}
@@ -2,34 +2,56 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"log"
 	"strconv"
 	"time"
 
 	"github.com/lib/pq"
-	"github.com/segmentio/kafka-go"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zahartd/social-network/src/events"
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
 	postpb "github.com/zahartd/social-network/src/gen/go/post"
 	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
 	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/relations"
 	"github.com/zahartd/social-network/src/services/post-service/internal/repository"
 	"github.com/zahartd/social-network/src/services/post-service/internal/utils"
 )
 
+// PostService never talks to Kafka directly: events are written to the
+// outbox in the same transaction as the change they describe and published
+// by the outbox relay.
 type PostService struct {
-	repo          repository.PostRepository
-	viewWriter    *kafka.Writer
-	likeWriter    *kafka.Writer
-	commentWriter *kafka.Writer
+	repo  repository.PostRepository
+	codec events.Codec
+	// requireVerifiedEmail lets only users with a verified email create
+	// posts, comments and replies.
+	requireVerifiedEmail bool
+	// relations looks up followers and followed authors for the feed.
+	relations relations.Lookup
+	// fanoutMaxFollowers is the most followers an author may have for their
+	// posts to be written into follower timelines on creation.
+	fanoutMaxFollowers int
 }
 
-func NewPostService(r repository.PostRepository, vw, lw, cw *kafka.Writer) *PostService {
-	return &PostService{repo: r, viewWriter: vw, likeWriter: lw, commentWriter: cw}
+func NewPostService(r repository.PostRepository, codec events.Codec, requireVerifiedEmail bool, rel relations.Lookup, fanoutMaxFollowers int) *PostService {
+	return &PostService{
+		repo:                 r,
+		codec:                codec,
+		requireVerifiedEmail: requireVerifiedEmail,
+		relations:            rel,
+		fanoutMaxFollowers:   fanoutMaxFollowers,
+	}
+}
+
+func (s *PostService) checkCanPublish(ctx context.Context) error {
+	if s.requireVerifiedEmail && !auth.EmailVerified(ctx) {
+		return status.Error(codes.PermissionDenied, "a verified email is required to publish")
+	}
+	return nil
 }
 
 func ToProtoPost(post *models.Post) *postpb.Post {
@@ -45,6 +67,8 @@ func ToProtoPost(post *models.Post) *postpb.Post {
 		UpdatedAt:   timestamppb.New(post.UpdatedAt),
 		IsPrivate:   post.IsPrivate,
 		Tags:        post.Tags,
+		Hidden:      post.HiddenAt != nil,
+		Visibility:  visibilityToProto[post.Visibility],
 	}
 }
 
@@ -75,6 +99,16 @@ func ToProtoReply(rp *models.Reply) *postpb.Reply {
 	}
 }
 
+func ToProtoLikeState(state *models.LikeState) *postpb.LikeStateResponse {
+	if state == nil {
+		return nil
+	}
+	return &postpb.LikeStateResponse{
+		Liked:      state.Liked,
+		LikesCount: state.LikesCount,
+	}
+}
+
 func handleRepoError(err error, operation string, postID string) error {
 	if err == nil {
 		return nil
@@ -82,6 +116,9 @@ func handleRepoError(err error, operation string, postID string) error {
 	if errors.Is(err, repository.ErrPostNotFound) {
 		return status.Errorf(codes.NotFound, "post %s not found", postID)
 	}
+	if errors.Is(err, repository.ErrCommentNotFound) {
+		return status.Error(codes.NotFound, "comment not found")
+	}
 	if errors.Is(err, repository.ErrForbidden) {
 		return status.Errorf(codes.PermissionDenied, "permission denied")
 	}
@@ -97,31 +134,48 @@ func (s *PostService) CreatePost(ctx context.Context, req *postpb.CreatePostRequ
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkCanPublish(ctx); err != nil {
+		return nil, err
+	}
 
 	if req.GetTitle() == "" {
 		return nil, status.Error(codes.InvalidArgument, "title is required")
 	}
 
+	visibility, err := requestedVisibility(req.GetVisibility(), req.GetIsPrivate())
+	if err != nil {
+		return nil, err
+	}
+
 	newPost := &models.Post{
 		UserID:      userID,
 		Title:       req.GetTitle(),
 		Description: req.GetDescription(),
-		IsPrivate:   req.GetIsPrivate(),
+		Visibility:  visibility,
 		Tags:        pq.StringArray(req.GetTags()),
 	}
 
-	postID, err := s.repo.CreatePost(ctx, newPost)
+	var createdPost *models.Post
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		postID, err := tx.CreatePost(ctx, newPost)
+		if err != nil {
+			return err
+		}
+		createdPost, err = tx.GetPostByID(ctx, postID)
+		if err != nil {
+			return err
+		}
+		if s.schedulesFanOut(createdPost) {
+			if err := tx.EnqueueFanOut(ctx, createdPost.ID); err != nil {
+				return err
+			}
+		}
+		return s.enqueueEvent(ctx, tx, topicPostLifecycle, createdPost.ID,
+			&eventspb.PostCreated{Post: toEventPostSnapshot(createdPost)}, createdPost.CreatedAt)
+	})
 	if err != nil {
 		return nil, handleRepoError(err, "create", "")
 	}
-	newPost.ID = postID
-
-	createdPost, err := s.repo.GetPostByID(ctx, postID)
-	if err != nil {
-		newPost.CreatedAt = time.Now()
-		newPost.UpdatedAt = newPost.CreatedAt
-		return newPost, nil
-	}
 
 	return createdPost, nil
 }
@@ -140,14 +194,16 @@ func (s *PostService) GetPost(ctx context.Context, postID string) (*models.Post,
 		return nil, handleRepoError(err, "get", postID)
 	}
 
-	if post.IsPrivate {
-		requestingUserID, err := auth.GetUserIDFromContext(ctx)
-		if err != nil && !errors.Is(err, status.Errorf(codes.Unauthenticated, "user ID not found in context")) {
-			return nil, err
-		}
-		if requestingUserID == "" || post.UserID != requestingUserID {
-			return nil, status.Errorf(codes.PermissionDenied, "you do not have permission to view this post")
-		}
+	requestingUserID, _ := auth.GetUserIDFromContext(ctx)
+	if err := checkNotHidden(ctx, requestingUserID, post); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCanView(ctx, requestingUserID, post); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBlocked(ctx, requestingUserID, post.UserID); err != nil {
+		return nil, err
 	}
 
 	return post, nil
@@ -175,35 +231,46 @@ func (s *PostService) UpdatePost(ctx context.Context, req *postpb.UpdatePostRequ
 		return nil, status.Error(codes.InvalidArgument, "title cannot be empty")
 	}
 
-	authorID, err := s.repo.GetPostAuthorID(ctx, postID)
+	currentPost, err := s.repo.GetPostByID(ctx, postID)
 	if err != nil {
 		return nil, handleRepoError(err, "check author for update", postID)
 	}
 
-	if authorID != userID {
+	if currentPost.UserID != userID {
 		return nil, status.Errorf(codes.PermissionDenied, "you are not authorized to update this post")
 	}
 
+	visibility, err := updatedVisibility(req.GetVisibility(), req.GetIsPrivate(), currentPost.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
 	updatedPostData := &models.Post{
 		ID:          postID,
 		UserID:      userID,
 		Title:       req.GetTitle(),
 		Description: req.GetDescription(),
-		IsPrivate:   req.GetIsPrivate(),
+		Visibility:  visibility,
 		Tags:        pq.StringArray(req.GetTags()),
 	}
 
-	err = s.repo.UpdatePost(ctx, updatedPostData)
+	var updatedPost *models.Post
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		if err := tx.UpdatePost(ctx, updatedPostData); err != nil {
+			return err
+		}
+		var err error
+		updatedPost, err = tx.GetPostByID(ctx, postID)
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, tx, topicPostLifecycle, postID,
+			&eventspb.PostUpdated{Post: toEventPostSnapshot(updatedPost)}, updatedPost.UpdatedAt)
+	})
 	if err != nil {
 		return nil, handleRepoError(err, "update", postID)
 	}
 
-	updatedPost, err := s.repo.GetPostByID(ctx, postID)
-	if err != nil {
-		updatedPostData.UpdatedAt = time.Now()
-		return updatedPostData, nil
-	}
-
 	return updatedPost, nil
 }
 
@@ -225,7 +292,14 @@ func (s *PostService) DeletePost(ctx context.Context, postID string) error {
 		return err
 	}
 
-	err = s.repo.DeletePost(ctx, postID, userID)
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		deletedPost, err := tx.DeletePost(ctx, postID, userID)
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(ctx, tx, topicPostLifecycle, postID,
+			&eventspb.PostDeleted{Post: toEventPostSnapshot(deletedPost)}, time.Now())
+	})
 	if err != nil {
 		return handleRepoError(err, "delete", postID)
 	}
@@ -280,8 +354,14 @@ func (s *PostService) ListPublicPosts(ctx context.Context, req *postpb.ListPubli
 	}
 
 	filterUserID := req.UserId
+	readerID, _ := auth.GetUserIDFromContext(ctx)
 
-	posts, totalCount, err := s.repo.GetPublicPosts(ctx, filterUserID, page, pageSize)
+	audience, err := s.audience(ctx, readerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	posts, totalCount, err := s.repo.GetPublicPosts(ctx, audience, filterUserID, page, pageSize)
 	if err != nil {
 		return nil, 0, status.Errorf(codes.Internal, "failed to list public posts: %v", err)
 	}
@@ -296,189 +376,178 @@ func (s *PostService) ListPublicPosts(ctx context.Context, req *postpb.ListPubli
 
 func (s *PostService) ViewPost(ctx context.Context, req *postpb.ViewPostRequest) error {
 	userID, _ := auth.GetUserIDFromContext(ctx)
-	_ = s.repo.RecordView(ctx, userID, req.PostId)
-
-	ev := struct {
-		UserID   string    `json:"user_id"`
-		PostId   string    `json:"post_id"`
-		ViewedAt time.Time `json:"viewed_at"`
-	}{
-		UserID:   userID,
-		PostId:   req.PostId,
-		ViewedAt: time.Now().UTC(),
-	}
-	payload, _ := json.Marshal(ev)
-
-	const retries = 3
-	for range retries {
-		writerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		err := s.viewWriter.WriteMessages(
-			writerCtx,
-			kafka.Message{
-				Key:   []byte(userID),
-				Value: payload,
-			},
-		)
-		if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		}
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return err
+	}
 
-		if err != nil {
-			log.Printf("failed to write messages: %s", err.Error())
+	err := s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		if err := tx.RecordView(ctx, userID, req.PostId); err != nil {
+			return err
 		}
-		break
+		return s.enqueueEvent(ctx, tx, topicPostViews, userID,
+			&eventspb.PostViewed{UserId: userID, PostId: req.PostId}, time.Now())
+	})
+	if err != nil {
+		return handleRepoError(err, "view", req.PostId)
 	}
 	return nil
 }
 
-func (s *PostService) LikePost(ctx context.Context, req *postpb.LikePostRequest) error {
+// LikePost and UnlikePost are idempotent: an event is published only when
+// the like actually appears or disappears, and both return the resulting
+// state either way.
+func (s *PostService) LikePost(ctx context.Context, req *postpb.LikePostRequest) (*models.LikeState, error) {
 	userID, _ := auth.GetUserIDFromContext(ctx)
-	_ = s.repo.RecordLike(ctx, userID, req.PostId)
-
-	ev := struct {
-		UserID  string    `json:"user_id"`
-		PostId  string    `json:"post_id"`
-		LikedAt time.Time `json:"liked_at"`
-	}{
-		UserID:  userID,
-		PostId:  req.PostId,
-		LikedAt: time.Now().UTC(),
-	}
-	payload, _ := json.Marshal(ev)
-
-	const retries = 3
-	for range retries {
-		writerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		err := s.likeWriter.WriteMessages(
-			writerCtx,
-			kafka.Message{
-				Key:   []byte(userID),
-				Value: payload,
-			},
-		)
-		if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		}
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, err
+	}
 
+	state := &models.LikeState{Liked: true}
+	err := s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		inserted, err := tx.RecordLike(ctx, userID, req.PostId)
 		if err != nil {
-			log.Printf("failed to write messages: %s", err.Error())
+			return err
+		}
+		if inserted {
+			err = s.enqueueEvent(ctx, tx, topicPostLikes, userID,
+				&eventspb.PostLiked{UserId: userID, PostId: req.PostId}, time.Now())
+			if err != nil {
+				return err
+			}
 		}
-		break
+		state.LikesCount, err = tx.CountLikes(ctx, req.PostId)
+		return err
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "like", req.PostId)
 	}
-	return nil
+	return state, nil
 }
 
-func (s *PostService) UnlikePost(ctx context.Context, req *postpb.UnlikePostRequest) error {
+func (s *PostService) UnlikePost(ctx context.Context, req *postpb.UnlikePostRequest) (*models.LikeState, error) {
 	userID, _ := auth.GetUserIDFromContext(ctx)
-	_ = s.repo.RemoveLike(ctx, userID, req.PostId)
-	return nil
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, err
+	}
+
+	state := &models.LikeState{Liked: false}
+	err := s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		removed, err := tx.RemoveLike(ctx, userID, req.PostId)
+		if err != nil {
+			return err
+		}
+		if removed {
+			err = s.enqueueEvent(ctx, tx, topicPostLikes, userID,
+				&eventspb.PostUnliked{UserId: userID, PostId: req.PostId}, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		state.LikesCount, err = tx.CountLikes(ctx, req.PostId)
+		return err
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "unlike", req.PostId)
+	}
+	return state, nil
 }
 
 func (s *PostService) AddComment(ctx context.Context, req *postpb.AddCommentRequest) (*models.Comment, error) {
 	userID, _ := auth.GetUserIDFromContext(ctx)
+	if err := s.checkCanPublish(ctx); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, err
+	}
 	cm := &models.Comment{
 		PostID: req.PostId,
 		UserID: userID,
 		Text:   req.Text,
 	}
-	id, _ := s.repo.CreateComment(ctx, cm)
-	cm.ID = id
-
-	ev := struct {
-		UserID    string    `json:"user_id"`
-		PostId    string    `json:"post_id"`
-		CommentId string    `json:"comment_id"`
-		CreatedAt time.Time `json:"created_at"`
-	}{
-		UserID:    userID,
-		PostId:    req.PostId,
-		CommentId: id,
-		CreatedAt: time.Now().UTC(),
-	}
-	payload, _ := json.Marshal(ev)
-
-	const retries = 3
-	for range retries {
-		writerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		err := s.commentWriter.WriteMessages(
-			writerCtx,
-			kafka.Message{
-				Key:   []byte(userID),
-				Value: payload,
-			},
-		)
-		if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		}
 
+	err := s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		id, err := tx.CreateComment(ctx, cm)
 		if err != nil {
-			log.Printf("failed to write messages: %s", err.Error())
+			return err
 		}
-		break
+		cm.ID = id
+		return s.enqueueEvent(ctx, tx, topicPostComments, userID,
+			&eventspb.CommentAdded{UserId: userID, PostId: req.PostId, CommentId: id}, time.Now())
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "comment on", req.PostId)
 	}
 	return cm, nil
 }
 
 func (s *PostService) AddReply(ctx context.Context, req *postpb.AddReplyRequest) (*models.Reply, error) {
 	userID, _ := auth.GetUserIDFromContext(ctx)
+	if err := s.checkCanPublish(ctx); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, err
+	}
+	commentAuthorID, err := s.repo.GetCommentAuthorID(ctx, req.PostId, req.ParentCommentId)
+	if err != nil {
+		return nil, handleRepoError(err, "reply on", req.PostId)
+	}
+	if err := s.checkNotBlocked(ctx, userID, commentAuthorID); err != nil {
+		return nil, err
+	}
 	rp := &models.Reply{
 		PostID:          req.PostId,
 		ParentCommentID: req.ParentCommentId,
 		UserID:          userID,
 		Text:            req.Text,
 	}
-	id, _ := s.repo.CreateReply(ctx, rp)
-	rp.ID = id
-
-	ev := struct {
-		UserID    string    `json:"user_id"`
-		PostId    string    `json:"post_id"`
-		CommentId string    `json:"comment_id"`
-		CreatedAt time.Time `json:"created_at"`
-	}{
-		UserID:    userID,
-		PostId:    req.PostId,
-		CommentId: id,
-		CreatedAt: time.Now().UTC(),
-	}
-	payload, _ := json.Marshal(ev)
-
-	const retries = 3
-	for range retries {
-		writerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		err := s.commentWriter.WriteMessages(
-			writerCtx,
-			kafka.Message{
-				Key:   []byte(userID),
-				Value: payload,
-			},
-		)
-		if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		}
 
+	err = s.repo.RunInTx(ctx, func(tx repository.PostRepository) error {
+		id, err := tx.CreateReply(ctx, rp)
 		if err != nil {
-			log.Printf("failed to write messages: %s", err.Error())
+			return err
 		}
-		break
+		rp.ID = id
+		return s.enqueueEvent(ctx, tx, topicPostComments, userID,
+			&eventspb.ReplyAdded{UserId: userID, PostId: req.PostId, CommentId: id, ParentCommentId: req.ParentCommentId}, time.Now())
+	})
+	if err != nil {
+		return nil, handleRepoError(err, "reply on", req.PostId)
 	}
 	return rp, nil
 }
 
+// ListComments leaves out comments by users the caller blocked, was blocked
+// by or muted. The comments of a blocked author's post are not shown at all.
 func (s *PostService) ListComments(ctx context.Context, req *postpb.ListCommentsRequest) ([]*postpb.Comment, int, error) {
-	comments, total, _ := s.repo.ListComments(ctx, req.PostId, int(req.Page), int(req.PageSize))
+	userID, _ := auth.GetUserIDFromContext(ctx)
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, 0, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, 0, err
+	}
+	restrictions, err := s.restrictions(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	comments, total, _ := s.repo.ListComments(ctx, req.PostId, hiddenFrom(restrictions), int(req.Page), int(req.PageSize))
 	var r []*postpb.Comment
 	for _, cm := range comments {
 		r = append(r, &postpb.Comment{
@@ -489,8 +558,24 @@ func (s *PostService) ListComments(ctx context.Context, req *postpb.ListComments
 	return r, total, nil
 }
 
+// ListReplies, like ListComments, shows nothing under a blocked author's
+// post and leaves out the replies of blocked and muted users.
 func (s *PostService) ListReplies(ctx context.Context, req *postpb.ListRepliesRequest) ([]*postpb.Reply, error) {
-	reps, _ := s.repo.ListReplies(ctx, req.ParentCommentId)
+	userID, _ := auth.GetUserIDFromContext(ctx)
+	if err := utils.ValidatePostID(req.PostId); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateCommentID(req.ParentCommentId); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanInteract(ctx, userID, req.PostId); err != nil {
+		return nil, err
+	}
+	restrictions, err := s.restrictions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	reps, _ := s.repo.ListReplies(ctx, req.PostId, req.ParentCommentId, hiddenFrom(restrictions))
 	var r []*postpb.Reply
 	for _, rp := range reps {
 		r = append(r, &postpb.Reply{
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/post-service/internal/utils"
+)
+
+func callerContext(t *testing.T, emailVerified string) context.Context {
+	t.Helper()
+	md := metadata.Pairs(auth.UserIDMetadataKey, "5f0c6d2e-8b1a-4e3f-9c7d-1a2b3c4d5e6f", auth.EmailVerifiedMetadataKey, emailVerified)
+	var ctx context.Context
+	_, err := auth.AuthInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, nil,
+		func(c context.Context, req any) (any, error) {
+			ctx = c
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("AuthInterceptor returned error: %v", err)
+	}
+	return ctx
+}
+
+func TestCheckCanPublish(t *testing.T) {
+	testCases := []struct {
+		name          string
+		require       bool
+		emailVerified string
+		wantCode      codes.Code
+	}{
+		{"policy off", false, "false", codes.OK},
+		{"verified", true, "true", codes.OK},
+		{"unverified", true, "false", codes.PermissionDenied},
+		{"no header", true, "", codes.PermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewPostService(nil, events.Codec{}, tc.require, nil, 0)
+			err := s.checkCanPublish(callerContext(t, tc.emailVerified))
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("checkCanPublish code = %v, want %v", got, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestListCommentsValidatesIDs(t *testing.T) {
+	s := NewPostService(nil, events.Codec{}, false, nil, 0)
+	ctx := callerContext(t, "true")
+	if _, _, err := s.ListComments(ctx, &postpb.ListCommentsRequest{PostId: "not-a-uuid", Page: 1, PageSize: 10}); !errors.Is(err, utils.ErrInvalidPostID) {
+		t.Errorf("ListComments error = %v, want %v", err, utils.ErrInvalidPostID)
+	}
+	if _, err := s.ListReplies(ctx, &postpb.ListRepliesRequest{PostId: "not-a-uuid", ParentCommentId: testCommentID}); !errors.Is(err, utils.ErrInvalidPostID) {
+		t.Errorf("ListReplies error = %v, want %v", err, utils.ErrInvalidPostID)
+	}
+	if _, err := s.ListReplies(ctx, &postpb.ListRepliesRequest{PostId: testPostID, ParentCommentId: "not-a-uuid"}); !errors.Is(err, utils.ErrInvalidCommentID) {
+		t.Errorf("ListReplies with a bad parent error = %v, want %v", err, utils.ErrInvalidCommentID)
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/utils"
+)
+
+const (
+	maxSearchPageSize    = 100
+	maxSearchQueryLength = 256
+)
+
+var searchOrders = map[postpb.SearchOrder]models.SearchOrder{
+	postpb.SearchOrder_SEARCH_ORDER_RELEVANCE: models.SearchOrderRelevance,
+	postpb.SearchOrder_SEARCH_ORDER_RECENT:    models.SearchOrderRecent,
+}
+
+// SearchPosts finds posts by text, tags, author and creation date among the
+// caller's own posts and those shared with the caller. Posts of blocked and
+// muted users are left out, as in the public listings.
+func (s *PostService) SearchPosts(ctx context.Context, req *postpb.SearchPostsRequest) ([]*postpb.Post, int, error) {
+	readerID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := utils.ValidateUserID(readerID); err != nil {
+		return nil, 0, err
+	}
+	page := int(req.GetPage())
+	if page < 1 {
+		return nil, 0, status.Error(codes.InvalidArgument, utils.ErrInvalidPage.Error())
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 1 || pageSize > maxSearchPageSize {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxSearchPageSize)
+	}
+	search, err := parseSearchRequest(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	audience, err := s.audience(ctx, readerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	posts, totalCount, err := s.repo.SearchPosts(ctx, audience, search, page, pageSize)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to search posts: %v", err)
+	}
+
+	protoPosts := make([]*postpb.Post, 0, len(posts))
+	for _, post := range posts {
+		protoPosts = append(protoPosts, ToProtoPost(&post))
+	}
+	return protoPosts, totalCount, nil
+}
+
+// parseSearchRequest validates the search criteria of req. The to date is
+// inclusive, so the range ends at the start of the day after it.
+func parseSearchRequest(req *postpb.SearchPostsRequest) (*models.PostSearch, error) {
+	search := &models.PostSearch{Query: strings.TrimSpace(req.GetQuery())}
+	if utf8.RuneCountInString(search.Query) > maxSearchQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "query must be at most %d characters", maxSearchQueryLength)
+	}
+
+	order, ok := searchOrders[req.GetOrder()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown search order %v", req.GetOrder())
+	}
+	search.Order = order
+
+	for _, tag := range req.GetTags() {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			search.Tags = append(search.Tags, tag)
+		}
+	}
+
+	if req.AuthorId != nil {
+		if err := utils.ValidateUserID(req.GetAuthorId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid author_id format")
+		}
+		search.AuthorID = req.GetAuthorId()
+	}
+
+	if req.GetFrom() != "" {
+		from, err := time.Parse(time.DateOnly, req.GetFrom())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from date %q, expected YYYY-MM-DD", req.GetFrom())
+		}
+		search.From = &from
+	}
+	if req.GetTo() != "" {
+		to, err := time.Parse(time.DateOnly, req.GetTo())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to date %q, expected YYYY-MM-DD", req.GetTo())
+		}
+		to = to.AddDate(0, 0, 1)
+		search.To = &to
+	}
+	if search.From != nil && search.To != nil && !search.From.Before(*search.To) {
+		return nil, status.Error(codes.InvalidArgument, "from must not be after to")
+	}
+	return search, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+func TestParseSearchRequest(t *testing.T) {
+	authorID := "0b6f6b8e-9d7a-4c1e-8f3b-2a6d5e4c3b21"
+	got, err := parseSearchRequest(&postpb.SearchPostsRequest{
+		Query:    "  golang -java ",
+		Tags:     []string{" go ", "", "db"},
+		AuthorId: &authorID,
+		From:     "2025-03-01",
+		To:       "2025-03-31",
+		Order:    postpb.SearchOrder_SEARCH_ORDER_RECENT,
+	})
+	if err != nil {
+		t.Fatalf("parseSearchRequest returned error: %v", err)
+	}
+	if got.Query != "golang -java" || got.AuthorID != authorID || got.Order != models.SearchOrderRecent {
+		t.Errorf("unexpected search %+v", got)
+	}
+	if strings.Join(got.Tags, ",") != "go,db" {
+		t.Errorf("tags = %q, want [go db]", got.Tags)
+	}
+	wantFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	if !got.From.Equal(wantFrom) || !got.To.Equal(wantTo) {
+		t.Errorf("range = [%v, %v), want [%v, %v)", got.From, got.To, wantFrom, wantTo)
+	}
+}
+
+func TestParseSearchRequestDefaults(t *testing.T) {
+	got, err := parseSearchRequest(&postpb.SearchPostsRequest{})
+	if err != nil {
+		t.Fatalf("parseSearchRequest returned error: %v", err)
+	}
+	if got.Order != models.SearchOrderRelevance || got.Tags != nil || got.From != nil || got.To != nil {
+		t.Errorf("unexpected search %+v", got)
+	}
+}
+
+func TestSearchPostsRejectsInvalidRequests(t *testing.T) {
+	s := NewPostService(nil, events.Codec{}, false, nil, 0)
+	ctx := callerContext(t, "true")
+	author := "user-1"
+	valid := func(req *postpb.SearchPostsRequest) *postpb.SearchPostsRequest {
+		req.Page, req.PageSize = 1, 10
+		return req
+	}
+	tests := []struct {
+		name string
+		req  *postpb.SearchPostsRequest
+	}{
+		{"zero page", &postpb.SearchPostsRequest{PageSize: 10}},
+		{"page size too large", &postpb.SearchPostsRequest{Page: 1, PageSize: maxSearchPageSize + 1}},
+		{"query too long", valid(&postpb.SearchPostsRequest{Query: strings.Repeat("a", maxSearchQueryLength+1)})},
+		{"unknown order", valid(&postpb.SearchPostsRequest{Order: postpb.SearchOrder(42)})},
+		{"author not a UUID", valid(&postpb.SearchPostsRequest{AuthorId: &author})},
+		{"malformed date", valid(&postpb.SearchPostsRequest{From: "01.03.2025"})},
+		{"from after to", valid(&postpb.SearchPostsRequest{From: "2025-03-02", To: "2025-03-01"})},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := s.SearchPosts(ctx, tc.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("SearchPosts error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
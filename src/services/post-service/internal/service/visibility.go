@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+)
+
+var visibilityToProto = map[models.Visibility]postpb.Visibility{
+	models.VisibilityPublic:       postpb.Visibility_VISIBILITY_PUBLIC,
+	models.VisibilityFollowers:    postpb.Visibility_VISIBILITY_FOLLOWERS,
+	models.VisibilityCloseFriends: postpb.Visibility_VISIBILITY_CLOSE_FRIENDS,
+	models.VisibilityOnlyMe:       postpb.Visibility_VISIBILITY_ONLY_ME,
+}
+
+// requestedVisibility reads the visibility of a create or update request.
+// Older clients only send is_private, which means only me.
+func requestedVisibility(v postpb.Visibility, isPrivate bool) (models.Visibility, error) {
+	if v == postpb.Visibility_VISIBILITY_UNSPECIFIED {
+		if isPrivate {
+			return models.VisibilityOnlyMe, nil
+		}
+		return models.VisibilityPublic, nil
+	}
+	for visibility, pv := range visibilityToProto {
+		if pv == v {
+			return visibility, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown visibility %v", v)
+}
+
+// updatedVisibility reads the visibility of an update request. The
+// is_private of older clients only switches between public and only me, so
+// without visibility a post shared with followers or close friends keeps
+// its visibility.
+func updatedVisibility(v postpb.Visibility, isPrivate bool, current models.Visibility) (models.Visibility, error) {
+	if v == postpb.Visibility_VISIBILITY_UNSPECIFIED && current != models.VisibilityPublic && current != models.VisibilityOnlyMe {
+		return current, nil
+	}
+	return requestedVisibility(v, isPrivate)
+}
+
+// checkCanView lets the author see any of their posts and other readers
+// only the posts shared with them.
+func (s *PostService) checkCanView(ctx context.Context, readerID string, post *models.Post) error {
+	if post.Visibility == models.VisibilityPublic || (readerID != "" && readerID == post.UserID) {
+		return nil
+	}
+	var allowed bool
+	switch {
+	case readerID == "" || s.relations == nil:
+	case post.Visibility == models.VisibilityFollowers:
+		follows, err := s.relations.Following(ctx, readerID)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to check post visibility: %v", err)
+		}
+		allowed = slices.ContainsFunc(follows, func(f models.Follow) bool { return f.AuthorID == post.UserID })
+	case post.Visibility == models.VisibilityCloseFriends:
+		authors, err := s.relations.CloseFriendOf(ctx, readerID)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to check post visibility: %v", err)
+		}
+		allowed = slices.Contains(authors, post.UserID)
+	}
+	if !allowed {
+		return status.Errorf(codes.PermissionDenied, "you do not have permission to view this post")
+	}
+	return nil
+}
+
+// audience looks up what the reader's access to posts depends on. Without
+// the follows the reader gets public posts only: the lookup failure is
+// logged. Without the blocks the listing fails.
+func (s *PostService) audience(ctx context.Context, readerID string) (*models.Audience, error) {
+	audience := &models.Audience{ReaderID: readerID}
+	if readerID == "" || s.relations == nil {
+		return audience, nil
+	}
+	r, err := s.restrictions(ctx, readerID)
+	if err != nil {
+		return nil, err
+	}
+	audience.Hidden = hiddenFrom(r)
+	follows, err := s.relations.Following(ctx, readerID)
+	if err != nil {
+		log.Printf("failed to look up follows of %s, listing public posts only: %v", readerID, err)
+		return audience, nil
+	}
+	for _, f := range follows {
+		audience.Following = append(audience.Following, f.AuthorID)
+	}
+	audience.CloseFriendOf = s.closeFriendOf(ctx, readerID)
+	return audience, nil
+}
+
+func (s *PostService) closeFriendOf(ctx context.Context, readerID string) []string {
+	authors, err := s.relations.CloseFriendOf(ctx, readerID)
+	if err != nil {
+		log.Printf("failed to look up close friend lists of %s: %v", readerID, err)
+		return nil
+	}
+	return authors
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zahartd/social-network/src/events"
+	postpb "github.com/zahartd/social-network/src/gen/go/post"
+	"github.com/zahartd/social-network/src/services/post-service/internal/models"
+	"github.com/zahartd/social-network/src/services/post-service/internal/relations"
+)
+
+type fakeLookup struct {
+	following     []models.Follow
+	closeFriendOf []string
+	restrictions  models.Restrictions
+	err           error
+}
+
+func (f *fakeLookup) Following(ctx context.Context, userID string) ([]models.Follow, error) {
+	return f.following, f.err
+}
+
+func (f *fakeLookup) Followers(ctx context.Context, userID string, limit int) ([]string, error) {
+	return nil, f.err
+}
+
+func (f *fakeLookup) CloseFriendOf(ctx context.Context, userID string) ([]string, error) {
+	return f.closeFriendOf, f.err
+}
+
+func (f *fakeLookup) Restrictions(ctx context.Context, userID string) (*models.Restrictions, error) {
+	return &f.restrictions, f.err
+}
+
+func TestRequestedVisibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         postpb.Visibility
+		isPrivate bool
+		want      models.Visibility
+	}{
+		{"legacy public", postpb.Visibility_VISIBILITY_UNSPECIFIED, false, models.VisibilityPublic},
+		{"legacy private", postpb.Visibility_VISIBILITY_UNSPECIFIED, true, models.VisibilityOnlyMe},
+		{"visibility wins over is_private", postpb.Visibility_VISIBILITY_FOLLOWERS, true, models.VisibilityFollowers},
+		{"close friends", postpb.Visibility_VISIBILITY_CLOSE_FRIENDS, false, models.VisibilityCloseFriends},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := requestedVisibility(tc.v, tc.isPrivate)
+			if err != nil || got != tc.want {
+				t.Errorf("requestedVisibility = %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+	if _, err := requestedVisibility(postpb.Visibility(42), false); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown visibility: error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestUpdatedVisibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         postpb.Visibility
+		isPrivate bool
+		current   models.Visibility
+		want      models.Visibility
+	}{
+		{"legacy private of a public post", postpb.Visibility_VISIBILITY_UNSPECIFIED, true, models.VisibilityPublic, models.VisibilityOnlyMe},
+		{"legacy public of a private post", postpb.Visibility_VISIBILITY_UNSPECIFIED, false, models.VisibilityOnlyMe, models.VisibilityPublic},
+		{"legacy update keeps followers", postpb.Visibility_VISIBILITY_UNSPECIFIED, false, models.VisibilityFollowers, models.VisibilityFollowers},
+		{"legacy update keeps close friends", postpb.Visibility_VISIBILITY_UNSPECIFIED, true, models.VisibilityCloseFriends, models.VisibilityCloseFriends},
+		{"visibility replaces close friends", postpb.Visibility_VISIBILITY_PUBLIC, false, models.VisibilityCloseFriends, models.VisibilityPublic},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := updatedVisibility(tc.v, tc.isPrivate, tc.current)
+			if err != nil || got != tc.want {
+				t.Errorf("updatedVisibility = %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckCanView(t *testing.T) {
+	const author, reader = "author", "reader"
+	follower := &fakeLookup{following: []models.Follow{{AuthorID: author}}}
+	closeFriend := &fakeLookup{closeFriendOf: []string{author}}
+	tests := []struct {
+		name       string
+		visibility models.Visibility
+		readerID   string
+		lookup     relations.Lookup
+		want       codes.Code
+	}{
+		{"public to anyone", models.VisibilityPublic, "", nil, codes.OK},
+		{"only me to the author", models.VisibilityOnlyMe, author, nil, codes.OK},
+		{"only me to others", models.VisibilityOnlyMe, reader, follower, codes.PermissionDenied},
+		{"followers to a follower", models.VisibilityFollowers, reader, follower, codes.OK},
+		{"followers to a stranger", models.VisibilityFollowers, reader, &fakeLookup{}, codes.PermissionDenied},
+		{"followers to a close friend who does not follow", models.VisibilityFollowers, reader, closeFriend, codes.PermissionDenied},
+		{"close friends to a close friend", models.VisibilityCloseFriends, reader, closeFriend, codes.OK},
+		{"close friends to a follower", models.VisibilityCloseFriends, reader, follower, codes.PermissionDenied},
+		{"lookup failure", models.VisibilityFollowers, reader, &fakeLookup{err: errors.New("down")}, codes.Unavailable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewPostService(nil, events.Codec{}, false, tc.lookup, 0)
+			post := &models.Post{UserID: author, Visibility: tc.visibility}
+			err := s.checkCanView(context.Background(), tc.readerID, post)
+			if status.Code(err) != tc.want {
+				t.Errorf("checkCanView error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckCanInteractVisibility(t *testing.T) {
+	const author, reader = "author", "reader"
+	tests := []struct {
+		name   string
+		lookup relations.Lookup
+		want   codes.Code
+	}{
+		{"follower", &fakeLookup{following: []models.Follow{{AuthorID: author}}}, codes.OK},
+		{"stranger", &fakeLookup{}, codes.NotFound},
+		{"lookup failure", &fakeLookup{err: errors.New("down")}, codes.Unavailable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			post := &models.Post{ID: testPostID, UserID: author, Visibility: models.VisibilityFollowers}
+			s := NewPostService(&postRepoStub{post: post}, events.Codec{}, false, tc.lookup, 0)
+			err := s.checkCanInteract(context.Background(), reader, testPostID)
+			if status.Code(err) != tc.want {
+				t.Errorf("checkCanInteract error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -10,10 +10,11 @@ import (
 )
 
 var (
-	ErrInvalidUserID   = status.Error(codes.Internal, "internal error: invalid user ID format in context")
-	ErrInvalidPage     = fmt.Errorf("page must be a positive integer")
-	ErrInvalidPageSize = fmt.Errorf("page_size must be a positive integer")
-	ErrInvalidPostID   = status.Error(codes.Internal, "internal error: invalid post ID format")
+	ErrInvalidUserID    = status.Error(codes.Internal, "internal error: invalid user ID format in context")
+	ErrInvalidPage      = fmt.Errorf("page must be a positive integer")
+	ErrInvalidPageSize  = fmt.Errorf("page_size must be a positive integer")
+	ErrInvalidPostID    = status.Error(codes.Internal, "internal error: invalid post ID format")
+	ErrInvalidCommentID = status.Error(codes.InvalidArgument, "invalid comment ID format")
 )
 
 func ValidateUserID(userIDValue any) error {
@@ -55,3 +56,10 @@ func ValidatePostID(postIDValue any) error {
 	}
 	return err
 }
+
+func ValidateCommentID(commentID string) error {
+	if _, err := uuid.Parse(commentID); err != nil {
+		return ErrInvalidCommentID
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+-- Сообщения для Kafka, записываемые в одной транзакции с изменением данных.
+-- Relay в post-service публикует их и проставляет sent_at.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY, -- Задаёт порядок публикации
+    event_id UUID NOT NULL DEFAULT uuid_generate_v4(), -- Передаётся в заголовке event_id для дедупликации у потребителей
+    topic TEXT NOT NULL,
+    msg_key TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_sent_at ON outbox (sent_at) WHERE sent_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS content_type TEXT NOT NULL DEFAULT 'application/json'; -- Формат payload: JSON или protobuf
@@ -0,0 +1,17 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ; -- Скрыт модератором, NULL - виден
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+
+-- Журнал действий модераторов. Ссылок на posts и comments нет: запись
+-- остается после удаления объекта.
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_type TEXT NOT NULL CHECK (target_type IN ('post', 'comment')),
+    target_id UUID NOT NULL,
+    post_id UUID NOT NULL, -- Пост, к которому относится объект
+    action TEXT NOT NULL CHECK (action IN ('hide', 'unhide', 'delete')),
+    reason TEXT NOT NULL,
+    moderator_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_post_id ON moderation_actions (post_id, created_at DESC);
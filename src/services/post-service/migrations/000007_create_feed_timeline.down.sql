@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_user_created;
+DROP TABLE IF EXISTS feed_timeline;
+ALTER TABLE posts DROP COLUMN IF EXISTS fanned_out;
@@ -0,0 +1,14 @@
+-- Лента строится гибридно: посты авторов с небольшим числом подписчиков
+-- раскладываются по лентам подписчиков при публикации (fanned_out = TRUE),
+-- посты остальных авторов выбираются при чтении по списку подписок.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS fanned_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS feed_timeline (
+    user_id UUID NOT NULL, -- Владелец ленты
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL, -- Время создания поста, копия для сортировки
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_timeline_user_created ON feed_timeline (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_user_created ON posts (user_id, created_at DESC, id DESC);
@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN is_private;
+ALTER TABLE posts ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE posts SET is_private = (visibility <> 'public');
+ALTER TABLE posts DROP COLUMN visibility;
@@ -0,0 +1,11 @@
+-- Видимость поста: public, followers (подписчики автора), close_friends
+-- (список близких друзей автора в user-service) или only_me.
+ALTER TABLE posts ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'followers', 'close_friends', 'only_me'));
+
+UPDATE posts SET visibility = 'only_me' WHERE is_private;
+
+-- is_private остается для старых клиентов и событий и теперь вычисляется
+-- из видимости: приватен любой непубличный пост.
+ALTER TABLE posts DROP COLUMN is_private;
+ALTER TABLE posts ADD COLUMN is_private BOOLEAN GENERATED ALWAYS AS (visibility <> 'public') STORED;
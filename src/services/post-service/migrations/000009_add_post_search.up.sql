@@ -0,0 +1,11 @@
+-- Полнотекстовый поиск по заголовку и описанию. Посты пишут на разных
+-- языках, поэтому используется конфигурация simple без стемминга.
+-- Совпадения в заголовке весят больше, чем в описании.
+ALTER TABLE posts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector);
+-- Для фильтра по тегам (tags @> ...).
+CREATE INDEX IF NOT EXISTS idx_posts_tags ON posts USING GIN (tags);
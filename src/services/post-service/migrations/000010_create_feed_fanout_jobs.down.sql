@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feed_timeline_created;
+DROP TABLE IF EXISTS feed_fanout_jobs;
@@ -0,0 +1,13 @@
+-- Пост раскладывается по лентам подписчиков фоновым воркером: задача пишется
+-- в одной транзакции с постом, а пока она не выполнена, пост выбирается
+-- при чтении ленты из posts (fanned_out = FALSE).
+CREATE TABLE IF NOT EXISTS feed_fanout_jobs (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0, -- Число неудачных попыток
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_fanout_jobs_next_attempt ON feed_fanout_jobs (next_attempt_at);
+
+-- Для удаления старых записей лент; старые посты выбираются из posts.
+CREATE INDEX IF NOT EXISTS idx_feed_timeline_created ON feed_timeline (created_at);
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/config"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/consumer"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/handlers"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/repository"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/service"
+)
+
+func main() {
+	cfg := config.Load()
+
+	db, err := sqlx.Connect("postgres", cfg.DB_DSN)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	statsRepo := repository.NewPostgresStatsRepository(db)
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{cfg.KafkaBrokerURL},
+		GroupID:     cfg.KafkaGroupID,
+		GroupTopics: consumer.Topics,
+		StartOffset: kafka.FirstOffset,
+		// Topics are auto-created by their producers, so some of them may
+		// only appear after the reader has joined the group.
+		WatchPartitionChanges: true,
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("failed to close reader: %v", err)
+		}
+	}()
+
+	eventConsumer := consumer.NewConsumer(reader, statsRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := eventConsumer.Run(ctx); err != nil {
+			log.Fatalf("Event consumer stopped: %v", err)
+		}
+	}()
+
+	statsService := service.NewStatsService(statsRepo)
+	statsHandler := handlers.NewStatsGRPCHandler(statsService)
+
+	grpcServer := grpc.NewServer()
+	statspb.RegisterStatsServiceServer(grpcServer, statsHandler)
+	reflection.Register(grpcServer)
+
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", cfg.GRPCPort, err)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve gRPC: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	grpcServer.GracefulStop()
+	cancel()
+	<-done
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"log"
+	"os"
+)
+
+type Config struct {
+	GRPCPort       string
+	DB_DSN         string
+	KafkaBrokerURL string
+	KafkaGroupID   string
+}
+
+func Load() *Config {
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "50052"
+	}
+
+	dbDSN := os.Getenv("DB_DSN")
+	if dbDSN == "" {
+		log.Fatal("DB_DSN environment variable is not set")
+	}
+
+	kafkaBrokerURL := os.Getenv("KAFKA_BROKER_URL")
+	if kafkaBrokerURL == "" {
+		log.Fatal("KAFKA_BROKER_URL environment variable is not set")
+	}
+
+	groupID := os.Getenv("KAFKA_GROUP_ID")
+	if groupID == "" {
+		groupID = "stats-service"
+	}
+
+	return &Config{
+		GRPCPort:       port,
+		DB_DSN:         dbDSN,
+		KafkaBrokerURL: kafkaBrokerURL,
+		KafkaGroupID:   groupID,
+	}
+}
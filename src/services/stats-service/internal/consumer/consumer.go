@@ -0,0 +1,95 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/repository"
+)
+
+const (
+	initialRetryDelay = 250 * time.Millisecond
+	maxRetryDelay     = 10 * time.Second
+)
+
+type Consumer struct {
+	reader *kafka.Reader
+	repo   repository.StatsRepository
+}
+
+func NewConsumer(reader *kafka.Reader, repo repository.StatsRepository) *Consumer {
+	return &Consumer{reader: reader, repo: repo}
+}
+
+// Run processes messages until ctx is cancelled. An offset is committed only
+// once the corresponding event has been persisted, so a crash in between
+// leads to a redelivery rather than a lost event.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch message: %w", err)
+		}
+
+		if err := c.handle(ctx, msg); err != nil {
+			return nil
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to commit offset: %w", err)
+		}
+	}
+}
+
+// handle decodes and stores a single message. Malformed messages are logged
+// and skipped; the only error it returns is the context one.
+func (c *Consumer) handle(ctx context.Context, msg kafka.Message) error {
+	if msg.Topic == TopicPostLifecycle {
+		ev, err := DecodePostEvent(msg)
+		if err != nil {
+			log.Printf("skipping message %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+			return nil
+		}
+		return c.persist(ctx, string(ev.Type)+" "+ev.Post.PostID, func() error {
+			return c.repo.ApplyPostEvent(ctx, ev)
+		})
+	}
+
+	ev, err := DecodeEvent(msg)
+	if err != nil {
+		log.Printf("skipping message %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		return nil
+	}
+	return c.persist(ctx, "event "+ev.ID, func() error {
+		return c.repo.SaveEvent(ctx, ev)
+	})
+}
+
+// persist retries save until it succeeds or ctx is cancelled; the only
+// error it returns is the context one.
+func (c *Consumer) persist(ctx context.Context, what string, save func() error) error {
+	delay := initialRetryDelay
+	for {
+		err := save()
+		if err == nil {
+			return nil
+		}
+		log.Printf("failed to persist %s: %v", what, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
@@ -0,0 +1,191 @@
+package consumer
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/zahartd/social-network/src/events"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+const (
+	TopicPostViews     = "post-views"
+	TopicPostLikes     = "post-likes"
+	TopicPostComments  = "post-comments"
+	TopicPostLifecycle = "post-lifecycle"
+)
+
+var Topics = []string{TopicPostViews, TopicPostLikes, TopicPostComments, TopicPostLifecycle}
+
+// eventIDHeader is set by the post-service outbox relay and stays the same
+// when a message is published more than once.
+const eventIDHeader = "event_id"
+
+// eventIDNamespace seeds the deterministic event IDs derived from message
+// coordinates, so a redelivered message always maps to the same event.
+var eventIDNamespace = uuid.MustParse("5f0c3f7e-8a0e-4c43-9d55-0c7b2a6e9b41")
+
+type postEventPayload struct {
+	UserID    string    `json:"user_id"`
+	PostID    string    `json:"post_id"`
+	ViewedAt  time.Time `json:"viewed_at"`
+	LikedAt   time.Time `json:"liked_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func validateIDs(postID, userID string, userRequired bool) error {
+	if _, err := uuid.Parse(postID); err != nil {
+		return fmt.Errorf("invalid post_id %q", postID)
+	}
+	if userID == "" && !userRequired {
+		return nil
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return fmt.Errorf("invalid user_id %q", userID)
+	}
+	return nil
+}
+
+// DecodeEvent parses a post activity message: a versioned envelope if the
+// message has a content-type header, the legacy JSON payload otherwise.
+func DecodeEvent(msg kafka.Message) (*models.Event, error) {
+	var ev *models.Event
+	var err error
+	if contentType := header(msg, events.HeaderContentType); contentType != "" {
+		ev, err = decodeEnvelopeEvent(msg, contentType)
+	} else {
+		ev, err = decodeLegacyEvent(msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = msg.Time
+	}
+	ev.OccurredAt = ev.OccurredAt.UTC()
+	return ev, nil
+}
+
+// DecodePostEvent parses a post-lifecycle message, in either format.
+func DecodePostEvent(msg kafka.Message) (*models.PostEvent, error) {
+	var ev *models.PostEvent
+	var err error
+	if contentType := header(msg, events.HeaderContentType); contentType != "" {
+		ev, err = decodeEnvelopePostEvent(msg, contentType)
+	} else {
+		ev, err = decodeLegacyPostEvent(msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ev.Post.Tags == nil {
+		ev.Post.Tags = []string{}
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = msg.Time
+	}
+	ev.OccurredAt = ev.OccurredAt.UTC()
+	if ev.Post.CreatedAt.IsZero() {
+		ev.Post.CreatedAt = ev.OccurredAt
+	}
+	if ev.Post.UpdatedAt.IsZero() {
+		ev.Post.UpdatedAt = ev.OccurredAt
+	}
+	return ev, nil
+}
+
+func decodeLegacyEvent(msg kafka.Message) (*models.Event, error) {
+	var payload postEventPayload
+	if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	if err := validateIDs(payload.PostID, payload.UserID, false); err != nil {
+		return nil, err
+	}
+
+	ev := &models.Event{
+		ID:       eventID(msg).String(),
+		UserID:   payload.UserID,
+		TargetID: payload.PostID,
+		Details:  json.RawMessage(msg.Value),
+	}
+	switch msg.Topic {
+	case TopicPostViews:
+		ev.Type = models.EventTypeView
+		ev.OccurredAt = payload.ViewedAt
+	case TopicPostLikes:
+		ev.Type = models.EventTypeLike
+		ev.OccurredAt = payload.LikedAt
+	case TopicPostComments:
+		ev.Type = models.EventTypeComment
+		ev.OccurredAt = payload.CreatedAt
+	default:
+		return nil, fmt.Errorf("unexpected topic %q", msg.Topic)
+	}
+	return ev, nil
+}
+
+type postLifecyclePayload struct {
+	EventType  models.PostEventType `json:"event_type"`
+	PostID     string               `json:"post_id"`
+	UserID     string               `json:"user_id"`
+	Tags       []string             `json:"tags"`
+	IsPrivate  bool                 `json:"is_private"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	OccurredAt time.Time            `json:"occurred_at"`
+}
+
+func decodeLegacyPostEvent(msg kafka.Message) (*models.PostEvent, error) {
+	var payload postLifecyclePayload
+	if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	switch payload.EventType {
+	case models.PostEventCreated, models.PostEventUpdated, models.PostEventDeleted:
+	default:
+		return nil, fmt.Errorf("unsupported lifecycle event %q", payload.EventType)
+	}
+	if err := validateIDs(payload.PostID, payload.UserID, true); err != nil {
+		return nil, err
+	}
+
+	ev := &models.PostEvent{
+		Type: payload.EventType,
+		Post: models.Post{
+			PostID:    payload.PostID,
+			UserID:    payload.UserID,
+			Tags:      payload.Tags,
+			IsPrivate: payload.IsPrivate,
+			CreatedAt: payload.CreatedAt.UTC(),
+			UpdatedAt: payload.UpdatedAt.UTC(),
+		},
+		OccurredAt: payload.OccurredAt,
+	}
+	return ev, nil
+}
+
+func eventID(msg kafka.Message) uuid.UUID {
+	for _, h := range msg.Headers {
+		if h.Key != eventIDHeader {
+			continue
+		}
+		if id, err := uuid.ParseBytes(h.Value); err == nil {
+			return id
+		}
+	}
+	return uuid.NewSHA1(eventIDNamespace, fmt.Appendf(nil, "%s/%d/%d", msg.Topic, msg.Partition, msg.Offset))
+}
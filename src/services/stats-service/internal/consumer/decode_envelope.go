@@ -0,0 +1,114 @@
+package consumer
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/zahartd/social-network/src/events"
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+// detailsMarshal renders envelopes for event_log.details, which is JSONB
+// whatever the wire format was.
+var detailsMarshal = protojson.MarshalOptions{UseProtoNames: true}
+
+func decodeEnvelope(msg kafka.Message, contentType string) (*eventspb.Envelope, string, error) {
+	env, err := events.Decode(msg.Value, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+	id := eventID(msg).String()
+	if parsed, err := uuid.Parse(env.GetEventId()); err == nil {
+		id = parsed.String()
+	}
+	return env, id, nil
+}
+
+func decodeEnvelopeEvent(msg kafka.Message, contentType string) (*models.Event, error) {
+	env, id, err := decodeEnvelope(msg, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := &models.Event{ID: id}
+	switch p := env.GetPayload().(type) {
+	case *eventspb.Envelope_PostViewed:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeView, p.PostViewed.GetUserId(), p.PostViewed.GetPostId()
+	case *eventspb.Envelope_PostLiked:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeLike, p.PostLiked.GetUserId(), p.PostLiked.GetPostId()
+	case *eventspb.Envelope_PostUnliked:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeUnlike, p.PostUnliked.GetUserId(), p.PostUnliked.GetPostId()
+	case *eventspb.Envelope_CommentAdded:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeComment, p.CommentAdded.GetUserId(), p.CommentAdded.GetPostId()
+	case *eventspb.Envelope_ReplyAdded:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeComment, p.ReplyAdded.GetUserId(), p.ReplyAdded.GetPostId()
+	case *eventspb.Envelope_CommentDeleted:
+		ev.Type, ev.UserID, ev.TargetID = models.EventTypeUncomment, p.CommentDeleted.GetUserId(), p.CommentDeleted.GetPostId()
+	default:
+		return nil, fmt.Errorf("unexpected event %q on topic %q", env.GetEventType(), msg.Topic)
+	}
+	if err := validateIDs(ev.TargetID, ev.UserID, false); err != nil {
+		return nil, err
+	}
+
+	if env.GetOccurredAt() != nil {
+		ev.OccurredAt = env.GetOccurredAt().AsTime()
+	}
+	ev.Details, err = detailsMarshal.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("could not render event details: %w", err)
+	}
+	return ev, nil
+}
+
+func decodeEnvelopePostEvent(msg kafka.Message, contentType string) (*models.PostEvent, error) {
+	env, _, err := decodeEnvelope(msg, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventType models.PostEventType
+	var snapshot *eventspb.PostSnapshot
+	switch p := env.GetPayload().(type) {
+	case *eventspb.Envelope_PostCreated:
+		eventType, snapshot = models.PostEventCreated, p.PostCreated.GetPost()
+	case *eventspb.Envelope_PostUpdated:
+		eventType, snapshot = models.PostEventUpdated, p.PostUpdated.GetPost()
+	case *eventspb.Envelope_PostDeleted:
+		eventType, snapshot = models.PostEventDeleted, p.PostDeleted.GetPost()
+	case *eventspb.Envelope_PostHidden:
+		eventType, snapshot = models.PostEventHidden, p.PostHidden.GetPost()
+	case *eventspb.Envelope_PostUnhidden:
+		eventType, snapshot = models.PostEventUnhidden, p.PostUnhidden.GetPost()
+	default:
+		return nil, fmt.Errorf("unsupported lifecycle event %q", env.GetEventType())
+	}
+	if err := validateIDs(snapshot.GetPostId(), snapshot.GetUserId(), true); err != nil {
+		return nil, err
+	}
+
+	ev := &models.PostEvent{
+		Type: eventType,
+		Post: models.Post{
+			PostID:    snapshot.GetPostId(),
+			UserID:    snapshot.GetUserId(),
+			Tags:      snapshot.GetTags(),
+			IsPrivate: snapshot.GetIsPrivate(),
+			Hidden:    snapshot.GetHidden(),
+		},
+	}
+	if snapshot.GetCreatedAt() != nil {
+		ev.Post.CreatedAt = snapshot.GetCreatedAt().AsTime()
+	}
+	if snapshot.GetUpdatedAt() != nil {
+		ev.Post.UpdatedAt = snapshot.GetUpdatedAt().AsTime()
+	}
+	if env.GetOccurredAt() != nil {
+		ev.OccurredAt = env.GetOccurredAt().AsTime()
+	}
+	return ev, nil
+}
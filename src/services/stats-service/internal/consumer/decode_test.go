@@ -0,0 +1,305 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zahartd/social-network/src/events"
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	userID := uuid.NewString()
+	postID := uuid.NewString()
+	occurredAt := time.Date(2025, 4, 20, 12, 30, 0, 0, time.UTC)
+	ts := occurredAt.Format(time.RFC3339Nano)
+
+	testCases := []struct {
+		name     string
+		topic    string
+		value    string
+		wantType models.EventType
+	}{
+		{"view", TopicPostViews, `{"user_id":"` + userID + `","post_id":"` + postID + `","viewed_at":"` + ts + `"}`, models.EventTypeView},
+		{"like", TopicPostLikes, `{"user_id":"` + userID + `","post_id":"` + postID + `","liked_at":"` + ts + `"}`, models.EventTypeLike},
+		{"comment", TopicPostComments, `{"user_id":"` + userID + `","post_id":"` + postID + `","comment_id":"` + uuid.NewString() + `","created_at":"` + ts + `"}`, models.EventTypeComment},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ev, err := DecodeEvent(kafka.Message{Topic: tc.topic, Offset: 7, Value: []byte(tc.value)})
+			if err != nil {
+				t.Fatalf("DecodeEvent returned error: %v", err)
+			}
+			if ev.Type != tc.wantType {
+				t.Errorf("Type = %q, want %q", ev.Type, tc.wantType)
+			}
+			if ev.UserID != userID || ev.TargetID != postID {
+				t.Errorf("got user %q post %q, want user %q post %q", ev.UserID, ev.TargetID, userID, postID)
+			}
+			if !ev.OccurredAt.Equal(occurredAt) {
+				t.Errorf("OccurredAt = %v, want %v", ev.OccurredAt, occurredAt)
+			}
+		})
+	}
+}
+
+func TestDecodeEventIDIsStable(t *testing.T) {
+	value := []byte(`{"user_id":"` + uuid.NewString() + `","post_id":"` + uuid.NewString() + `"}`)
+	msg := kafka.Message{Topic: TopicPostViews, Partition: 1, Offset: 42, Value: value, Time: time.Now()}
+
+	first, err := DecodeEvent(msg)
+	if err != nil {
+		t.Fatalf("DecodeEvent returned error: %v", err)
+	}
+	second, err := DecodeEvent(msg)
+	if err != nil {
+		t.Fatalf("DecodeEvent returned error: %v", err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("redelivered message got a different ID: %s vs %s", first.ID, second.ID)
+	}
+
+	msg.Offset++
+	third, err := DecodeEvent(msg)
+	if err != nil {
+		t.Fatalf("DecodeEvent returned error: %v", err)
+	}
+	if third.ID == first.ID {
+		t.Errorf("different offsets must produce different IDs")
+	}
+}
+
+func TestDecodeEventIDFromHeader(t *testing.T) {
+	id := uuid.NewString()
+	value := []byte(`{"user_id":"` + uuid.NewString() + `","post_id":"` + uuid.NewString() + `"}`)
+	headers := []kafka.Header{{Key: eventIDHeader, Value: []byte(id)}}
+
+	for _, offset := range []int64{3, 9} {
+		ev, err := DecodeEvent(kafka.Message{Topic: TopicPostLikes, Offset: offset, Value: value, Headers: headers})
+		if err != nil {
+			t.Fatalf("DecodeEvent returned error: %v", err)
+		}
+		if ev.ID != id {
+			t.Errorf("republished message at offset %d got ID %s, want %s", offset, ev.ID, id)
+		}
+	}
+}
+
+func TestDecodeEventInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		topic string
+		value string
+	}{
+		{"not json", TopicPostViews, "not json"},
+		{"missing post id", TopicPostLikes, `{"user_id":"` + uuid.NewString() + `"}`},
+		{"invalid user id", TopicPostLikes, `{"user_id":"nope","post_id":"` + uuid.NewString() + `"}`},
+		{"unknown topic", "user-registrations", `{"post_id":"` + uuid.NewString() + `"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := DecodeEvent(kafka.Message{Topic: tc.topic, Value: []byte(tc.value)}); err == nil {
+				t.Errorf("DecodeEvent(%s) expected error, got nil", tc.value)
+			}
+		})
+	}
+}
+
+func TestDecodePostEvent(t *testing.T) {
+	userID := uuid.NewString()
+	postID := uuid.NewString()
+	createdAt := time.Date(2025, 4, 20, 12, 30, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	snapshot := `"post_id":"` + postID + `","user_id":"` + userID + `","tags":["go"],"is_private":true,` +
+		`"created_at":"` + createdAt.Format(time.RFC3339Nano) + `","updated_at":"` + updatedAt.Format(time.RFC3339Nano) + `"`
+
+	for _, eventType := range []models.PostEventType{models.PostEventCreated, models.PostEventUpdated, models.PostEventDeleted} {
+		t.Run(string(eventType), func(t *testing.T) {
+			value := `{"event_type":"` + string(eventType) + `",` + snapshot + `}`
+			ev, err := DecodePostEvent(kafka.Message{Topic: TopicPostLifecycle, Value: []byte(value), Time: updatedAt})
+			if err != nil {
+				t.Fatalf("DecodePostEvent returned error: %v", err)
+			}
+			if ev.Type != eventType {
+				t.Errorf("Type = %q, want %q", ev.Type, eventType)
+			}
+			if ev.Post.PostID != postID || ev.Post.UserID != userID {
+				t.Errorf("got post %q user %q, want post %q user %q", ev.Post.PostID, ev.Post.UserID, postID, userID)
+			}
+			if len(ev.Post.Tags) != 1 || ev.Post.Tags[0] != "go" || !ev.Post.IsPrivate {
+				t.Errorf("got tags %v private %v, want [go] true", ev.Post.Tags, ev.Post.IsPrivate)
+			}
+			if !ev.Post.CreatedAt.Equal(createdAt) || !ev.Post.UpdatedAt.Equal(updatedAt) {
+				t.Errorf("got created %v updated %v, want %v %v", ev.Post.CreatedAt, ev.Post.UpdatedAt, createdAt, updatedAt)
+			}
+			if !ev.OccurredAt.Equal(updatedAt) {
+				t.Errorf("OccurredAt = %v, want message time %v", ev.OccurredAt, updatedAt)
+			}
+		})
+	}
+}
+
+func TestDecodePostEventInvalid(t *testing.T) {
+	postID := uuid.NewString()
+	userID := uuid.NewString()
+	invalid := []string{
+		"not json",
+		`{"event_type":"post_archived","post_id":"` + postID + `","user_id":"` + userID + `"}`,
+		`{"event_type":"post_created","post_id":"` + postID + `"}`,
+		`{"event_type":"post_deleted","user_id":"` + userID + `"}`,
+	}
+	for _, v := range invalid {
+		if _, err := DecodePostEvent(kafka.Message{Topic: TopicPostLifecycle, Value: []byte(v)}); err == nil {
+			t.Errorf("DecodePostEvent(%s) expected error, got nil", v)
+		}
+	}
+}
+
+func envelopeMessage(t *testing.T, encoding, topic string, payload proto.Message, occurredAt time.Time) (kafka.Message, *eventspb.Envelope) {
+	t.Helper()
+	codec, err := events.NewCodec(encoding)
+	if err != nil {
+		t.Fatalf("NewCodec returned error: %v", err)
+	}
+	env, err := events.NewEnvelope(payload, occurredAt)
+	if err != nil {
+		t.Fatalf("NewEnvelope returned error: %v", err)
+	}
+	value, err := codec.Encode(env)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	return kafka.Message{
+		Topic:   topic,
+		Value:   value,
+		Headers: []kafka.Header{{Key: events.HeaderContentType, Value: []byte(codec.ContentType())}},
+	}, env
+}
+
+func TestDecodeEventEnvelope(t *testing.T) {
+	userID := uuid.NewString()
+	postID := uuid.NewString()
+	occurredAt := time.Date(2025, 4, 20, 12, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		topic    string
+		payload  proto.Message
+		wantType models.EventType
+	}{
+		{"view", TopicPostViews, &eventspb.PostViewed{PostId: postID, UserId: userID}, models.EventTypeView},
+		{"like", TopicPostLikes, &eventspb.PostLiked{PostId: postID, UserId: userID}, models.EventTypeLike},
+		{"unlike", TopicPostLikes, &eventspb.PostUnliked{PostId: postID, UserId: userID}, models.EventTypeUnlike},
+		{"comment", TopicPostComments, &eventspb.CommentAdded{PostId: postID, UserId: userID, CommentId: uuid.NewString()}, models.EventTypeComment},
+		{"reply", TopicPostComments, &eventspb.ReplyAdded{PostId: postID, UserId: userID, CommentId: uuid.NewString(), ParentCommentId: uuid.NewString()}, models.EventTypeComment},
+		{"comment deleted", TopicPostComments, &eventspb.CommentDeleted{PostId: postID, UserId: userID, CommentId: uuid.NewString()}, models.EventTypeUncomment},
+	}
+
+	for _, encoding := range []string{"json", "protobuf"} {
+		for _, tc := range testCases {
+			t.Run(encoding+"/"+tc.name, func(t *testing.T) {
+				msg, env := envelopeMessage(t, encoding, tc.topic, tc.payload, occurredAt)
+				ev, err := DecodeEvent(msg)
+				if err != nil {
+					t.Fatalf("DecodeEvent returned error: %v", err)
+				}
+				if ev.ID != env.GetEventId() {
+					t.Errorf("ID = %q, want envelope ID %q", ev.ID, env.GetEventId())
+				}
+				if ev.Type != tc.wantType {
+					t.Errorf("Type = %q, want %q", ev.Type, tc.wantType)
+				}
+				if ev.UserID != userID || ev.TargetID != postID {
+					t.Errorf("got user %q post %q, want user %q post %q", ev.UserID, ev.TargetID, userID, postID)
+				}
+				if !ev.OccurredAt.Equal(occurredAt) {
+					t.Errorf("OccurredAt = %v, want %v", ev.OccurredAt, occurredAt)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodePostEventEnvelope(t *testing.T) {
+	createdAt := time.Date(2025, 4, 20, 12, 30, 0, 0, time.UTC)
+	snapshot := &eventspb.PostSnapshot{
+		PostId:    uuid.NewString(),
+		UserId:    uuid.NewString(),
+		Tags:      []string{"go"},
+		IsPrivate: true,
+		CreatedAt: timestamppb.New(createdAt),
+		UpdatedAt: timestamppb.New(createdAt.Add(time.Hour)),
+		Hidden:    true,
+	}
+
+	testCases := []struct {
+		payload  proto.Message
+		wantType models.PostEventType
+	}{
+		{&eventspb.PostCreated{Post: snapshot}, models.PostEventCreated},
+		{&eventspb.PostUpdated{Post: snapshot}, models.PostEventUpdated},
+		{&eventspb.PostDeleted{Post: snapshot}, models.PostEventDeleted},
+		{&eventspb.PostHidden{Post: snapshot}, models.PostEventHidden},
+		{&eventspb.PostUnhidden{Post: snapshot}, models.PostEventUnhidden},
+	}
+
+	for _, encoding := range []string{"json", "protobuf"} {
+		for _, tc := range testCases {
+			t.Run(encoding+"/"+string(tc.wantType), func(t *testing.T) {
+				msg, _ := envelopeMessage(t, encoding, TopicPostLifecycle, tc.payload, createdAt.Add(time.Hour))
+				ev, err := DecodePostEvent(msg)
+				if err != nil {
+					t.Fatalf("DecodePostEvent returned error: %v", err)
+				}
+				if ev.Type != tc.wantType {
+					t.Errorf("Type = %q, want %q", ev.Type, tc.wantType)
+				}
+				if ev.Post.PostID != snapshot.PostId || ev.Post.UserID != snapshot.UserId {
+					t.Errorf("got post %q user %q, want post %q user %q", ev.Post.PostID, ev.Post.UserID, snapshot.PostId, snapshot.UserId)
+				}
+				if len(ev.Post.Tags) != 1 || ev.Post.Tags[0] != "go" || !ev.Post.IsPrivate || !ev.Post.Hidden {
+					t.Errorf("got tags %v private %v hidden %v, want [go] true true", ev.Post.Tags, ev.Post.IsPrivate, ev.Post.Hidden)
+				}
+				if !ev.Post.CreatedAt.Equal(createdAt) || !ev.OccurredAt.Equal(createdAt.Add(time.Hour)) {
+					t.Errorf("got created %v occurred %v", ev.Post.CreatedAt, ev.OccurredAt)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeEnvelopeInvalid(t *testing.T) {
+	contentType := []kafka.Header{{Key: events.HeaderContentType, Value: []byte(events.ContentTypeJSON)}}
+	postID := uuid.NewString()
+
+	newer, _ := envelopeMessage(t, "json", TopicPostViews, &eventspb.PostViewed{PostId: postID}, time.Now())
+	newer.Value = []byte(`{"event_id":"` + uuid.NewString() + `","schema_version":2,"post_viewed":{"post_id":"` + postID + `"}}`)
+
+	lifecycleOnActivity, _ := envelopeMessage(t, "json", TopicPostViews,
+		&eventspb.PostCreated{Post: &eventspb.PostSnapshot{PostId: postID, UserId: uuid.NewString()}}, time.Now())
+
+	msgs := map[string]kafka.Message{
+		"not json":              {Topic: TopicPostViews, Value: []byte("not json"), Headers: contentType},
+		"unknown content type":  {Topic: TopicPostViews, Value: []byte("{}"), Headers: []kafka.Header{{Key: events.HeaderContentType, Value: []byte("text/plain")}}},
+		"newer schema":          newer,
+		"lifecycle on activity": lifecycleOnActivity,
+	}
+	for name, msg := range msgs {
+		if _, err := DecodeEvent(msg); err == nil {
+			t.Errorf("DecodeEvent(%s) expected error, got nil", name)
+		}
+	}
+
+	viewOnLifecycle, _ := envelopeMessage(t, "protobuf", TopicPostLifecycle, &eventspb.PostViewed{PostId: postID}, time.Now())
+	if _, err := DecodePostEvent(viewOnLifecycle); err == nil {
+		t.Errorf("DecodePostEvent(post_viewed) expected error, got nil")
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/service"
+)
+
+type StatsGRPCHandler struct {
+	statspb.UnimplementedStatsServiceServer
+	statsService *service.StatsService
+}
+
+func NewStatsGRPCHandler(statsService *service.StatsService) *StatsGRPCHandler {
+	return &StatsGRPCHandler{
+		statsService: statsService,
+	}
+}
+
+func (h *StatsGRPCHandler) GetPostStats(ctx context.Context, req *statspb.GetPostStatsRequest) (*statspb.PostStatsResponse, error) {
+	stats, err := h.statsService.GetPostStats(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+	return &statspb.PostStatsResponse{Stats: service.ToProtoPostStats(stats)}, nil
+}
+
+func (h *StatsGRPCHandler) GetPostTimeline(ctx context.Context, req *statspb.GetPostTimelineRequest) (*statspb.PostTimelineResponse, error) {
+	timeline, err := h.statsService.GetPostTimeline(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoTimeline(timeline, req.GetMetric()), nil
+}
+
+func (h *StatsGRPCHandler) TopPosts(ctx context.Context, req *statspb.TopRequest) (*statspb.TopPostsResponse, error) {
+	top, err := h.statsService.TopPosts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoTopPosts(top, req.GetMetric()), nil
+}
+
+func (h *StatsGRPCHandler) TopUsers(ctx context.Context, req *statspb.TopRequest) (*statspb.TopUsersResponse, error) {
+	top, err := h.statsService.TopUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return service.ToProtoTopUsers(top, req.GetMetric()), nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type DailyCount struct {
+	Date  time.Time `db:"day"`
+	Count int64     `db:"count"`
+}
+
+type Timeline struct {
+	PostID    string
+	EventType EventType
+	From      time.Time
+	To        time.Time
+	Points    []DailyCount
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTypeView    EventType = "view"
+	EventTypeLike    EventType = "like"
+	EventTypeComment EventType = "comment"
+	// EventTypeUnlike takes back an earlier like.
+	EventTypeUnlike EventType = "unlike"
+	// EventTypeUncomment takes back a comment deleted by a moderator.
+	EventTypeUncomment EventType = "uncomment"
+)
+
+type Event struct {
+	ID         string          `db:"event_id"`
+	Type       EventType       `db:"event_type"`
+	UserID     string          `db:"user_id"`
+	TargetID   string          `db:"target_id"`
+	OccurredAt time.Time       `db:"occurred_at"`
+	Details    json.RawMessage `db:"details"`
+}
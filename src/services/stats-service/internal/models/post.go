@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type PostEventType string
+
+const (
+	PostEventCreated  PostEventType = "post_created"
+	PostEventUpdated  PostEventType = "post_updated"
+	PostEventDeleted  PostEventType = "post_deleted"
+	PostEventHidden   PostEventType = "post_hidden"
+	PostEventUnhidden PostEventType = "post_unhidden"
+)
+
+// Post holds what the stats service knows about a post from the
+// post-lifecycle topic: enough to attribute events to the post author and
+// to leave deleted and hidden posts out of the leaderboards.
+type Post struct {
+	PostID    string         `db:"post_id"`
+	UserID    string         `db:"user_id"`
+	Tags      pq.StringArray `db:"tags"`
+	IsPrivate bool           `db:"is_private"`
+	Hidden    bool           `db:"hidden"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	DeletedAt *time.Time     `db:"deleted_at"`
+}
+
+type PostEvent struct {
+	Type       PostEventType
+	Post       Post
+	OccurredAt time.Time
+}
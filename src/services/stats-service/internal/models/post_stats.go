@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type PostStats struct {
+	PostID        string    `db:"post_id"`
+	ViewsCount    int64     `db:"views_count"`
+	LikesCount    int64     `db:"likes_count"`
+	CommentsCount int64     `db:"comments_count"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
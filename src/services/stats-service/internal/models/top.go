@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type PostRank struct {
+	PostID string `db:"post_id"`
+	UserID string `db:"user_id"`
+	Count  int64  `db:"count"`
+}
+
+type UserRank struct {
+	UserID string `db:"user_id"`
+	Count  int64  `db:"count"`
+}
+
+type TopPosts struct {
+	EventType EventType
+	From      time.Time
+	To        time.Time
+	Posts     []PostRank
+}
+
+type TopUsers struct {
+	EventType EventType
+	From      time.Time
+	To        time.Time
+	Users     []UserRank
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+type counter struct {
+	column string
+	delta  int
+}
+
+var counters = map[models.EventType]counter{
+	models.EventTypeView:      {"views_count", 1},
+	models.EventTypeLike:      {"likes_count", 1},
+	models.EventTypeComment:   {"comments_count", 1},
+	models.EventTypeUnlike:    {"likes_count", -1},
+	models.EventTypeUncomment: {"comments_count", -1},
+}
+
+// reversedBy names the event type that cancels out an event type. Counts of
+// such metrics are net: every reversing event subtracts one.
+var reversedBy = map[models.EventType]models.EventType{
+	models.EventTypeLike:    models.EventTypeUnlike,
+	models.EventTypeComment: models.EventTypeUncomment,
+}
+
+// countedTypes returns the event types that make up the count of eventType:
+// the type itself and the type reversing it, or the type itself twice.
+func countedTypes(eventType models.EventType) (models.EventType, models.EventType) {
+	if reversal, ok := reversedBy[eventType]; ok {
+		return eventType, reversal
+	}
+	return eventType, eventType
+}
+
+type StatsRepository interface {
+	SaveEvent(ctx context.Context, ev *models.Event) error
+	GetPostStats(ctx context.Context, postID string) (*models.PostStats, error)
+	GetDailyCounts(ctx context.Context, postID string, eventType models.EventType, from, to time.Time) ([]models.DailyCount, error)
+	ApplyPostEvent(ctx context.Context, ev *models.PostEvent) error
+	GetTopPosts(ctx context.Context, eventType models.EventType, from, to time.Time, limit int) ([]models.PostRank, error)
+	GetTopUsers(ctx context.Context, eventType models.EventType, from, to time.Time, limit int) ([]models.UserRank, error)
+}
+
+type postgresStatsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresStatsRepository(db *sqlx.DB) StatsRepository {
+	return &postgresStatsRepository{db: db}
+}
+
+// SaveEvent appends the event to the event log and bumps the matching post
+// counter in one transaction. Redelivered events are recognised by their ID
+// and leave the counters untouched.
+func (r *postgresStatsRepository) SaveEvent(ctx context.Context, ev *models.Event) error {
+	c, ok := counters[ev.Type]
+	if !ok {
+		return fmt.Errorf("unknown event type %q", ev.Type)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO event_log (event_id, event_type, user_id, target_id, occurred_at, details)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (event_id) DO NOTHING`,
+		ev.ID, ev.Type, sql.NullString{String: ev.UserID, Valid: ev.UserID != ""}, ev.TargetID, ev.OccurredAt, []byte(ev.Details))
+	if err != nil {
+		return fmt.Errorf("could not append event: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify event insert: %w", err)
+	}
+	if inserted == 0 {
+		return tx.Commit()
+	}
+
+	// Counters never go below zero: an unlike may refer to a like that
+	// happened before stats were collected.
+	query := fmt.Sprintf(
+		`INSERT INTO post_stats (post_id, %[1]s, updated_at) VALUES ($1, GREATEST($2, 0), NOW())
+		 ON CONFLICT (post_id) DO UPDATE SET %[1]s = GREATEST(post_stats.%[1]s + $2, 0), updated_at = NOW()`,
+		c.column)
+	if _, err := tx.ExecContext(ctx, query, ev.TargetID, c.delta); err != nil {
+		return fmt.Errorf("could not update post stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit event: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresStatsRepository) GetPostStats(ctx context.Context, postID string) (*models.PostStats, error) {
+	query := `SELECT post_id, views_count, likes_count, comments_count, updated_at FROM post_stats WHERE post_id = $1`
+	var stats models.PostStats
+	err := r.db.GetContext(ctx, &stats, query, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &models.PostStats{PostID: postID}, nil
+		}
+		return nil, fmt.Errorf("could not get post stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// GetDailyCounts returns per-day event counts for the post in [from, to).
+// Days without events are omitted. Like and comment counts are net of
+// unlikes and deleted comments, so a day may have a negative count.
+func (r *postgresStatsRepository) GetDailyCounts(ctx context.Context, postID string, eventType models.EventType, from, to time.Time) ([]models.DailyCount, error) {
+	query := `SELECT (occurred_at AT TIME ZONE 'UTC')::date AS day,
+                     SUM(CASE WHEN event_type = $2 THEN 1 ELSE -1 END) AS count
+              FROM event_log
+              WHERE target_id = $1 AND event_type IN ($2, $5) AND occurred_at >= $3 AND occurred_at < $4
+              GROUP BY day
+              ORDER BY day`
+	counted, reversal := countedTypes(eventType)
+	counts := []models.DailyCount{}
+	err := r.db.SelectContext(ctx, &counts, query, postID, counted, from, to, reversal)
+	if err != nil {
+		return nil, fmt.Errorf("could not get daily counts: %w", err)
+	}
+	return counts, nil
+}
+
+// ApplyPostEvent keeps the posts table in line with the post-lifecycle
+// topic. Events older than the stored snapshot are ignored and a deleted
+// post is never revived, so redeliveries are harmless. Only hide and unhide
+// events change the hidden flag, ordered by their own timestamp.
+func (r *postgresStatsRepository) ApplyPostEvent(ctx context.Context, ev *models.PostEvent) error {
+	if ev.Type == models.PostEventHidden || ev.Type == models.PostEventUnhidden {
+		return r.applyPostHidden(ctx, ev)
+	}
+
+	var deletedAt *time.Time
+	if ev.Type == models.PostEventDeleted {
+		deletedAt = &ev.OccurredAt
+	}
+
+	query := `INSERT INTO posts (post_id, user_id, tags, is_private, hidden, created_at, updated_at, deleted_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+              ON CONFLICT (post_id) DO UPDATE SET
+                  tags = EXCLUDED.tags,
+                  is_private = EXCLUDED.is_private,
+                  updated_at = EXCLUDED.updated_at,
+                  deleted_at = EXCLUDED.deleted_at
+              WHERE posts.deleted_at IS NULL
+                AND (posts.updated_at IS NULL OR posts.updated_at <= EXCLUDED.updated_at OR EXCLUDED.deleted_at IS NOT NULL)`
+	_, err := r.db.ExecContext(ctx, query,
+		ev.Post.PostID, ev.Post.UserID, ev.Post.Tags, ev.Post.IsPrivate, ev.Post.Hidden, ev.Post.CreatedAt, ev.Post.UpdatedAt, deletedAt)
+	if err != nil {
+		return fmt.Errorf("could not apply %s event: %w", ev.Type, err)
+	}
+	return nil
+}
+
+func (r *postgresStatsRepository) applyPostHidden(ctx context.Context, ev *models.PostEvent) error {
+	query := `INSERT INTO posts (post_id, user_id, tags, is_private, hidden, hidden_changed_at, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+              ON CONFLICT (post_id) DO UPDATE SET
+                  hidden = EXCLUDED.hidden,
+                  hidden_changed_at = EXCLUDED.hidden_changed_at
+              WHERE posts.hidden_changed_at IS NULL OR posts.hidden_changed_at <= EXCLUDED.hidden_changed_at`
+	hidden := ev.Type == models.PostEventHidden
+	_, err := r.db.ExecContext(ctx, query,
+		ev.Post.PostID, ev.Post.UserID, ev.Post.Tags, ev.Post.IsPrivate, hidden, ev.OccurredAt, ev.Post.CreatedAt, ev.Post.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("could not apply %s event: %w", ev.Type, err)
+	}
+	return nil
+}
+
+// GetTopPosts ranks posts by the number of events in [from, to), leaving out
+// deleted, private and hidden posts and posts without a positive count. The
+// author is empty for posts whose creation event has not been seen.
+func (r *postgresStatsRepository) GetTopPosts(ctx context.Context, eventType models.EventType, from, to time.Time, limit int) ([]models.PostRank, error) {
+	query := `SELECT e.target_id AS post_id, COALESCE(p.user_id::text, '') AS user_id,
+                     SUM(CASE WHEN e.event_type = $1 THEN 1 ELSE -1 END) AS count
+              FROM event_log e
+              LEFT JOIN posts p ON p.post_id = e.target_id
+              WHERE e.event_type IN ($1, $5) AND e.occurred_at >= $2 AND e.occurred_at < $3
+                AND p.deleted_at IS NULL AND p.is_private IS NOT TRUE AND p.hidden IS NOT TRUE
+              GROUP BY e.target_id, p.user_id
+              HAVING SUM(CASE WHEN e.event_type = $1 THEN 1 ELSE -1 END) > 0
+              ORDER BY count DESC, e.target_id
+              LIMIT $4`
+	counted, reversal := countedTypes(eventType)
+	ranks := []models.PostRank{}
+	err := r.db.SelectContext(ctx, &ranks, query, counted, from, to, limit, reversal)
+	if err != nil {
+		return nil, fmt.Errorf("could not get top posts: %w", err)
+	}
+	return ranks, nil
+}
+
+// GetTopUsers ranks post authors by the number of events their posts
+// received in [from, to). Events on deleted, private or hidden posts and on
+// posts with an unknown author are skipped, as are authors without a positive
+// count.
+func (r *postgresStatsRepository) GetTopUsers(ctx context.Context, eventType models.EventType, from, to time.Time, limit int) ([]models.UserRank, error) {
+	query := `SELECT p.user_id, SUM(CASE WHEN e.event_type = $1 THEN 1 ELSE -1 END) AS count
+              FROM event_log e
+              JOIN posts p ON p.post_id = e.target_id
+              WHERE e.event_type IN ($1, $5) AND e.occurred_at >= $2 AND e.occurred_at < $3
+                AND p.deleted_at IS NULL AND p.is_private IS NOT TRUE AND p.hidden IS NOT TRUE
+              GROUP BY p.user_id
+              HAVING SUM(CASE WHEN e.event_type = $1 THEN 1 ELSE -1 END) > 0
+              ORDER BY count DESC, p.user_id
+              LIMIT $4`
+	counted, reversal := countedTypes(eventType)
+	ranks := []models.UserRank{}
+	err := r.db.SelectContext(ctx, &ranks, query, counted, from, to, limit, reversal)
+	if err != nil {
+		return nil, fmt.Errorf("could not get top users: %w", err)
+	}
+	return ranks, nil
+}
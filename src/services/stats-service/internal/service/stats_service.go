@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/repository"
+)
+
+type StatsService struct {
+	repo repository.StatsRepository
+}
+
+func NewStatsService(r repository.StatsRepository) *StatsService {
+	return &StatsService{repo: r}
+}
+
+func ToProtoPostStats(stats *models.PostStats) *statspb.PostStats {
+	if stats == nil {
+		return nil
+	}
+	res := &statspb.PostStats{
+		PostId:   stats.PostID,
+		Views:    stats.ViewsCount,
+		Likes:    stats.LikesCount,
+		Comments: stats.CommentsCount,
+	}
+	if !stats.UpdatedAt.IsZero() {
+		res.UpdatedAt = timestamppb.New(stats.UpdatedAt)
+	}
+	return res
+}
+
+func ToProtoTimeline(timeline *models.Timeline, metric statspb.Metric) *statspb.PostTimelineResponse {
+	if timeline == nil {
+		return nil
+	}
+	points := make([]*statspb.DailyCount, 0, len(timeline.Points))
+	for _, p := range timeline.Points {
+		points = append(points, &statspb.DailyCount{Date: p.Date.Format(time.DateOnly), Count: p.Count})
+	}
+	return &statspb.PostTimelineResponse{
+		PostId: timeline.PostID,
+		Metric: metric,
+		From:   timeline.From.Format(time.DateOnly),
+		To:     timeline.To.Format(time.DateOnly),
+		Points: points,
+	}
+}
+
+func validatePostID(postID string) error {
+	if postID == "" {
+		return status.Error(codes.InvalidArgument, "post ID is required")
+	}
+	if _, err := uuid.Parse(postID); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid post ID format: %s", postID)
+	}
+	return nil
+}
+
+func (s *StatsService) GetPostStats(ctx context.Context, postID string) (*models.PostStats, error) {
+	if err := validatePostID(postID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetPostStats(ctx, postID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get stats for post %s: %v", postID, err)
+	}
+	return stats, nil
+}
+
+func (s *StatsService) GetPostTimeline(ctx context.Context, req *statspb.GetPostTimelineRequest) (*models.Timeline, error) {
+	postID := req.GetPostId()
+	if err := validatePostID(postID); err != nil {
+		return nil, err
+	}
+	eventType, err := metricEventType(req.GetMetric())
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDateRange(req.GetFrom(), req.GetTo(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.GetDailyCounts(ctx, postID, eventType, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get timeline for post %s: %v", postID, err)
+	}
+
+	return &models.Timeline{
+		PostID:    postID,
+		EventType: eventType,
+		From:      from,
+		To:        to,
+		Points:    fillDailySeries(from, to, counts),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+const (
+	defaultTimelineDays = 30
+	maxTimelineDays     = 366
+)
+
+var metricEventTypes = map[statspb.Metric]models.EventType{
+	statspb.Metric_METRIC_VIEWS:    models.EventTypeView,
+	statspb.Metric_METRIC_LIKES:    models.EventTypeLike,
+	statspb.Metric_METRIC_COMMENTS: models.EventTypeComment,
+}
+
+func metricEventType(metric statspb.Metric) (models.EventType, error) {
+	eventType, ok := metricEventTypes[metric]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "unsupported metric %s", metric)
+	}
+	return eventType, nil
+}
+
+// parseDateRange resolves an inclusive [from, to] range of UTC days. An empty
+// to means today, an empty from means defaultTimelineDays days back from to.
+func parseDateRange(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	to := now.UTC().Truncate(24 * time.Hour)
+	if toStr != "" {
+		parsed, err := time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "invalid to date %q, expected YYYY-MM-DD", toStr)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultTimelineDays - 1))
+	if fromStr != "" {
+		parsed, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "invalid from date %q, expected YYYY-MM-DD", fromStr)
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "from must not be after to")
+	}
+	if from.AddDate(0, 0, maxTimelineDays).Before(to.AddDate(0, 0, 1)) {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "date range must not exceed %d days", maxTimelineDays)
+	}
+	return from, to, nil
+}
+
+// fillDailySeries expands sparse per-day counts into one point per day of
+// [from, to], using zero for days without events.
+func fillDailySeries(from, to time.Time, counts []models.DailyCount) []models.DailyCount {
+	byDay := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		byDay[c.Date.Format(time.DateOnly)] = c.Count
+	}
+
+	series := []models.DailyCount{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		series = append(series, models.DailyCount{Date: day, Count: byDay[day.Format(time.DateOnly)]})
+	}
+	return series
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+func day(s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2025, 5, 10, 18, 45, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{"defaults to last 30 days", "", "", day("2025-04-11"), day("2025-05-10"), false},
+		{"explicit range", "2025-01-01", "2025-01-31", day("2025-01-01"), day("2025-01-31"), false},
+		{"only to", "", "2025-03-30", day("2025-03-01"), day("2025-03-30"), false},
+		{"single day", "2025-02-02", "2025-02-02", day("2025-02-02"), day("2025-02-02"), false},
+		{"max span", "2024-01-01", "2024-12-31", day("2024-01-01"), day("2024-12-31"), false},
+		{"span too long", "2024-01-01", "2025-01-01", time.Time{}, time.Time{}, true},
+		{"from after to", "2025-02-03", "2025-02-02", time.Time{}, time.Time{}, true},
+		{"bad from", "02/01/2025", "", time.Time{}, time.Time{}, true},
+		{"bad to", "", "yesterday", time.Time{}, time.Time{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := parseDateRange(tc.from, tc.to, now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseDateRange(%q, %q) error = %v, wantErr %t", tc.from, tc.to, err, tc.wantErr)
+			}
+			if !from.Equal(tc.wantFrom) || !to.Equal(tc.wantTo) {
+				t.Errorf("parseDateRange(%q, %q) = [%s, %s], want [%s, %s]", tc.from, tc.to, from, to, tc.wantFrom, tc.wantTo)
+			}
+		})
+	}
+}
+
+func TestFillDailySeries(t *testing.T) {
+	counts := []models.DailyCount{
+		{Date: day("2025-03-02"), Count: 4},
+		{Date: day("2025-03-04"), Count: 1},
+	}
+
+	series := fillDailySeries(day("2025-03-01"), day("2025-03-05"), counts)
+
+	want := []int64{0, 4, 0, 1, 0}
+	if len(series) != len(want) {
+		t.Fatalf("got %d points, want %d", len(series), len(want))
+	}
+	for i, p := range series {
+		wantDay := day("2025-03-01").AddDate(0, 0, i)
+		if !p.Date.Equal(wantDay) || p.Count != want[i] {
+			t.Errorf("point %d = (%s, %d), want (%s, %d)", i, p.Date.Format(time.DateOnly), p.Count, wantDay.Format(time.DateOnly), want[i])
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	statspb "github.com/zahartd/social-network/src/gen/go/stats"
+	"github.com/zahartd/social-network/src/services/stats-service/internal/models"
+)
+
+const (
+	defaultTopLimit = 10
+	maxTopLimit     = 100
+)
+
+// resolveTopLimit applies defaultTopLimit to an unset limit and rejects
+// values outside (0, maxTopLimit].
+func resolveTopLimit(limit int32) (int, error) {
+	if limit == 0 {
+		return defaultTopLimit, nil
+	}
+	if limit < 0 || limit > maxTopLimit {
+		return 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxTopLimit)
+	}
+	return int(limit), nil
+}
+
+func parseTopRequest(req *statspb.TopRequest) (models.EventType, time.Time, time.Time, int, error) {
+	eventType, err := metricEventType(req.GetMetric())
+	if err != nil {
+		return "", time.Time{}, time.Time{}, 0, err
+	}
+	from, to, err := parseDateRange(req.GetFrom(), req.GetTo(), time.Now())
+	if err != nil {
+		return "", time.Time{}, time.Time{}, 0, err
+	}
+	limit, err := resolveTopLimit(req.GetLimit())
+	if err != nil {
+		return "", time.Time{}, time.Time{}, 0, err
+	}
+	return eventType, from, to, limit, nil
+}
+
+func ToProtoTopPosts(top *models.TopPosts, metric statspb.Metric) *statspb.TopPostsResponse {
+	if top == nil {
+		return nil
+	}
+	posts := make([]*statspb.TopPost, 0, len(top.Posts))
+	for _, p := range top.Posts {
+		posts = append(posts, &statspb.TopPost{PostId: p.PostID, UserId: p.UserID, Count: p.Count})
+	}
+	return &statspb.TopPostsResponse{
+		Metric: metric,
+		From:   top.From.Format(time.DateOnly),
+		To:     top.To.Format(time.DateOnly),
+		Posts:  posts,
+	}
+}
+
+func ToProtoTopUsers(top *models.TopUsers, metric statspb.Metric) *statspb.TopUsersResponse {
+	if top == nil {
+		return nil
+	}
+	users := make([]*statspb.TopUser, 0, len(top.Users))
+	for _, u := range top.Users {
+		users = append(users, &statspb.TopUser{UserId: u.UserID, Count: u.Count})
+	}
+	return &statspb.TopUsersResponse{
+		Metric: metric,
+		From:   top.From.Format(time.DateOnly),
+		To:     top.To.Format(time.DateOnly),
+		Users:  users,
+	}
+}
+
+func (s *StatsService) TopPosts(ctx context.Context, req *statspb.TopRequest) (*models.TopPosts, error) {
+	eventType, from, to, limit, err := parseTopRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.repo.GetTopPosts(ctx, eventType, from, to.AddDate(0, 0, 1), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get top posts: %v", err)
+	}
+	return &models.TopPosts{EventType: eventType, From: from, To: to, Posts: posts}, nil
+}
+
+// TopUsers ranks authors by the events received by their posts, e.g. likes
+// received rather than likes given.
+func (s *StatsService) TopUsers(ctx context.Context, req *statspb.TopRequest) (*models.TopUsers, error) {
+	eventType, from, to, limit, err := parseTopRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.repo.GetTopUsers(ctx, eventType, from, to.AddDate(0, 0, 1), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get top users: %v", err)
+	}
+	return &models.TopUsers{EventType: eventType, From: from, To: to, Users: users}, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import "testing"
+
+func TestResolveTopLimit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		limit   int32
+		want    int
+		wantErr bool
+	}{
+		{"unset", 0, defaultTopLimit, false},
+		{"explicit", 5, 5, false},
+		{"max", maxTopLimit, maxTopLimit, false},
+		{"too large", maxTopLimit + 1, 0, true},
+		{"negative", -1, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveTopLimit(tc.limit)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("resolveTopLimit(%d) error = %v, wantErr %v", tc.limit, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("resolveTopLimit(%d) = %d, want %d", tc.limit, got, tc.want)
+			}
+		})
+	}
+}
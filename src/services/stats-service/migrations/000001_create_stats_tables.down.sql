@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_event_log_target;
+DROP TABLE IF EXISTS event_log;
+DROP TABLE IF EXISTS post_stats;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS post_stats (
+    post_id UUID PRIMARY KEY, -- Ссылки на таблицу posts нет, т.к. это разные БД
+    views_count BIGINT NOT NULL DEFAULT 0,
+    likes_count BIGINT NOT NULL DEFAULT 0,
+    comments_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS event_log (
+    event_id UUID PRIMARY KEY,
+    event_type TEXT NOT NULL, -- view, like, comment
+    user_id UUID, -- Пользователь, инициировавший событие
+    target_id UUID NOT NULL, -- Пост, к которому относится событие
+    occurred_at TIMESTAMPTZ NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb -- Исходное сообщение из Kafka
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_log_target ON event_log (target_id, event_type, occurred_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_event_log_type_time;
+DROP TABLE IF EXISTS posts;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS posts (
+    post_id UUID PRIMARY KEY, -- Заполняется из событий топика post-lifecycle
+    user_id UUID NOT NULL, -- Автор поста
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts (user_id);
+
+-- Для рейтингов по окну времени без привязки к конкретному посту
+CREATE INDEX IF NOT EXISTS idx_event_log_type_time ON event_log (event_type, occurred_at);
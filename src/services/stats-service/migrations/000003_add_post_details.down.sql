@@ -0,0 +1,5 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS is_private,
+    DROP COLUMN IF EXISTS tags;
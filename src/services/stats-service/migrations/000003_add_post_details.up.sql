@@ -0,0 +1,5 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ, -- Время последнего применённого события post_updated
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ; -- Удалённые посты не участвуют в рейтингах
@@ -0,0 +1,3 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS hidden_changed_at,
+    DROP COLUMN IF EXISTS hidden;
@@ -0,0 +1,3 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE, -- Скрытые модератором посты не участвуют в рейтингах
+    ADD COLUMN IF NOT EXISTS hidden_changed_at TIMESTAMPTZ; -- Время последнего применённого события post_hidden/post_unhidden
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -10,9 +13,12 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/segmentio/kafka-go"
 
+	"github.com/zahartd/social-network/src/events"
 	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
 	"github.com/zahartd/social-network/src/services/user-service/internal/config"
 	"github.com/zahartd/social-network/src/services/user-service/internal/handlers"
+	"github.com/zahartd/social-network/src/services/user-service/internal/mailer"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
 	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
 	"github.com/zahartd/social-network/src/services/user-service/internal/service"
 	"github.com/zahartd/social-network/src/services/user-service/internal/utils"
@@ -56,7 +62,30 @@ func initCustomValidators() {
 	}
 }
 
+// grantAdminRole makes an existing user an admin. It is the one-off way to
+// get the first admin of a deployment; later admins are granted through the
+// API.
+func grantAdminRole(userRepo repository.UserRepository, roleRepo repository.RoleRepository, login string) error {
+	user, err := userRepo.GetByLogin(login)
+	if err != nil {
+		return err
+	}
+	granted, err := roleRepo.Grant(user.ID, models.RoleAdmin, "")
+	if err != nil {
+		return err
+	}
+	if granted {
+		log.Printf("granted the admin role to %s", login)
+	} else {
+		log.Printf("%s is already an admin", login)
+	}
+	return nil
+}
+
 func main() {
+	grantAdmin := flag.String("grant-admin", "", "grant the admin role to the user with this login and exit")
+	flag.Parse()
+
 	cfg := config.Load()
 
 	db, err := sql.Open("postgres", cfg.DB_DSN)
@@ -67,19 +96,57 @@ func main() {
 
 	userRepo := repository.NewPostgresUserRepo(db)
 	sessionRepo := repository.NewPostgresSessionRepo(db)
-	auth.SetSessionRepo(sessionRepo)
-	registrationsWriter := &kafka.Writer{
-		Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
-		Topic:                  "user-registrations",
-		Async:                  true,
-		AllowAutoTopicCreation: true,
+	roleRepo := repository.NewPostgresRoleRepo(db)
+	resetRepo := repository.NewPostgresPasswordResetRepo(db)
+	verifyRepo := repository.NewPostgresEmailVerificationRepo(db)
+	attemptRepo := repository.NewPostgresLoginAttemptRepo(db)
+	auditRepo := repository.NewPostgresAuthAuditRepo(db)
+	mfaRepo := repository.NewPostgresMFARepo(db)
+	followRepo := repository.NewPostgresFollowRepo(db)
+	blockRepo := repository.NewPostgresBlockRepo(db)
+	outboxRepo := repository.NewPostgresOutboxRepo(db)
+	if *grantAdmin != "" {
+		if err := grantAdminRole(userRepo, roleRepo, *grantAdmin); err != nil {
+			log.Fatalf("Failed to grant the admin role to %s: %v", *grantAdmin, err)
+		}
+		return
 	}
+
+	auth.SetSessionRepo(sessionRepo)
+	auth.SetRoleRepo(roleRepo)
+	// Synchronous writer without a fixed topic: the relay marks a message as
+	// sent only after Kafka has acknowledged it, and each outbox row names its
+	// own topic. Hash keeps messages with the same key in one partition.
+	publisher := events.NewKafkaPublisher(
+		&kafka.Writer{
+			Addr:                   kafka.TCP(cfg.KafkaBrokerURL),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			BatchTimeout:           10 * time.Millisecond,
+			AllowAutoTopicCreation: true,
+		},
+		events.WithHooks(events.Hooks{
+			OnRetry: func(topic string, attempt int, err error) {
+				log.Printf("retrying publish to %s (attempt %d): %v", topic, attempt, err)
+			},
+		}),
+	)
 	defer func() {
-		if err := registrationsWriter.Close(); err != nil {
-			log.Fatal("failed to close writer:", err)
+		if err := publisher.Close(); err != nil {
+			log.Fatal("failed to close publisher:", err)
 		}
 	}()
-	userService := service.NewUserService(userRepo, sessionRepo, registrationsWriter)
+	relay := events.NewRelay(outboxRepo, publisher)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Run(ctx)
+	go service.PruneLoginAttempts(ctx, attemptRepo)
+
+	codec, err := events.NewCodec(cfg.EventsEncoding)
+	if err != nil {
+		log.Fatalf("Invalid EVENTS_ENCODING: %v", err)
+	}
+	userService := service.NewUserService(userRepo, sessionRepo, roleRepo, resetRepo, verifyRepo, attemptRepo, auditRepo, mfaRepo, followRepo, blockRepo, codec, mailer.NewFileMailer(cfg.MailerFile))
 	userHandler := handlers.NewUserHandler(userService)
 
 	auth.InitJWT()
@@ -89,14 +156,53 @@ func main() {
 	initCustomValidators()
 
 	router.POST("/user", userHandler.CreateUser)
-	router.GET("/user/login", userHandler.Login)
+	router.POST("/user/login", userHandler.Login)
+	router.GET("/user/login", userHandler.LoginWithQuery)
+	router.POST("/user/login/mfa", userHandler.CompleteMFALogin)
 	router.GET("/user/logout", userHandler.Logout)
+	router.POST("/user/token/refresh", userHandler.RefreshToken)
+	router.POST("/user/password/forgot", userHandler.ForgotPassword)
+	router.POST("/user/password/reset", userHandler.ResetPassword)
+	router.POST("/user/verify-email", userHandler.VerifyEmail)
+	router.GET("/internal/token/introspect", userHandler.IntrospectToken)
+	router.GET("/internal/users/:userID/following", userHandler.InternalFollowing)
+	router.GET("/internal/users/:userID/followers", userHandler.InternalFollowers)
+	router.GET("/internal/users/:userID/close-friend-of", userHandler.InternalCloseFriendOf)
+	router.GET("/internal/users/:userID/restrictions", userHandler.InternalRestrictions)
 
 	protected := router.Group("/user")
 	protected.Use(auth.JWTAuthMiddleware())
+	protected.POST("/verify-email/resend", userHandler.ResendVerification)
+	protected.GET("/sessions", userHandler.ListSessions)
+	protected.DELETE("/sessions", userHandler.RevokeOtherSessions)
+	protected.DELETE("/sessions/:sessionID", userHandler.RevokeSession)
+	protected.POST("/mfa/totp", userHandler.EnrollTOTP)
+	protected.POST("/mfa/totp/confirm", userHandler.ConfirmTOTP)
+	protected.DELETE("/mfa/totp", userHandler.DisableTOTP)
+	protected.GET("/close-friends", userHandler.ListCloseFriends)
+	protected.POST("/close-friends/:identifier", userHandler.AddCloseFriend)
+	protected.DELETE("/close-friends/:identifier", userHandler.RemoveCloseFriend)
+	protected.GET("/blocks", userHandler.ListBlocks)
+	protected.POST("/blocks/:identifier", userHandler.Block)
+	protected.DELETE("/blocks/:identifier", userHandler.Unblock)
+	protected.GET("/mutes", userHandler.ListMutes)
+	protected.POST("/mutes/:identifier", userHandler.Mute)
+	protected.DELETE("/mutes/:identifier", userHandler.Unmute)
 	protected.GET("/:identifier", userHandler.GetUser)
 	protected.PUT("/:identifier", userHandler.UpdateUser)
 	protected.DELETE("/:identifier", userHandler.DeleteUser)
+	protected.PUT("/:identifier/password", userHandler.ChangePassword)
+	protected.POST("/:identifier/follow", userHandler.Follow)
+	protected.DELETE("/:identifier/follow", userHandler.Unfollow)
+	protected.GET("/:identifier/followers", userHandler.ListFollowers)
+	protected.GET("/:identifier/following", userHandler.ListFollowing)
+
+	admin := protected.Group("/:identifier/roles")
+	admin.Use(auth.RequireRole(models.RoleAdmin))
+	admin.GET("", userHandler.ListRoles)
+	admin.POST("", userHandler.GrantRole)
+	admin.DELETE("/:role", userHandler.RevokeRole)
+	protected.DELETE("/:identifier/lockout", auth.RequireRole(models.RoleAdmin), userHandler.UnlockAccount)
 
 	router.Run(":" + cfg.Port)
 }
@@ -1,27 +1,37 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"github.com/zahartd/social-network/src/services/user-service/internal/models"
 	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
 )
 
-const tokenExpireTime = 3 * time.Minute
+const (
+	tokenExpireTime        = 3 * time.Minute
+	refreshTokenExpireTime = 30 * 24 * time.Hour
+)
 
 var (
 	rsaPrivateKey *rsa.PrivateKey
 	rsaPublicKey  *rsa.PublicKey
 	sessionRepo   repository.SessionRepository
+	roleRepo      repository.RoleRepository
 )
 
 func InitJWT() {
@@ -57,32 +67,105 @@ func SetSessionRepo(repo repository.SessionRepository) {
 	sessionRepo = repo
 }
 
+func SetRoleRepo(repo repository.RoleRepository) {
+	roleRepo = repo
+}
+
+// GenerateToken issues an access token carrying the user's roles and
+// whether their email is verified. Tokens are short-lived, so such changes
+// reach other services within tokenExpireTime.
 func GenerateToken(user *models.User) (string, error) {
 	claims := jwt.MapClaims{
-		"sub":   user.ID,
-		"login": user.Login,
-		"exp":   time.Now().Add(tokenExpireTime).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":            user.ID,
+		"login":          user.Login,
+		"roles":          user.Roles,
+		"email_verified": user.EmailVerifiedAt != nil,
+		"jti":            uuid.NewString(),
+		"exp":            time.Now().Add(tokenExpireTime).Unix(),
+		"iat":            time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	return token.SignedString(rsaPrivateKey)
 }
 
-func CreateSession(user *models.User, token string, ipAddress string) error {
-	if sessionRepo == nil {
-		return fmt.Errorf("session repository is not set")
+// NewOpaqueToken returns a random token, used for refresh and password
+// reset tokens, and the hash that is stored instead of it.
+func NewOpaqueToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken is a plain SHA-256: opaque tokens carry 256 random bits,
+// so unlike passwords they need no salt or slow hash, and the hash can be
+// looked up directly.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewTokenPair(accessToken, refreshToken string) *models.TokenPair {
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(tokenExpireTime.Seconds()),
 	}
+}
+
+func newSession(user *models.User, familyID, token, ipAddress string) (*models.Session, string, error) {
 	ip := net.ParseIP(ipAddress)
 	if ip == nil {
-		return fmt.Errorf("invalid IP address: %s", ipAddress)
+		return nil, "", fmt.Errorf("invalid IP address: %s", ipAddress)
+	}
+	refreshToken, refreshHash, err := NewOpaqueToken()
+	if err != nil {
+		return nil, "", err
 	}
+	now := time.Now()
 	session := &models.Session{
-		UserID:    user.ID,
-		Token:     token,
-		ExpiresAt: time.Now().Add(tokenExpireTime),
-		IPAddress: ip,
+		FamilyID:         familyID,
+		UserID:           user.ID,
+		Token:            token,
+		RefreshTokenHash: refreshHash,
+		ExpiresAt:        now.Add(tokenExpireTime),
+		RefreshExpiresAt: now.Add(refreshTokenExpireTime),
+		IPAddress:        ip,
+	}
+	return session, refreshToken, nil
+}
+
+// CreateSession starts a new session family for token and returns its
+// refresh token.
+func CreateSession(user *models.User, token string, ipAddress string) (string, error) {
+	if sessionRepo == nil {
+		return "", fmt.Errorf("session repository is not set")
 	}
-	return sessionRepo.CreateSession(session)
+	session, refreshToken, err := newSession(user, uuid.NewString(), token, ipAddress)
+	if err != nil {
+		return "", err
+	}
+	if err := sessionRepo.CreateSession(session); err != nil {
+		return "", err
+	}
+	return refreshToken, nil
+}
+
+// RotateSession replaces old with a session for token in the same family and
+// returns the new refresh token. It reports false if old was rotated or
+// revoked concurrently.
+func RotateSession(old *models.Session, user *models.User, token string, ipAddress string) (string, bool, error) {
+	session, refreshToken, err := newSession(user, old.FamilyID, token, ipAddress)
+	if err != nil {
+		return "", false, err
+	}
+	rotated, err := sessionRepo.RotateSession(old.ID, session)
+	if err != nil || !rotated {
+		return "", rotated, err
+	}
+	return refreshToken, true, nil
 }
 
 func TrimBearerPrefix(token string) string {
@@ -137,3 +220,25 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireRole lets the request through only if the authenticated user holds
+// one of roles. Roles are read from the database rather than the token, so a
+// revoked role stops working at once. Must run after JWTAuthMiddleware.
+func RequireRole(roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		held, err := roleRepo.GetRoles(userID)
+		if err != nil {
+			log.Printf("Could not load roles of %s: %v", userID, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Could not check roles"})
+			return
+		}
+		for _, role := range held {
+			if slices.Contains(roles, role) {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+	}
+}
@@ -19,6 +19,7 @@ func TestGenerateToken(t *testing.T) {
 	user := &models.User{
 		ID:    "test-uuid",
 		Login: "testuser",
+		Roles: []models.Role{models.RoleUser, models.RoleModerator},
 	}
 
 	tokenStr, err := GenerateToken(user)
@@ -47,6 +48,13 @@ func TestGenerateToken(t *testing.T) {
 	if claims["login"] != user.Login {
 		t.Errorf("Expected login claim %s, got %v", user.Login, claims["login"])
 	}
+	roles, ok := claims["roles"].([]any)
+	if !ok || len(roles) != 2 || roles[0] != "user" || roles[1] != "moderator" {
+		t.Errorf("Expected roles claim [user moderator], got %v", claims["roles"])
+	}
+	if claims["email_verified"] != false {
+		t.Errorf("Expected email_verified claim false, got %v", claims["email_verified"])
+	}
 
 	iat, ok1 := claims["iat"].(float64)
 	exp, ok2 := claims["exp"].(float64)
@@ -76,3 +84,27 @@ func TestTrimBearerPrefix(t *testing.T) {
 		t.Errorf("Expected empty string, got %q", result)
 	}
 }
+
+func TestNewOpaqueToken(t *testing.T) {
+	token, hash, err := NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("NewOpaqueToken returned error: %v", err)
+	}
+	if len(token) != 43 {
+		t.Errorf("Expected 43-char token, got %q", token)
+	}
+	if hash != HashOpaqueToken(token) {
+		t.Error("Returned hash does not match HashOpaqueToken")
+	}
+	if hash == token {
+		t.Error("Hash must differ from the token")
+	}
+
+	other, _, err := NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("NewOpaqueToken returned error: %v", err)
+	}
+	if other == token {
+		t.Error("Two opaque tokens are equal")
+	}
+}
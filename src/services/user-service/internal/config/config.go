@@ -9,6 +9,11 @@ type Config struct {
 	Port           string
 	DB_DSN         string
 	KafkaBrokerURL string
+	// EventsEncoding is the wire format of published events: json or protobuf.
+	EventsEncoding string
+	// MailerFile is where the local mailer appends outgoing mail; empty
+	// means mail is only logged.
+	MailerFile string
 }
 
 func Load() *Config {
@@ -26,5 +31,7 @@ func Load() *Config {
 		Port:           port,
 		DB_DSN:         dbDSN,
 		KafkaBrokerURL: os.Getenv("KAFKA_BROKER_URL"),
+		EventsEncoding: os.Getenv("EVENTS_ENCODING"),
+		MailerFile:     os.Getenv("MAILER_FILE"),
 	}
 }
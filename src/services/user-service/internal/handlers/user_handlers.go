@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -33,20 +38,42 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	user, token, err := h.service.CreateUser(c, req.Login, req.Firstname, req.Surname, req.Email, req.Password)
+	user, tokens, err := h.service.CreateUser(c, req.Login, req.Firstname, req.Surname, req.Email, req.Password)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"user":  user,
-		"token": token,
+		"user":          user,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
 	})
 }
 
 func (h *UserHandler) Login(c *gin.Context) {
-	login := c.Query("login")
+	var req struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.login(c, req.Login, req.Password)
+}
+
+// LoginWithQuery is the deprecated GET /user/login, which puts the password
+// in the URL and so in access logs. It is kept until clients move to POST.
+func (h *UserHandler) LoginWithQuery(c *gin.Context) {
+	c.Header("Deprecation", "true")
+	c.Header("Link", `</user/login>; rel="successor-version"`)
+	c.Header("Warning", `299 - "GET /user/login is deprecated, send credentials with POST /user/login"`)
+	h.login(c, c.Query("login"), c.Query("password"))
+}
+
+func (h *UserHandler) login(c *gin.Context, login, password string) {
 	if login == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "login is required"})
 		return
@@ -55,7 +82,6 @@ func (h *UserHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "login is incorrect"})
 		return
 	}
-	password := c.Query("password")
 	if password == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "password is required"})
 		return
@@ -64,12 +90,97 @@ func (h *UserHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "password is incorrect"})
 		return
 	}
-	token, err := h.service.Login(c, login, password)
+	tokens, pending, err := h.service.Login(c, login, password)
+	if respondLocked(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if pending != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"mfa_required": true,
+			"mfa_token":    pending.Token,
+			"expires_in":   pending.ExpiresIn,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// respondLocked answers 429 with a Retry-After header if err is a
+// *service.LockedError.
+func respondLocked(c *gin.Context, err error) bool {
+	var locked *service.LockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	retryAfter := int(math.Ceil(time.Until(locked.Until).Seconds()))
+	c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
+
+func (h *UserHandler) CompleteMFALogin(c *gin.Context) {
+	var req struct {
+		MFAToken string `json:"mfa_token" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tokens, err := h.service.CompleteMFALogin(c, req.MFAToken, req.Code)
+	if respondLocked(c, err) {
+		return
+	}
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidMFAToken) || errors.Is(err, service.ErrInvalidMFACode) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	tokens, err := h.service.RefreshToken(c, req.RefreshToken)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// IntrospectToken tells the API gateway whether the bearer token's session
+// is still active. It answers 200 either way; the route is internal and not
+// proxied by the gateway.
+func (h *UserHandler) IntrospectToken(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing token"})
+		return
+	}
+	active, err := h.service.IntrospectToken(c, token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check session"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"active": active})
 }
 
 func (h *UserHandler) Logout(c *gin.Context) {
@@ -110,15 +221,26 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	counts, err := h.service.FollowCounts(c, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count followers"})
+		return
+	}
+
 	if requesterID == user.ID {
-		c.JSON(http.StatusOK, user)
+		c.JSON(http.StatusOK, struct {
+			*models.User
+			models.FollowCounts
+		}{user, counts})
 	} else {
 		summary := gin.H{
-			"login":     user.Login,
-			"email":     user.Email,
-			"firstname": user.Firstname,
-			"surname":   user.Surname,
-			"bio":       user.Bio,
+			"login":          user.Login,
+			"email":          user.Email,
+			"firstname":      user.Firstname,
+			"surname":        user.Surname,
+			"bio":            user.Bio,
+			"followersCount": counts.Followers,
+			"followingCount": counts.Following,
 		}
 		c.JSON(http.StatusOK, summary)
 	}
@@ -206,3 +328,507 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
+
+func (h *UserHandler) findUser(c *gin.Context) (*models.User, bool) {
+	id, isUUID, err := utils.ParseIdentifier(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	var user *models.User
+	if isUUID {
+		user, err = h.service.GetUserByID(c, id)
+	} else {
+		user, err = h.service.GetUserByLogin(c, id)
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return user, true
+}
+
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrBaseRole), errors.Is(err, service.ErrSelfDemotion):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *UserHandler) ListRoles(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	roles, err := h.service.ListRoles(c, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+func (h *UserHandler) GrantRole(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	role, err := models.ParseRole(req.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	roles, err := h.service.GrantRole(c, user.ID, role, c.GetString("userID"))
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+func (h *UserHandler) RevokeRole(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	role, err := models.ParseRole(c.Param("role"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	roles, err := h.service.RevokeRole(c, user.ID, role, c.GetString("userID"))
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.service.ListSessions(c, c.GetString("userID"), c.GetHeader("Authorization"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	sessionID := c.Param("sessionID")
+	if !utils.ValidateUserID(sessionID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		return
+	}
+	if err := h.service.RevokeSession(c, c.GetString("userID"), sessionID); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	revoked, err := h.service.RevokeOtherSessions(c, c.GetString("userID"), c.GetHeader("Authorization"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	if c.GetString("userID") != user.ID {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized to change this password"})
+		return
+	}
+	var req struct {
+		OldPassword string `json:"old_password" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,password"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	revoked, err := h.service.ChangePassword(c, user.ID, req.OldPassword, req.NewPassword, c.GetHeader("Authorization"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrWrongPassword) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed", "revoked_sessions": revoked})
+}
+
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.RequestPasswordReset(c, req.Email); err != nil {
+		if respondLocked(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email is registered, a reset token has been sent to it"})
+}
+
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,password"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.ResetPassword(c, req.Token, req.NewPassword); err != nil {
+		if respondLocked(c, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.VerifyEmail(c, req.Token); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidVerificationToken) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
+
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	if err := h.service.ResendVerification(c, c.GetString("userID")); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrUserNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
+
+func (h *UserHandler) UnlockAccount(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	if err := h.service.UnlockAccount(c, user.ID, c.GetString("userID")); err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked"})
+}
+
+func (h *UserHandler) EnrollTOTP(c *gin.Context) {
+	enrollment, err := h.service.EnrollTOTP(c, c.GetString("userID"))
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, enrollment)
+}
+
+func (h *UserHandler) ConfirmTOTP(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	codes, err := h.service.ConfirmTOTP(c, c.GetString("userID"), req.Code)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *UserHandler) DisableTOTP(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.DisableTOTP(c, c.GetString("userID"), req.Password); err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrMFAAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrMFANotPending), errors.Is(err, service.ErrMFANotEnabled), errors.Is(err, service.ErrInvalidMFACode):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrWrongPassword):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *UserHandler) Follow(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Follow(c, c.GetString("userID"), user.ID); err != nil {
+		c.JSON(relationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Following " + user.Login})
+}
+
+func (h *UserHandler) Unfollow(c *gin.Context) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Unfollow(c, c.GetString("userID"), user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unfollowed " + user.Login})
+}
+
+func (h *UserHandler) ListFollowers(c *gin.Context) {
+	h.listFollows(c, "followers", h.service.ListFollowers)
+}
+
+func (h *UserHandler) ListFollowing(c *gin.Context) {
+	h.listFollows(c, "following", h.service.ListFollowing)
+}
+
+func (h *UserHandler) listFollows(c *gin.Context, key string, list func(*gin.Context, string, int, int) ([]models.FollowUser, error)) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	users, err := list(c, user.ID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{key: users, "page": page, "page_size": pageSize})
+}
+
+func (h *UserHandler) AddCloseFriend(c *gin.Context) {
+	h.changeRelation(c, h.service.AddCloseFriend, "%s added to close friends")
+}
+
+func (h *UserHandler) RemoveCloseFriend(c *gin.Context) {
+	h.changeRelation(c, h.service.RemoveCloseFriend, "%s removed from close friends")
+}
+
+func (h *UserHandler) Block(c *gin.Context) {
+	h.changeRelation(c, h.service.Block, "Blocked %s")
+}
+
+func (h *UserHandler) Unblock(c *gin.Context) {
+	h.changeRelation(c, h.service.Unblock, "Unblocked %s")
+}
+
+func (h *UserHandler) Mute(c *gin.Context) {
+	h.changeRelation(c, h.service.Mute, "Muted %s")
+}
+
+func (h *UserHandler) Unmute(c *gin.Context) {
+	h.changeRelation(c, h.service.Unmute, "Unmuted %s")
+}
+
+// changeRelation applies change from the caller to the user in the path
+// and answers with message, formatted with the user's login.
+func (h *UserHandler) changeRelation(c *gin.Context, change func(*gin.Context, string, string) error, message string) {
+	user, ok := h.findUser(c)
+	if !ok {
+		return
+	}
+	if err := change(c, c.GetString("userID"), user.ID); err != nil {
+		c.JSON(relationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf(message, user.Login)})
+}
+
+func relationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSelfFollow), errors.Is(err, service.ErrSelfCloseFriend),
+		errors.Is(err, service.ErrSelfBlock), errors.Is(err, service.ErrSelfMute):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrBlocked):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListCloseFriends, ListBlocks and ListMutes list the caller's own lists;
+// nobody else can see them.
+func (h *UserHandler) ListCloseFriends(c *gin.Context) {
+	h.listOwn(c, "close_friends", h.service.ListCloseFriends)
+}
+
+func (h *UserHandler) ListBlocks(c *gin.Context) {
+	h.listOwn(c, "blocks", h.service.ListBlocked)
+}
+
+func (h *UserHandler) ListMutes(c *gin.Context) {
+	h.listOwn(c, "mutes", h.service.ListMuted)
+}
+
+func (h *UserHandler) listOwn(c *gin.Context, key string, list func(*gin.Context, string, int, int) ([]models.FollowUser, error)) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	users, err := list(c, c.GetString("userID"), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{key: users, "page": page, "page_size": pageSize})
+}
+
+// parsePagination reads page and page_size, answering 400 if they are not
+// positive numbers.
+func parsePagination(c *gin.Context) (page, pageSize int, ok bool) {
+	const maxPageSize = 100
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return 0, 0, false
+	}
+	pageSize, err = strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page_size"})
+		return 0, 0, false
+	}
+	return page, pageSize, true
+}
+
+// InternalFollowing lists everyone a user follows, for the feed of
+// post-service. Like the other /internal routes it is not proxied by the
+// gateway.
+func (h *UserHandler) InternalFollowing(c *gin.Context) {
+	userID := c.Param("userID")
+	if !utils.ValidateUserID(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	follows, err := h.service.FollowingOf(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"following": follows})
+}
+
+// InternalFollowers lists up to limit followers of a user, for feed
+// fan-out in post-service.
+func (h *UserHandler) InternalFollowers(c *gin.Context) {
+	userID := c.Param("userID")
+	if !utils.ValidateUserID(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	ids, err := h.service.FollowerIDs(c, userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"followers": ids})
+}
+
+// InternalCloseFriendOf lists the users who have a user on their close
+// friends list, for the post visibility checks of post-service.
+func (h *UserHandler) InternalCloseFriendOf(c *gin.Context) {
+	userID := c.Param("userID")
+	if !utils.ValidateUserID(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	ids, err := h.service.CloseFriendOf(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"authors": ids})
+}
+
+// InternalRestrictions lists whom a user has blocked or been blocked by and
+// whom they muted, for the checks of post-service.
+func (h *UserHandler) InternalRestrictions(c *gin.Context) {
+	userID := c.Param("userID")
+	if !utils.ValidateUserID(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	restrictions, err := h.service.Restrictions(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, restrictions)
+}
@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer delivers messages to users. Production deployments plug in an SMTP
+// or provider-backed implementation.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type fileMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileMailer returns a Mailer for local use that appends each message as
+// a JSON line to the file at path, or only logs it if path is empty.
+func NewFileMailer(path string) Mailer {
+	return &fileMailer{path: path}
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	if m.path == "" {
+		log.Printf("mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+	line, err := json.Marshal(struct {
+		Message
+		SentAt time.Time `json:"sent_at"`
+	}{msg, time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
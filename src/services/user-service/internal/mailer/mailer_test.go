@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileMailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	m := NewFileMailer(path)
+
+	msgs := []Message{
+		{To: "a@example.com", Subject: "first", Body: "line one\nline two"},
+		{To: "b@example.com", Subject: "second", Body: "body"},
+	}
+	for _, msg := range msgs {
+		if err := m.Send(context.Background(), msg); err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open outbox: %v", err)
+	}
+	defer f.Close()
+
+	var got []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, msg)
+	}
+	if len(got) != len(msgs) {
+		t.Fatalf("Expected %d messages, got %d", len(msgs), len(got))
+	}
+	for i := range msgs {
+		if got[i] != msgs[i] {
+			t.Errorf("Message %d: expected %+v, got %+v", i, msgs[i], got[i])
+		}
+	}
+}
+
+func TestLogMailer(t *testing.T) {
+	if err := NewFileMailer("").Send(context.Background(), Message{To: "a@example.com"}); err != nil {
+		t.Errorf("Send without a file returned error: %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// Restrictions is what post-service hides from a user: everyone they have
+// blocked or who blocked them, and everyone they muted.
+type Restrictions struct {
+	Blocked []string `json:"blocked"`
+	Muted   []string `json:"muted"`
+}
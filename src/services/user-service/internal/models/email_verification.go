@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// EmailVerification confirms that UserID owns Email.
+type EmailVerification struct {
+	ID        string
+	UserID    string
+	Email     string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// FollowUser is an entry of a followers, following or close friends list.
+// For close friends FollowedAt is when the user was added.
+type FollowUser struct {
+	ID         string    `json:"id"`
+	Login      string    `json:"login"`
+	Firstname  string    `json:"firstname"`
+	Surname    string    `json:"surname"`
+	FollowedAt time.Time `json:"followedAt"`
+}
+
+// FollowCounts is shown on user profiles.
+type FollowCounts struct {
+	Followers int64 `json:"followersCount"`
+	Following int64 `json:"followingCount"`
+}
+
+// Follow is an edge of the graph as other services see it: the followed
+// user and when the follow started.
+type Follow struct {
+	UserID     string    `json:"user_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
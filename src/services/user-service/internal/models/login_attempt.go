@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AttemptKind is what failed logins and password reset requests are
+// counted by.
+type AttemptKind string
+
+const (
+	AttemptByLogin      AttemptKind = "login"
+	AttemptByIP         AttemptKind = "ip"
+	AttemptByResetEmail AttemptKind = "reset_email"
+	AttemptByResetIP    AttemptKind = "reset_ip"
+)
+
+type AuthAuditEvent string
+
+const (
+	AuditLoginFailed      AuthAuditEvent = "login_failed"
+	AuditLoginLocked      AuthAuditEvent = "login_locked"
+	AuditAccountUnlocked  AuthAuditEvent = "account_unlocked"
+	AuditMFAFailed        AuthAuditEvent = "mfa_failed"
+	AuditMFAEnabled       AuthAuditEvent = "mfa_enabled"
+	AuditMFADisabled      AuthAuditEvent = "mfa_disabled"
+	AuditRecoveryCodeUsed AuthAuditEvent = "recovery_code_used"
+)
+
+// AuthAuditEntry records a security-relevant authentication event. Empty
+// fields are stored as NULL.
+type AuthAuditEntry struct {
+	ID        string
+	Event     AuthAuditEvent
+	Login     string
+	UserID    string
+	ActorID   string
+	IPAddress string
+	CreatedAt time.Time
+}
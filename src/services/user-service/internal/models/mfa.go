@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// TOTP is a user's authenticator secret. It only guards logins once
+// EnabledAt is set, after the user has proven it with a code.
+type TOTP struct {
+	UserID       string
+	Secret       string
+	CreatedAt    time.Time
+	EnabledAt    *time.Time
+	LastUsedStep int64
+}
+
+// MFAChallenge is a login that passed the password check and waits for a
+// second factor.
+type MFAChallenge struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// MFAPending is returned by login instead of a token pair when the user has
+// a second factor enabled.
+type MFAPending struct {
+	Token     string `json:"mfa_token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// OutboxMessage is an event stored with the change it describes, waiting
+// for the outbox relay to publish it.
+type OutboxMessage struct {
+	ID          int64
+	EventID     string
+	Topic       string
+	Key         string
+	Payload     []byte
+	ContentType string
+	CreatedAt   time.Time
+	SentAt      *time.Time
+}
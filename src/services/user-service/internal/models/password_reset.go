@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type PasswordReset struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
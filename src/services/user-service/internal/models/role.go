@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleUser         Role = "user"
+	RoleApprovedUser Role = "approved_user"
+	RoleModerator    Role = "moderator"
+	RoleAdmin        Role = "admin"
+)
+
+var roles = map[Role]bool{
+	RoleUser:         true,
+	RoleApprovedUser: true,
+	RoleModerator:    true,
+	RoleAdmin:        true,
+}
+
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if !roles[role] {
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+	return role, nil
+}
+
+// RoleAssignment records who granted a role to a user. AssignedBy is nil
+// for roles assigned by the system.
+type RoleAssignment struct {
+	Role       Role      `json:"role"`
+	AssignedBy *string   `json:"assignedBy"`
+	AssignedAt time.Time `json:"assignedAt"`
+}
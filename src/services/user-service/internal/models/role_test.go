@@ -0,0 +1,20 @@
+package models
+
+import "testing"
+
+func TestParseRole(t *testing.T) {
+	for _, s := range []string{"user", "approved_user", "moderator", "admin"} {
+		role, err := ParseRole(s)
+		if err != nil {
+			t.Errorf("ParseRole(%q) returned error: %v", s, err)
+		}
+		if string(role) != s {
+			t.Errorf("ParseRole(%q) = %q", s, role)
+		}
+	}
+	for _, s := range []string{"", "Admin", "root"} {
+		if _, err := ParseRole(s); err == nil {
+			t.Errorf("ParseRole(%q) expected error, got nil", s)
+		}
+	}
+}
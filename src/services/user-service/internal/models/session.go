@@ -5,11 +5,37 @@ import (
 	"time"
 )
 
+// Session is one link of a refresh token chain. Rotating a refresh token
+// closes the session (RotatedAt) and opens a new one with the same FamilyID.
 type Session struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"userId"`
-	Token     string    `json:"token"`
-	CreatedAt time.Time `json:"createdAt"`
-	ExpiresAt time.Time `json:"expiresAt"`
-	IPAddress net.IP    `json:"ipAddress"`
+	ID               string     `json:"id"`
+	FamilyID         string     `json:"familyId"`
+	UserID           string     `json:"userId"`
+	Token            string     `json:"token"`
+	RefreshTokenHash string     `json:"-"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	ExpiresAt        time.Time  `json:"expiresAt"`
+	RefreshExpiresAt time.Time  `json:"refreshExpiresAt"`
+	RotatedAt        *time.Time `json:"rotatedAt,omitempty"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	IPAddress        net.IP     `json:"ipAddress"`
+}
+
+// SessionInfo describes one login of a user as a whole: ID is the session
+// family, which keeps its identity across refresh token rotations.
+type SessionInfo struct {
+	ID              string    `json:"id"`
+	CreatedAt       time.Time `json:"createdAt"`
+	LastRefreshedAt time.Time `json:"lastRefreshedAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	IPAddress       string    `json:"ipAddress"`
+	Current         bool      `json:"current"`
+}
+
+// TokenPair is returned on login and refresh. ExpiresIn is the access token
+// lifetime in seconds.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
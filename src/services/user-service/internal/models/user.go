@@ -3,14 +3,16 @@ package models
 import "time"
 
 type User struct {
-	ID           string    `json:"id"`
-	Login        string    `json:"login"`
-	Firstname    string    `json:"firstname"`
-	Surname      string    `json:"surname"`
-	Email        string    `json:"email"`
-	Phone        string    `json:"phone,omitempty"`
-	Bio          string    `json:"bio,omitempty"`
-	PasswordHash string    `json:"-"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID              string     `json:"id"`
+	Login           string     `json:"login"`
+	Firstname       string     `json:"firstname"`
+	Surname         string     `json:"surname"`
+	Email           string     `json:"email"`
+	Phone           string     `json:"phone,omitempty"`
+	Bio             string     `json:"bio,omitempty"`
+	PasswordHash    string     `json:"-"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	Roles           []Role     `json:"roles,omitempty"`
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type AuthAuditRepository interface {
+	Record(entry *models.AuthAuditEntry) error
+}
+
+type postgresAuthAuditRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresAuthAuditRepo(db *sql.DB) AuthAuditRepository {
+	return &postgresAuthAuditRepo{db: db}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (r *postgresAuthAuditRepo) Record(entry *models.AuthAuditEntry) error {
+	query := `
+	INSERT INTO auth_audit_log (event, login, user_id, actor_id, ip_address, created_at)
+	VALUES ($1, $2, $3, $4, $5, now())
+	RETURNING id, created_at`
+	return r.db.QueryRow(query, entry.Event, nullString(entry.Login), nullString(entry.UserID),
+		nullString(entry.ActorID), nullString(entry.IPAddress)).
+		Scan(&entry.ID, &entry.CreatedAt)
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type BlockRepository interface {
+	// Block, Unblock, Mute and Unmute report whether the lists changed.
+	// Block also removes the follows and close friend entries between the
+	// two users in both directions, in the same transaction, and enqueues
+	// the event unfollowEvent builds for each removed follow.
+	Block(blockerID, blockedID string, unfollowEvent func(followerID, followeeID string) (*models.OutboxMessage, error)) (bool, error)
+	Unblock(blockerID, blockedID string) (bool, error)
+	// IsBlocked reports whether either user blocked the other.
+	IsBlocked(userID, otherID string) (bool, error)
+	// ListBlocked and ListMuted return the newest entries first.
+	ListBlocked(userID string, limit, offset int) ([]models.FollowUser, error)
+	Mute(muterID, mutedID string) (bool, error)
+	Unmute(muterID, mutedID string) (bool, error)
+	ListMuted(userID string, limit, offset int) ([]models.FollowUser, error)
+	Restrictions(userID string) (*models.Restrictions, error)
+}
+
+type postgresBlockRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresBlockRepo(db *sql.DB) BlockRepository {
+	return &postgresBlockRepo{db: db}
+}
+
+func (r *postgresBlockRepo) Block(blockerID, blockedID string, unfollowEvent func(followerID, followeeID string) (*models.OutboxMessage, error)) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+	VALUES ($1, $2, now())
+	ON CONFLICT (blocker_id, blocked_id) DO NOTHING`
+	blocked, err := execAffected(tx, query, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+
+	query = `
+	DELETE FROM user_follows
+	WHERE (follower_id=$1 AND followee_id=$2) OR (follower_id=$2 AND followee_id=$1)
+	RETURNING follower_id, followee_id`
+	rows, err := tx.Query(query, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+	var unfollows [][2]string
+	for rows.Next() {
+		var f [2]string
+		if err := rows.Scan(&f[0], &f[1]); err != nil {
+			rows.Close()
+			return false, err
+		}
+		unfollows = append(unfollows, f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	for _, f := range unfollows {
+		event, err := unfollowEvent(f[0], f[1])
+		if err != nil {
+			return false, err
+		}
+		if err := enqueueEvent(tx, event); err != nil {
+			return false, err
+		}
+	}
+
+	query = `
+	DELETE FROM close_friends
+	WHERE (user_id=$1 AND friend_id=$2) OR (user_id=$2 AND friend_id=$1)`
+	if _, err := tx.Exec(query, blockerID, blockedID); err != nil {
+		return false, err
+	}
+	return blocked, tx.Commit()
+}
+
+func (r *postgresBlockRepo) Unblock(blockerID, blockedID string) (bool, error) {
+	query := `DELETE FROM user_blocks WHERE blocker_id=$1 AND blocked_id=$2`
+	return execAffected(r.db, query, blockerID, blockedID)
+}
+
+// blockBetween selects the blocks between the users $1 and $2, in either
+// direction.
+const blockBetween = `
+	SELECT 1 FROM user_blocks
+	WHERE (blocker_id=$1 AND blocked_id=$2) OR (blocker_id=$2 AND blocked_id=$1)`
+
+func (r *postgresBlockRepo) IsBlocked(userID, otherID string) (bool, error) {
+	query := `SELECT EXISTS (` + blockBetween + `)`
+	var blocked bool
+	err := r.db.QueryRow(query, userID, otherID).Scan(&blocked)
+	return blocked, err
+}
+
+func (r *postgresBlockRepo) ListBlocked(userID string, limit, offset int) ([]models.FollowUser, error) {
+	query := `
+	SELECT u.id, u.login, u.firstname, u.surname, b.created_at
+	FROM user_blocks b JOIN users u ON u.id = b.blocked_id
+	WHERE b.blocker_id=$1
+	ORDER BY b.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`
+	return queryFollowUsers(r.db, query, userID, limit, offset)
+}
+
+func (r *postgresBlockRepo) Mute(muterID, mutedID string) (bool, error) {
+	query := `
+	INSERT INTO user_mutes (muter_id, muted_id, created_at)
+	VALUES ($1, $2, now())
+	ON CONFLICT (muter_id, muted_id) DO NOTHING`
+	return execAffected(r.db, query, muterID, mutedID)
+}
+
+func (r *postgresBlockRepo) Unmute(muterID, mutedID string) (bool, error) {
+	query := `DELETE FROM user_mutes WHERE muter_id=$1 AND muted_id=$2`
+	return execAffected(r.db, query, muterID, mutedID)
+}
+
+func (r *postgresBlockRepo) ListMuted(userID string, limit, offset int) ([]models.FollowUser, error) {
+	query := `
+	SELECT u.id, u.login, u.firstname, u.surname, m.created_at
+	FROM user_mutes m JOIN users u ON u.id = m.muted_id
+	WHERE m.muter_id=$1
+	ORDER BY m.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`
+	return queryFollowUsers(r.db, query, userID, limit, offset)
+}
+
+func (r *postgresBlockRepo) Restrictions(userID string) (*models.Restrictions, error) {
+	blocked, err := queryIDs(r.db, `
+	SELECT blocked_id FROM user_blocks WHERE blocker_id=$1
+	UNION
+	SELECT blocker_id FROM user_blocks WHERE blocked_id=$1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	muted, err := queryIDs(r.db, `SELECT muted_id FROM user_mutes WHERE muter_id=$1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Restrictions{Blocked: blocked, Muted: muted}, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+var ErrVerificationTokenInvalid = errors.New("invalid or expired verification token")
+
+type EmailVerificationRepository interface {
+	// Create stores v and voids the user's earlier pending tokens.
+	Create(v *models.EmailVerification) error
+	// Consume marks the token used and returns it. An unknown, expired or
+	// already used token yields ErrVerificationTokenInvalid.
+	Consume(tokenHash string) (*models.EmailVerification, error)
+}
+
+type postgresEmailVerificationRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresEmailVerificationRepo(db *sql.DB) EmailVerificationRepository {
+	return &postgresEmailVerificationRepo{db: db}
+}
+
+func (r *postgresEmailVerificationRepo) Create(v *models.EmailVerification) error {
+	query := `
+	WITH voided AS (
+		UPDATE email_verification_tokens SET used_at=now()
+		WHERE user_id=$1 AND used_at IS NULL
+	)
+	INSERT INTO email_verification_tokens (user_id, email, token_hash, created_at, expires_at)
+	VALUES ($1, $2, $3, now(), $4)
+	RETURNING id, created_at`
+	return r.db.QueryRow(query, v.UserID, v.Email, v.TokenHash, v.ExpiresAt).
+		Scan(&v.ID, &v.CreatedAt)
+}
+
+func (r *postgresEmailVerificationRepo) Consume(tokenHash string) (*models.EmailVerification, error) {
+	query := `
+	UPDATE email_verification_tokens SET used_at=now()
+	WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now()
+	RETURNING id, user_id, email, token_hash, created_at, expires_at`
+	v := &models.EmailVerification{}
+	err := r.db.QueryRow(query, tokenHash).
+		Scan(&v.ID, &v.UserID, &v.Email, &v.TokenHash, &v.CreatedAt, &v.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
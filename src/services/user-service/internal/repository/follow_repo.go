@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type FollowRepository interface {
+	// Follow and Unfollow report whether the graph changed. Only a change
+	// enqueues event, in the same transaction. Follow and AddCloseFriend
+	// change nothing while either user blocked the other.
+	Follow(followerID, followeeID string, event *models.OutboxMessage) (bool, error)
+	Unfollow(followerID, followeeID string, event *models.OutboxMessage) (bool, error)
+	// ListFollowers and ListFollowing return the newest follows first.
+	ListFollowers(userID string, limit, offset int) ([]models.FollowUser, error)
+	ListFollowing(userID string, limit, offset int) ([]models.FollowUser, error)
+	Counts(userID string) (models.FollowCounts, error)
+	// FollowingOf returns every user userID follows.
+	FollowingOf(userID string) ([]models.Follow, error)
+	// FollowerIDs returns up to limit followers of userID.
+	FollowerIDs(userID string, limit int) ([]string, error)
+
+	// AddCloseFriend and RemoveCloseFriend report whether the list changed.
+	AddCloseFriend(userID, friendID string) (bool, error)
+	RemoveCloseFriend(userID, friendID string) (bool, error)
+	// ListCloseFriends returns the newest additions first.
+	ListCloseFriends(userID string, limit, offset int) ([]models.FollowUser, error)
+	// CloseFriendOf returns the users who have userID on their close friends
+	// list.
+	CloseFriendOf(userID string) ([]string, error)
+}
+
+type postgresFollowRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresFollowRepo(db *sql.DB) FollowRepository {
+	return &postgresFollowRepo{db: db}
+}
+
+func (r *postgresFollowRepo) Follow(followerID, followeeID string, event *models.OutboxMessage) (bool, error) {
+	query := `
+	INSERT INTO user_follows (follower_id, followee_id, created_at)
+	SELECT $1, $2, now()
+	WHERE NOT EXISTS (` + blockBetween + `)
+	ON CONFLICT (follower_id, followee_id) DO NOTHING`
+	return execWithEvent(r.db, event, query, followerID, followeeID)
+}
+
+func (r *postgresFollowRepo) Unfollow(followerID, followeeID string, event *models.OutboxMessage) (bool, error) {
+	query := `DELETE FROM user_follows WHERE follower_id=$1 AND followee_id=$2`
+	return execWithEvent(r.db, event, query, followerID, followeeID)
+}
+
+// execWithEvent runs query in a transaction and enqueues event in it if the
+// query changed any rows.
+func execWithEvent(db *sql.DB, event *models.OutboxMessage, query string, args ...any) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	changed, err := execAffected(tx, query, args...)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err := enqueueEvent(tx, event); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *postgresFollowRepo) ListFollowers(userID string, limit, offset int) ([]models.FollowUser, error) {
+	query := `
+	SELECT u.id, u.login, u.firstname, u.surname, f.created_at
+	FROM user_follows f JOIN users u ON u.id = f.follower_id
+	WHERE f.followee_id=$1
+	ORDER BY f.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`
+	return queryFollowUsers(r.db, query, userID, limit, offset)
+}
+
+func (r *postgresFollowRepo) ListFollowing(userID string, limit, offset int) ([]models.FollowUser, error) {
+	query := `
+	SELECT u.id, u.login, u.firstname, u.surname, f.created_at
+	FROM user_follows f JOIN users u ON u.id = f.followee_id
+	WHERE f.follower_id=$1
+	ORDER BY f.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`
+	return queryFollowUsers(r.db, query, userID, limit, offset)
+}
+
+// queryFollowUsers runs a query selecting id, login, firstname, surname and
+// the time of the relation, as used by all user lists of the graph.
+func queryFollowUsers(db *sql.DB, query string, args ...any) ([]models.FollowUser, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.FollowUser{}
+	for rows.Next() {
+		var u models.FollowUser
+		if err := rows.Scan(&u.ID, &u.Login, &u.Firstname, &u.Surname, &u.FollowedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (r *postgresFollowRepo) Counts(userID string) (models.FollowCounts, error) {
+	query := `
+	SELECT
+		(SELECT count(*) FROM user_follows WHERE followee_id=$1),
+		(SELECT count(*) FROM user_follows WHERE follower_id=$1)`
+	var counts models.FollowCounts
+	err := r.db.QueryRow(query, userID).Scan(&counts.Followers, &counts.Following)
+	return counts, err
+}
+
+func (r *postgresFollowRepo) FollowingOf(userID string) ([]models.Follow, error) {
+	rows, err := r.db.Query(`SELECT followee_id, created_at FROM user_follows WHERE follower_id=$1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	follows := []models.Follow{}
+	for rows.Next() {
+		var f models.Follow
+		if err := rows.Scan(&f.UserID, &f.FollowedAt); err != nil {
+			return nil, err
+		}
+		follows = append(follows, f)
+	}
+	return follows, rows.Err()
+}
+
+func (r *postgresFollowRepo) FollowerIDs(userID string, limit int) ([]string, error) {
+	return queryIDs(r.db, `SELECT follower_id FROM user_follows WHERE followee_id=$1 LIMIT $2`, userID, limit)
+}
+
+func (r *postgresFollowRepo) AddCloseFriend(userID, friendID string) (bool, error) {
+	query := `
+	INSERT INTO close_friends (user_id, friend_id, created_at)
+	SELECT $1, $2, now()
+	WHERE NOT EXISTS (` + blockBetween + `)
+	ON CONFLICT (user_id, friend_id) DO NOTHING`
+	return execAffected(r.db, query, userID, friendID)
+}
+
+func (r *postgresFollowRepo) RemoveCloseFriend(userID, friendID string) (bool, error) {
+	query := `DELETE FROM close_friends WHERE user_id=$1 AND friend_id=$2`
+	return execAffected(r.db, query, userID, friendID)
+}
+
+func (r *postgresFollowRepo) ListCloseFriends(userID string, limit, offset int) ([]models.FollowUser, error) {
+	query := `
+	SELECT u.id, u.login, u.firstname, u.surname, cf.created_at
+	FROM close_friends cf JOIN users u ON u.id = cf.friend_id
+	WHERE cf.user_id=$1
+	ORDER BY cf.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`
+	return queryFollowUsers(r.db, query, userID, limit, offset)
+}
+
+func (r *postgresFollowRepo) CloseFriendOf(userID string) ([]string, error) {
+	return queryIDs(r.db, `SELECT user_id FROM close_friends WHERE friend_id=$1`, userID)
+}
+
+func queryIDs(db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type LoginAttemptRepository interface {
+	// RecordFailure bumps the failure counter of key and returns it. A
+	// counter whose last failure is older than window starts over.
+	RecordFailure(kind models.AttemptKind, key string, window time.Duration) (int, error)
+	Lock(kind models.AttemptKind, key string, until time.Time) error
+	// LockedUntil returns the zero time if key is not locked.
+	LockedUntil(kind models.AttemptKind, key string) (time.Time, error)
+	Reset(kind models.AttemptKind, key string) error
+	// DeleteStale deletes unlocked counters whose last failure is older
+	// than before and returns how many were deleted.
+	DeleteStale(before time.Time) (int64, error)
+}
+
+type postgresLoginAttemptRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresLoginAttemptRepo(db *sql.DB) LoginAttemptRepository {
+	return &postgresLoginAttemptRepo{db: db}
+}
+
+func (r *postgresLoginAttemptRepo) RecordFailure(kind models.AttemptKind, key string, window time.Duration) (int, error) {
+	query := `
+	INSERT INTO login_attempts (kind, key, failures, last_failure_at)
+	VALUES ($1, $2, 1, now())
+	ON CONFLICT (kind, key) DO UPDATE SET
+		failures = CASE
+			WHEN login_attempts.last_failure_at < now() - make_interval(secs => $3) THEN 1
+			ELSE login_attempts.failures + 1
+		END,
+		last_failure_at = now()
+	RETURNING failures`
+	var failures int
+	err := r.db.QueryRow(query, kind, key, window.Seconds()).Scan(&failures)
+	return failures, err
+}
+
+func (r *postgresLoginAttemptRepo) Lock(kind models.AttemptKind, key string, until time.Time) error {
+	query := `UPDATE login_attempts SET locked_until=$3 WHERE kind=$1 AND key=$2`
+	_, err := r.db.Exec(query, kind, key, until)
+	return err
+}
+
+func (r *postgresLoginAttemptRepo) LockedUntil(kind models.AttemptKind, key string) (time.Time, error) {
+	query := `
+	SELECT locked_until FROM login_attempts
+	WHERE kind=$1 AND key=$2 AND locked_until > now()`
+	var until time.Time
+	err := r.db.QueryRow(query, kind, key).Scan(&until)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return until, err
+}
+
+func (r *postgresLoginAttemptRepo) Reset(kind models.AttemptKind, key string) error {
+	_, err := r.db.Exec(`DELETE FROM login_attempts WHERE kind=$1 AND key=$2`, kind, key)
+	return err
+}
+
+func (r *postgresLoginAttemptRepo) DeleteStale(before time.Time) (int64, error) {
+	query := `
+	DELETE FROM login_attempts
+	WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until <= now())`
+	res, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
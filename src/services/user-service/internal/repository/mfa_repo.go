@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+var (
+	ErrTOTPNotFound        = errors.New("TOTP is not set up")
+	ErrMFAChallengeInvalid = errors.New("invalid or expired MFA token")
+)
+
+type MFARepository interface {
+	GetTOTP(userID string) (*models.TOTP, error)
+	// SavePendingTOTP stores a new unconfirmed secret, replacing an earlier
+	// unconfirmed one. It reports false if the user already has TOTP enabled.
+	SavePendingTOTP(userID, secret string) (bool, error)
+	// EnableTOTP confirms the pending secret at step and replaces the user's
+	// recovery codes in one transaction. It reports false if there was no
+	// pending secret.
+	EnableTOTP(userID string, step int64, recoveryCodeHashes []string) (bool, error)
+	// UseTOTPStep records step as used. It reports false if step is not
+	// newer than the last used one, i.e. the code was already spent.
+	UseTOTPStep(userID string, step int64) (bool, error)
+	// UseRecoveryCode marks the code used and reports false if it is
+	// unknown or already used.
+	UseRecoveryCode(userID, codeHash string) (bool, error)
+	// DeleteTOTP removes the secret and the recovery codes.
+	DeleteTOTP(userID string) error
+
+	CreateChallenge(challenge *models.MFAChallenge) error
+	// GetChallenge returns an unused, unexpired challenge or
+	// ErrMFAChallengeInvalid.
+	GetChallenge(tokenHash string) (*models.MFAChallenge, error)
+	// FailChallenge counts a wrong code and returns the attempts so far.
+	FailChallenge(id string) (int, error)
+	// ConsumeChallenge marks the challenge used. It reports false if it was
+	// used concurrently.
+	ConsumeChallenge(id string) (bool, error)
+}
+
+type postgresMFARepo struct {
+	db *sql.DB
+}
+
+func NewPostgresMFARepo(db *sql.DB) MFARepository {
+	return &postgresMFARepo{db: db}
+}
+
+func (r *postgresMFARepo) GetTOTP(userID string) (*models.TOTP, error) {
+	query := `SELECT user_id, secret, created_at, enabled_at, last_used_step FROM user_totp WHERE user_id=$1`
+	t := &models.TOTP{}
+	var enabledAt sql.NullTime
+	err := r.db.QueryRow(query, userID).Scan(&t.UserID, &t.Secret, &t.CreatedAt, &enabledAt, &t.LastUsedStep)
+	if err == sql.ErrNoRows {
+		return nil, ErrTOTPNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enabledAt.Valid {
+		t.EnabledAt = &enabledAt.Time
+	}
+	return t, nil
+}
+
+func (r *postgresMFARepo) SavePendingTOTP(userID, secret string) (bool, error) {
+	query := `
+	INSERT INTO user_totp (user_id, secret, created_at)
+	VALUES ($1, $2, now())
+	ON CONFLICT (user_id) DO UPDATE SET secret=EXCLUDED.secret, created_at=now(), last_used_step=0
+	WHERE user_totp.enabled_at IS NULL`
+	return execAffected(r.db, query, userID, secret)
+}
+
+func (r *postgresMFARepo) EnableTOTP(userID string, step int64, recoveryCodeHashes []string) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	UPDATE user_totp SET enabled_at=now(), last_used_step=$2
+	WHERE user_id=$1 AND enabled_at IS NULL`
+	enabled, err := execAffected(tx, query, userID, step)
+	if err != nil || !enabled {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return false, err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec(`INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+func (r *postgresMFARepo) UseTOTPStep(userID string, step int64) (bool, error) {
+	query := `
+	UPDATE user_totp SET last_used_step=$2
+	WHERE user_id=$1 AND enabled_at IS NOT NULL AND last_used_step < $2`
+	return execAffected(r.db, query, userID, step)
+}
+
+func (r *postgresMFARepo) UseRecoveryCode(userID, codeHash string) (bool, error) {
+	query := `
+	UPDATE mfa_recovery_codes SET used_at=now()
+	WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`
+	return execAffected(r.db, query, userID, codeHash)
+}
+
+func (r *postgresMFARepo) DeleteTOTP(userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM user_totp WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *postgresMFARepo) CreateChallenge(challenge *models.MFAChallenge) error {
+	query := `
+	INSERT INTO mfa_challenges (user_id, token_hash, created_at, expires_at)
+	VALUES ($1, $2, now(), $3)
+	RETURNING id, created_at`
+	return r.db.QueryRow(query, challenge.UserID, challenge.TokenHash, challenge.ExpiresAt).
+		Scan(&challenge.ID, &challenge.CreatedAt)
+}
+
+func (r *postgresMFARepo) GetChallenge(tokenHash string) (*models.MFAChallenge, error) {
+	query := `
+	SELECT id, user_id, token_hash, attempts, created_at, expires_at FROM mfa_challenges
+	WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now()`
+	c := &models.MFAChallenge{}
+	err := r.db.QueryRow(query, tokenHash).Scan(&c.ID, &c.UserID, &c.TokenHash, &c.Attempts, &c.CreatedAt, &c.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrMFAChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *postgresMFARepo) FailChallenge(id string) (int, error) {
+	query := `UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id=$1 RETURNING attempts`
+	var attempts int
+	err := r.db.QueryRow(query, id).Scan(&attempts)
+	return attempts, err
+}
+
+func (r *postgresMFARepo) ConsumeChallenge(id string) (bool, error) {
+	query := `UPDATE mfa_challenges SET used_at=now() WHERE id=$1 AND used_at IS NULL`
+	return execAffected(r.db, query, id)
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// execAffected runs query and reports whether it changed any row.
+func execAffected(db execer, query string, args ...any) (bool, error) {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
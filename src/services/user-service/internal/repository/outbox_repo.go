@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/zahartd/social-network/src/events"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type postgresOutboxRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepo(db *sql.DB) events.OutboxStore {
+	return &postgresOutboxRepo{db: db}
+}
+
+// enqueueEvent stores msg for the outbox relay. Repositories call it within
+// the transaction of the change msg describes, so the event is published if
+// and only if the change commits.
+func enqueueEvent(db execer, msg *models.OutboxMessage) error {
+	query := `
+	INSERT INTO outbox (event_id, topic, msg_key, payload, content_type, created_at)
+	VALUES ($1, $2, $3, $4, $5, now())`
+	_, err := db.Exec(query, msg.EventID, msg.Topic, msg.Key, msg.Payload, msg.ContentType)
+	if err != nil {
+		return fmt.Errorf("could not enqueue event: %w", err)
+	}
+	return nil
+}
+
+// PublishPending holds the row locks while publishing, so concurrent relays
+// skip each other's batches. A crash after publish but before commit leaves
+// the rows unsent and they are published again: delivery is at least once.
+func (r *postgresOutboxRepo) PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, msgs []events.OutboxMessage) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT id, event_id, topic, msg_key, payload, content_type
+	FROM outbox
+	WHERE sent_at IS NULL
+	ORDER BY id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("could not fetch pending outbox messages: %w", err)
+	}
+	var msgs []events.OutboxMessage
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var msg events.OutboxMessage
+		if err := rows.Scan(&id, &msg.EventID, &msg.Topic, &msg.Key, &msg.Payload, &msg.ContentType); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("could not fetch pending outbox messages: %w", err)
+		}
+		msgs = append(msgs, msg)
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("could not fetch pending outbox messages: %w", err)
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(ctx, msgs); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE outbox SET sent_at=now() WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return 0, fmt.Errorf("could not mark outbox messages sent: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit outbox batch: %w", err)
+	}
+	return len(msgs), nil
+}
+
+func (r *postgresOutboxRepo) DeleteSent(ctx context.Context, sentBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE sent_at < $1`, sentBefore)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete sent outbox messages: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+var ErrResetTokenInvalid = errors.New("invalid or expired reset token")
+
+type PasswordResetRepository interface {
+	Create(reset *models.PasswordReset) error
+	// Consume marks the token used and sets passwordHash as its user's
+	// password in one transaction, returning the user. Any other pending
+	// tokens of that user are used up too. An unknown, expired or already
+	// used token yields ErrResetTokenInvalid.
+	Consume(tokenHash, passwordHash string) (string, error)
+}
+
+type postgresPasswordResetRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresPasswordResetRepo(db *sql.DB) PasswordResetRepository {
+	return &postgresPasswordResetRepo{db: db}
+}
+
+func (r *postgresPasswordResetRepo) Create(reset *models.PasswordReset) error {
+	query := `
+	INSERT INTO password_reset_tokens (user_id, token_hash, created_at, expires_at)
+	VALUES ($1, $2, now(), $3)
+	RETURNING id, created_at`
+	return r.db.QueryRow(query, reset.UserID, reset.TokenHash, reset.ExpiresAt).
+		Scan(&reset.ID, &reset.CreatedAt)
+}
+
+func (r *postgresPasswordResetRepo) Consume(tokenHash, passwordHash string) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	query := `
+	WITH t AS (
+		SELECT user_id FROM password_reset_tokens
+		WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now()
+	)
+	UPDATE password_reset_tokens SET used_at=now()
+	WHERE user_id = (SELECT user_id FROM t) AND used_at IS NULL
+	RETURNING user_id`
+	var userID string
+	err = tx.QueryRow(query, tokenHash).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", ErrResetTokenInvalid
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(`UPDATE users SET password_hash=$1, updated_at=now() WHERE id=$2`, passwordHash, userID); err != nil {
+		return "", err
+	}
+	return userID, tx.Commit()
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+type RoleRepository interface {
+	GetRoles(userID string) ([]models.Role, error)
+	ListAssignments(userID string) ([]models.RoleAssignment, error)
+	// Grant and Revoke report whether the user's roles changed. An empty
+	// assignedBy marks a role assigned by the system.
+	Grant(userID string, role models.Role, assignedBy string) (bool, error)
+	Revoke(userID string, role models.Role) (bool, error)
+}
+
+type postgresRoleRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresRoleRepo(db *sql.DB) RoleRepository {
+	return &postgresRoleRepo{db: db}
+}
+
+func (r *postgresRoleRepo) GetRoles(userID string) ([]models.Role, error) {
+	rows, err := r.db.Query(`SELECT role FROM user_roles WHERE user_id=$1 ORDER BY role`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *postgresRoleRepo) ListAssignments(userID string) ([]models.RoleAssignment, error) {
+	query := `
+	SELECT role, assign_user_id, assigned_at
+	FROM user_roles WHERE user_id=$1 ORDER BY role`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []models.RoleAssignment{}
+	for rows.Next() {
+		var a models.RoleAssignment
+		var assignedBy sql.NullString
+		if err := rows.Scan(&a.Role, &assignedBy, &a.AssignedAt); err != nil {
+			return nil, err
+		}
+		if assignedBy.Valid {
+			a.AssignedBy = &assignedBy.String
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, rows.Err()
+}
+
+func (r *postgresRoleRepo) Grant(userID string, role models.Role, assignedBy string) (bool, error) {
+	query := `
+	INSERT INTO user_roles (user_id, role, assign_user_id, assigned_at)
+	VALUES ($1, $2, $3, now())
+	ON CONFLICT (user_id, role) DO NOTHING`
+	result, err := r.db.Exec(query, userID, role, sql.NullString{String: assignedBy, Valid: assignedBy != ""})
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *postgresRoleRepo) Revoke(userID string, role models.Role) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM user_roles WHERE user_id=$1 AND role=$2`, userID, role)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
@@ -8,9 +8,27 @@ import (
 	"github.com/zahartd/social-network/src/services/user-service/internal/models"
 )
 
+var ErrSessionNotFound = errors.New("session not found")
+
 type SessionRepository interface {
 	CreateSession(session *models.Session) error
 	GetSessionByToken(token string) (*models.Session, error)
+	// GetSessionByRefreshHash also returns rotated and revoked sessions, so
+	// the caller can tell a reused refresh token from an unknown one.
+	GetSessionByRefreshHash(hash string) (*models.Session, error)
+	// RotateSession closes the session oldID and stores next in one
+	// transaction. It reports false if oldID was already rotated or revoked.
+	RotateSession(oldID string, next *models.Session) (bool, error)
+	RevokeFamily(familyID string) error
+	// ListActiveSessions returns the user's live session families, most
+	// recently refreshed first.
+	ListActiveSessions(userID string) ([]models.SessionInfo, error)
+	RevokeUserSession(userID, familyID string) (bool, error)
+	// RevokeOtherSessions revokes every session family of the user except
+	// keepFamilyID and returns how many were revoked.
+	RevokeOtherSessions(userID, keepFamilyID string) (int64, error)
+	RevokeAllSessions(userID string) error
+	// DeleteSessionByToken deletes the whole session family of the token.
 	DeleteSessionByToken(token string) error
 }
 
@@ -22,36 +40,160 @@ func NewPostgresSessionRepo(db *sql.DB) SessionRepository {
 	return &postgresSessionRepo{db: db}
 }
 
-func (r *postgresSessionRepo) CreateSession(session *models.Session) error {
-	query := `
-	INSERT INTO user_sessions (user_id, token, created_at, expires_at, ip_address)
-	VALUES ($1, $2, now(), $3, $4)
+const sessionColumns = `id, family_id, user_id, token, refresh_token_hash, created_at, expires_at,
+	refresh_expires_at, rotated_at, revoked_at, ip_address`
+
+func scanSession(row *sql.Row) (*models.Session, error) {
+	session := &models.Session{}
+	var refreshHash, ipStr sql.NullString
+	var refreshExpiresAt, rotatedAt, revokedAt sql.NullTime
+	err := row.Scan(&session.ID, &session.FamilyID, &session.UserID, &session.Token, &refreshHash,
+		&session.CreatedAt, &session.ExpiresAt, &refreshExpiresAt, &rotatedAt, &revokedAt, &ipStr)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	session.RefreshTokenHash = refreshHash.String
+	session.RefreshExpiresAt = refreshExpiresAt.Time
+	if rotatedAt.Valid {
+		session.RotatedAt = &rotatedAt.Time
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	session.IPAddress = net.ParseIP(ipStr.String)
+	return session, nil
+}
+
+const insertSessionQuery = `
+	INSERT INTO user_sessions (family_id, user_id, token, refresh_token_hash, created_at, expires_at, refresh_expires_at, ip_address)
+	VALUES ($1, $2, $3, $4, now(), $5, $6, $7)
 	RETURNING id, created_at`
-	ipStr := session.IPAddress.String()
-	return r.db.QueryRow(query, session.UserID, session.Token, session.ExpiresAt, ipStr).
+
+func insertSessionArgs(session *models.Session) []any {
+	return []any{session.FamilyID, session.UserID, session.Token, session.RefreshTokenHash,
+		session.ExpiresAt, session.RefreshExpiresAt, session.IPAddress.String()}
+}
+
+func (r *postgresSessionRepo) CreateSession(session *models.Session) error {
+	return r.db.QueryRow(insertSessionQuery, insertSessionArgs(session)...).
 		Scan(&session.ID, &session.CreatedAt)
 }
 
 func (r *postgresSessionRepo) GetSessionByToken(token string) (*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM user_sessions WHERE token=$1 AND revoked_at IS NULL`
+	return scanSession(r.db.QueryRow(query, token))
+}
+
+func (r *postgresSessionRepo) GetSessionByRefreshHash(hash string) (*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM user_sessions WHERE refresh_token_hash=$1`
+	return scanSession(r.db.QueryRow(query, hash))
+}
+
+func (r *postgresSessionRepo) RotateSession(oldID string, next *models.Session) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
 	query := `
-	SELECT id, user_id, token, created_at, expires_at, ip_address
-	FROM user_sessions WHERE token=$1`
-	row := r.db.QueryRow(query, token)
-	session := &models.Session{}
-	var ipStr string
-	err := row.Scan(&session.ID, &session.UserID, &session.Token, &session.CreatedAt, &session.ExpiresAt, &ipStr)
+	UPDATE user_sessions SET rotated_at=now()
+	WHERE id=$1 AND rotated_at IS NULL AND revoked_at IS NULL`
+	result, err := tx.Exec(query, oldID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	if err := tx.QueryRow(insertSessionQuery, insertSessionArgs(next)...).Scan(&next.ID, &next.CreatedAt); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *postgresSessionRepo) RevokeFamily(familyID string) error {
+	query := `UPDATE user_sessions SET revoked_at=now() WHERE family_id=$1 AND revoked_at IS NULL`
+	_, err := r.db.Exec(query, familyID)
+	return err
+}
+
+func (r *postgresSessionRepo) ListActiveSessions(userID string) ([]models.SessionInfo, error) {
+	query := `
+	SELECT s.family_id, f.started_at, s.created_at, s.refresh_expires_at, s.ip_address
+	FROM user_sessions s
+	JOIN (
+		SELECT family_id, MIN(created_at) AS started_at
+		FROM user_sessions WHERE user_id=$1
+		GROUP BY family_id
+	) f ON f.family_id = s.family_id
+	WHERE s.user_id=$1 AND s.rotated_at IS NULL AND s.revoked_at IS NULL
+		AND s.refresh_expires_at > now()
+	ORDER BY s.created_at DESC`
+	rows, err := r.db.Query(query, userID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("session not found")
-		}
 		return nil, err
 	}
-	session.IPAddress = net.ParseIP(ipStr)
-	return session, nil
+	defer rows.Close()
+
+	sessions := []models.SessionInfo{}
+	for rows.Next() {
+		var s models.SessionInfo
+		var ipStr sql.NullString
+		if err := rows.Scan(&s.ID, &s.CreatedAt, &s.LastRefreshedAt, &s.ExpiresAt, &ipStr); err != nil {
+			return nil, err
+		}
+		s.IPAddress = ipStr.String
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *postgresSessionRepo) RevokeUserSession(userID, familyID string) (bool, error) {
+	query := `
+	UPDATE user_sessions SET revoked_at=now()
+	WHERE user_id=$1 AND family_id=$2 AND revoked_at IS NULL`
+	result, err := r.db.Exec(query, userID, familyID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *postgresSessionRepo) RevokeOtherSessions(userID, keepFamilyID string) (int64, error) {
+	query := `
+	WITH revoked AS (
+		UPDATE user_sessions SET revoked_at=now()
+		WHERE user_id=$1 AND family_id<>$2 AND revoked_at IS NULL
+		RETURNING family_id
+	)
+	SELECT COUNT(DISTINCT family_id) FROM revoked`
+	var count int64
+	err := r.db.QueryRow(query, userID, keepFamilyID).Scan(&count)
+	return count, err
+}
+
+func (r *postgresSessionRepo) RevokeAllSessions(userID string) error {
+	query := `UPDATE user_sessions SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL`
+	_, err := r.db.Exec(query, userID)
+	return err
 }
 
 func (r *postgresSessionRepo) DeleteSessionByToken(token string) error {
-	query := `DELETE FROM user_sessions WHERE token=$1`
+	query := `
+	DELETE FROM user_sessions
+	WHERE family_id = (SELECT family_id FROM user_sessions WHERE token=$1)`
 	result, err := r.db.Exec(query, token)
 	if err != nil {
 		return err
@@ -61,7 +203,7 @@ func (r *postgresSessionRepo) DeleteSessionByToken(token string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("session not found")
+		return ErrSessionNotFound
 	}
 	return nil
 }
@@ -8,10 +8,22 @@ import (
 )
 
 type UserRepository interface {
-	Create(user *models.User) error
+	// Create inserts the user with its ID and enqueues event in the same
+	// transaction.
+	Create(user *models.User, event *models.OutboxMessage) error
 	GetByLogin(login string) (*models.User, error)
 	GetByID(id string) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	// Update resets the email verification when the email changes.
 	Update(user *models.User) error
+	// ChangePassword sets passwordHash as the user's password, revokes every
+	// session family but keepFamilyID and uses up the pending reset tokens in
+	// one transaction. It returns how many session families were revoked.
+	ChangePassword(id, passwordHash, keepFamilyID string) (int64, error)
+	// MarkEmailVerified marks email as verified for the user. It reports
+	// false, changing nothing, if the user has since switched to another
+	// address.
+	MarkEmailVerified(id, email string) (bool, error)
 	Delete(id string) error
 }
 
@@ -23,23 +35,42 @@ func NewPostgresUserRepo(db *sql.DB) UserRepository {
 	return &postgresUserRepo{db: db}
 }
 
-func (r *postgresUserRepo) Create(user *models.User) error {
+// Create inserts the user together with the base user role.
+func (r *postgresUserRepo) Create(user *models.User, event *models.OutboxMessage) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-	INSERT INTO
-	users (login, firstname, surname, email, phone, bio, password_hash, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
-	RETURNING id, created_at, updated_at`
-	return r.db.QueryRow(query, user.Login, user.Firstname, user.Surname, user.Email, user.Phone, user.Bio, user.PasswordHash).
-		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	WITH u AS (
+		INSERT INTO
+		users (id, login, firstname, surname, email, phone, bio, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
+		RETURNING id, created_at, updated_at
+	), r AS (
+		INSERT INTO user_roles (user_id, role) SELECT id, 'user' FROM u
+	)
+	SELECT created_at, updated_at FROM u`
+	err = tx.QueryRow(query, user.ID, user.Login, user.Firstname, user.Surname, user.Email, user.Phone, user.Bio, user.PasswordHash).
+		Scan(&user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := enqueueEvent(tx, event); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *postgresUserRepo) GetByLogin(login string) (*models.User, error) {
 	query := `
-	SELECT id, login, firstname, surname, email, phone, bio, password_hash, created_at, updated_at
+	SELECT id, login, firstname, surname, email, phone, bio, password_hash, email_verified_at, created_at, updated_at
 	FROM users WHERE login=$1`
 	row := r.db.QueryRow(query, login)
 	user := &models.User{}
-	err := row.Scan(&user.ID, &user.Login, &user.Firstname, &user.Surname, &user.Email, &user.Phone, &user.Bio, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+	err := row.Scan(&user.ID, &user.Login, &user.Firstname, &user.Surname, &user.Email, &user.Phone, &user.Bio, &user.PasswordHash, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
@@ -51,11 +82,27 @@ func (r *postgresUserRepo) GetByLogin(login string) (*models.User, error) {
 
 func (r *postgresUserRepo) GetByID(id string) (*models.User, error) {
 	query := `
-	SELECT id, login, firstname, surname, email, phone, bio, password_hash, created_at, updated_at
+	SELECT id, login, firstname, surname, email, phone, bio, password_hash, email_verified_at, created_at, updated_at
 	FROM users WHERE id=$1`
 	row := r.db.QueryRow(query, id)
 	user := &models.User{}
-	err := row.Scan(&user.ID, &user.Login, &user.Firstname, &user.Surname, &user.Email, &user.Phone, &user.Bio, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+	err := row.Scan(&user.ID, &user.Login, &user.Firstname, &user.Surname, &user.Email, &user.Phone, &user.Bio, &user.PasswordHash, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *postgresUserRepo) GetByEmail(email string) (*models.User, error) {
+	query := `
+	SELECT id, login, firstname, surname, email, phone, bio, password_hash, email_verified_at, created_at, updated_at
+	FROM users WHERE email=$1`
+	row := r.db.QueryRow(query, email)
+	user := &models.User{}
+	err := row.Scan(&user.ID, &user.Login, &user.Firstname, &user.Surname, &user.Email, &user.Phone, &user.Bio, &user.PasswordHash, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
@@ -67,19 +114,64 @@ func (r *postgresUserRepo) GetByID(id string) (*models.User, error) {
 
 func (r *postgresUserRepo) Update(user *models.User) error {
 	query := `
-	UPDATE users SET email=$1, firstname=$2, surname=$3, phone=$4, bio=$5, updated_at=now() WHERE id=$6`
-	result, err := r.db.Exec(query, user.Email, user.Firstname, user.Surname, user.Phone, user.Bio, user.ID)
+	UPDATE users SET email=$1, firstname=$2, surname=$3, phone=$4, bio=$5,
+		email_verified_at = CASE WHEN email=$1 THEN email_verified_at END,
+		updated_at=now()
+	WHERE id=$6
+	RETURNING email_verified_at, updated_at`
+	err := r.db.QueryRow(query, user.Email, user.Firstname, user.Surname, user.Phone, user.Bio, user.ID).
+		Scan(&user.EmailVerifiedAt, &user.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("user not found")
+	}
+	return err
+}
+
+func (r *postgresUserRepo) MarkEmailVerified(id, email string) (bool, error) {
+	query := `
+	UPDATE users SET email_verified_at=COALESCE(email_verified_at, now())
+	WHERE id=$1 AND email=$2`
+	result, err := r.db.Exec(query, id, email)
 	if err != nil {
-		return err
+		return false, err
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
 	}
-	if rowsAffected == 0 {
-		return errors.New("user not found")
+	return rowsAffected > 0, nil
+}
+
+func (r *postgresUserRepo) ChangePassword(id, passwordHash, keepFamilyID string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
 	}
-	return nil
+	defer tx.Rollback()
+
+	updated, err := execAffected(tx, `UPDATE users SET password_hash=$1, updated_at=now() WHERE id=$2`, passwordHash, id)
+	if err != nil {
+		return 0, err
+	}
+	if !updated {
+		return 0, errors.New("user not found")
+	}
+	query := `
+	WITH revoked AS (
+		UPDATE user_sessions SET revoked_at=now()
+		WHERE user_id=$1 AND family_id<>$2 AND revoked_at IS NULL
+		RETURNING family_id
+	)
+	SELECT COUNT(DISTINCT family_id) FROM revoked`
+	var revoked int64
+	if err := tx.QueryRow(query, id, keepFamilyID).Scan(&revoked); err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(`UPDATE password_reset_tokens SET used_at=now() WHERE user_id=$1 AND used_at IS NULL`, id)
+	if err != nil {
+		return 0, err
+	}
+	return revoked, tx.Commit()
 }
 
 func (r *postgresUserRepo) Delete(id string) error {
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+var (
+	ErrSelfBlock = errors.New("users cannot block themselves")
+	ErrSelfMute  = errors.New("users cannot mute themselves")
+	// ErrBlocked is returned for relations between users when either of them
+	// blocked the other.
+	ErrBlocked = errors.New("this user is not available")
+)
+
+// Block stops the two users from seeing and interacting with each other.
+// Existing follows and close friend entries between them are removed in
+// both directions along with the block; the unfollows are published as
+// usual.
+func (s *userService) Block(ctx *gin.Context, userID, targetID string) error {
+	if userID == targetID {
+		return ErrSelfBlock
+	}
+	_, err := s.blockRepo.Block(userID, targetID, func(followerID, followeeID string) (*models.OutboxMessage, error) {
+		return s.newEvent(topicUserRelations, followerID, &eventspb.UserUnfollowed{FollowerId: followerID, FolloweeId: followeeID}, s.now())
+	})
+	return err
+}
+
+// Unblock does not restore the follows removed by Block.
+func (s *userService) Unblock(ctx *gin.Context, userID, targetID string) error {
+	_, err := s.blockRepo.Unblock(userID, targetID)
+	return err
+}
+
+func (s *userService) ListBlocked(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error) {
+	return s.blockRepo.ListBlocked(userID, pageSize, (page-1)*pageSize)
+}
+
+// Mute hides the target from the listings of userID only; the target is
+// not affected otherwise.
+func (s *userService) Mute(ctx *gin.Context, userID, targetID string) error {
+	if userID == targetID {
+		return ErrSelfMute
+	}
+	_, err := s.blockRepo.Mute(userID, targetID)
+	return err
+}
+
+func (s *userService) Unmute(ctx *gin.Context, userID, targetID string) error {
+	_, err := s.blockRepo.Unmute(userID, targetID)
+	return err
+}
+
+func (s *userService) ListMuted(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error) {
+	return s.blockRepo.ListMuted(userID, pageSize, (page-1)*pageSize)
+}
+
+// Restrictions serves the block and mute checks of post-service.
+func (s *userService) Restrictions(ctx *gin.Context, userID string) (*models.Restrictions, error) {
+	return s.blockRepo.Restrictions(userID)
+}
+
+// checkNotBlocked returns ErrBlocked if either user blocked the other.
+func (s *userService) checkNotBlocked(userID, otherID string) error {
+	blocked, err := s.blockRepo.IsBlocked(userID, otherID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
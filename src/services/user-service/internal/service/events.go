@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zahartd/social-network/src/events"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+const (
+	topicUserRegistrations = "user-registrations"
+	topicUserRelations     = "user-relations"
+)
+
+// newEvent wraps payload in an envelope for the outbox. The repositories
+// store it in the transaction of the change it describes and the outbox
+// relay publishes it, so a Kafka outage delays events without failing
+// requests.
+func (s *userService) newEvent(topic, key string, payload proto.Message, occurredAt time.Time) (*models.OutboxMessage, error) {
+	env, err := events.NewEnvelope(payload, occurredAt)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.codec.Encode(env)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode %s event: %w", env.GetEventType(), err)
+	}
+	return &models.OutboxMessage{
+		EventID:     env.GetEventId(),
+		Topic:       topic,
+		Key:         key,
+		Payload:     value,
+		ContentType: s.codec.ContentType(),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+)
+
+var (
+	ErrSelfFollow      = errors.New("users cannot follow themselves")
+	ErrSelfCloseFriend = errors.New("users cannot add themselves to close friends")
+)
+
+// Follow makes followerID follow followeeID unless either blocked the
+// other. Following again is a no-op and publishes nothing. Events of the
+// graph are keyed by the acting user, so their changes stay in order.
+func (s *userService) Follow(ctx *gin.Context, followerID, followeeID string) error {
+	if followerID == followeeID {
+		return ErrSelfFollow
+	}
+	if err := s.checkNotBlocked(followerID, followeeID); err != nil {
+		return err
+	}
+	event, err := s.newEvent(topicUserRelations, followerID, &eventspb.UserFollowed{FollowerId: followerID, FolloweeId: followeeID}, s.now())
+	if err != nil {
+		return err
+	}
+	_, err = s.followRepo.Follow(followerID, followeeID, event)
+	return err
+}
+
+func (s *userService) Unfollow(ctx *gin.Context, followerID, followeeID string) error {
+	event, err := s.newEvent(topicUserRelations, followerID, &eventspb.UserUnfollowed{FollowerId: followerID, FolloweeId: followeeID}, s.now())
+	if err != nil {
+		return err
+	}
+	_, err = s.followRepo.Unfollow(followerID, followeeID, event)
+	return err
+}
+
+func (s *userService) ListFollowers(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error) {
+	return s.followRepo.ListFollowers(userID, pageSize, (page-1)*pageSize)
+}
+
+func (s *userService) ListFollowing(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error) {
+	return s.followRepo.ListFollowing(userID, pageSize, (page-1)*pageSize)
+}
+
+// FollowingOf and FollowerIDs serve the internal lookups of post-service.
+func (s *userService) FollowingOf(ctx *gin.Context, userID string) ([]models.Follow, error) {
+	return s.followRepo.FollowingOf(userID)
+}
+
+func (s *userService) FollowerIDs(ctx *gin.Context, userID string, limit int) ([]string, error) {
+	return s.followRepo.FollowerIDs(userID, limit)
+}
+
+// AddCloseFriend lets friendID see the posts userID shares with close
+// friends. The list is private to userID, so changes publish no events.
+func (s *userService) AddCloseFriend(ctx *gin.Context, userID, friendID string) error {
+	if userID == friendID {
+		return ErrSelfCloseFriend
+	}
+	if err := s.checkNotBlocked(userID, friendID); err != nil {
+		return err
+	}
+	_, err := s.followRepo.AddCloseFriend(userID, friendID)
+	return err
+}
+
+func (s *userService) RemoveCloseFriend(ctx *gin.Context, userID, friendID string) error {
+	_, err := s.followRepo.RemoveCloseFriend(userID, friendID)
+	return err
+}
+
+func (s *userService) ListCloseFriends(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error) {
+	return s.followRepo.ListCloseFriends(userID, pageSize, (page-1)*pageSize)
+}
+
+// CloseFriendOf serves the visibility checks of post-service.
+func (s *userService) CloseFriendOf(ctx *gin.Context, userID string) ([]string, error) {
+	return s.followRepo.CloseFriendOf(userID)
+}
+
+func (s *userService) FollowCounts(ctx *gin.Context, userID string) (models.FollowCounts, error) {
+	return s.followRepo.Counts(userID)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
+)
+
+// lockoutPolicy locks a login or IP once it reaches threshold failures,
+// doubling the lock with each further failure up to max. Failures older than
+// window are forgotten.
+type lockoutPolicy struct {
+	threshold int
+	base      time.Duration
+	max       time.Duration
+	window    time.Duration
+}
+
+// Logins are locked early; IPs get more room since many users may share
+// one behind NAT.
+var (
+	loginLockout = lockoutPolicy{threshold: 5, base: time.Minute, max: time.Hour, window: 24 * time.Hour}
+	ipLockout    = lockoutPolicy{threshold: 30, base: time.Minute, max: time.Hour, window: 15 * time.Minute}
+)
+
+// Password reset requests are counted whether they succeed or not: each
+// one mails a token or tries to guess one. IPs get the login policy.
+var resetEmailLimit = lockoutPolicy{threshold: 3, base: time.Hour, max: time.Hour, window: time.Hour}
+
+// attemptRetention outlives the longest window above, so pruning never
+// forgets a failure that would still count.
+const (
+	attemptRetention = 24 * time.Hour
+	pruneInterval    = time.Hour
+)
+
+// lockFor returns how long to lock after failures consecutive failures, or
+// zero if not at all.
+func (p lockoutPolicy) lockFor(failures int) time.Duration {
+	if failures < p.threshold {
+		return 0
+	}
+	d := p.base
+	for i := p.threshold; i < failures && d < p.max; i++ {
+		d *= 2
+	}
+	return min(d, p.max)
+}
+
+var ErrInvalidCredentials = errors.New("invalid login or password")
+
+// LockedError is returned while a login or IP is locked out, or while
+// password resets are throttled.
+type LockedError struct {
+	Until time.Time
+	msg   string
+}
+
+func (e *LockedError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return "too many failed login attempts, try again later"
+}
+
+const msgTooManyResets = "too many password reset requests, try again later"
+
+// dummyPasswordHash is compared against for unknown logins, so they take as
+// long to reject as wrong passwords.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+type attemptKey struct {
+	kind   models.AttemptKind
+	key    string
+	policy lockoutPolicy
+}
+
+func attemptKeys(login, ip string) []attemptKey {
+	return []attemptKey{
+		{models.AttemptByLogin, login, loginLockout},
+		{models.AttemptByIP, ip, ipLockout},
+	}
+}
+
+func (s *userService) checkLockout(login, ip string) error {
+	until, err := s.lockedUntil(attemptKeys(login, ip))
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		return &LockedError{Until: until}
+	}
+	return nil
+}
+
+// lockedUntil returns the latest lock of keys, or the zero time.
+func (s *userService) lockedUntil(keys []attemptKey) (time.Time, error) {
+	var until time.Time
+	for _, k := range keys {
+		lockedUntil, err := s.attemptRepo.LockedUntil(k.kind, k.key)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if lockedUntil.After(until) {
+			until = lockedUntil
+		}
+	}
+	return until, nil
+}
+
+// recordFailure counts a failed login or second factor for both the login
+// and the IP, and locks whichever crossed its threshold.
+func (s *userService) recordFailure(event models.AuthAuditEvent, login, ip, userID string) {
+	s.countAttempts(attemptKeys(login, ip))
+	s.audit(&models.AuthAuditEntry{Event: event, Login: login, UserID: userID, IPAddress: ip})
+}
+
+// clearFailures forgets the failures of login and ip after a successful
+// login.
+func (s *userService) clearFailures(login, ip string) {
+	for _, k := range attemptKeys(login, ip) {
+		if err := s.attemptRepo.Reset(k.kind, k.key); err != nil {
+			log.Printf("failed to reset login failures of %s %s: %v", k.kind, k.key, err)
+		}
+	}
+}
+
+func (s *userService) countAttempts(keys []attemptKey) {
+	for _, k := range keys {
+		failures, err := s.attemptRepo.RecordFailure(k.kind, k.key, k.policy.window)
+		if err != nil {
+			log.Printf("failed to record attempt for %s %s: %v", k.kind, k.key, err)
+			continue
+		}
+		if d := k.policy.lockFor(failures); d > 0 {
+			if err := s.attemptRepo.Lock(k.kind, k.key, time.Now().Add(d)); err != nil {
+				log.Printf("failed to lock %s %s: %v", k.kind, k.key, err)
+			}
+		}
+	}
+}
+
+// throttleReset refuses a password reset request with *LockedError while
+// any of keys is locked, and otherwise counts it against all of them.
+func (s *userService) throttleReset(keys ...attemptKey) error {
+	until, err := s.lockedUntil(keys)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		return &LockedError{Until: until, msg: msgTooManyResets}
+	}
+	s.countAttempts(keys)
+	return nil
+}
+
+func (s *userService) audit(entry *models.AuthAuditEntry) {
+	if err := s.auditRepo.Record(entry); err != nil {
+		log.Printf("failed to write auth audit entry %s: %v", entry.Event, err)
+	}
+}
+
+// UnlockAccount clears the failed login counter of the user's login. IP
+// locks are left to expire.
+func (s *userService) UnlockAccount(ctx *gin.Context, userID, adminID string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if err := s.attemptRepo.Reset(models.AttemptByLogin, user.Login); err != nil {
+		return err
+	}
+	s.audit(&models.AuthAuditEntry{Event: models.AuditAccountUnlocked, Login: user.Login, UserID: user.ID, ActorID: adminID, IPAddress: ctx.ClientIP()})
+	return nil
+}
+
+// PruneLoginAttempts deletes stale counters every pruneInterval until ctx is
+// cancelled. Every failure creates a row, even for logins that do not exist,
+// so without it the table would only grow.
+func PruneLoginAttempts(ctx context.Context, repo repository.LoginAttemptRepository) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if deleted, err := repo.DeleteStale(time.Now().Add(-attemptRetention)); err != nil {
+			log.Printf("failed to prune login attempts: %v", err)
+		} else if deleted > 0 {
+			log.Printf("pruned %d login attempt counters", deleted)
+		}
+	}
+}
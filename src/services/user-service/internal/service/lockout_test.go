@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicyLockFor(t *testing.T) {
+	p := lockoutPolicy{threshold: 5, base: time.Minute, max: time.Hour}
+
+	testCases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{4, 0},
+		{5, time.Minute},
+		{6, 2 * time.Minute},
+		{7, 4 * time.Minute},
+		{10, 32 * time.Minute},
+		{11, time.Hour},
+		{1000, time.Hour},
+	}
+
+	for _, tc := range testCases {
+		if got := p.lockFor(tc.failures); got != tc.want {
+			t.Errorf("lockFor(%d) = %v, want %v", tc.failures, got, tc.want)
+		}
+	}
+}
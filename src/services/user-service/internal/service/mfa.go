@@ -0,0 +1,239 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
+	"github.com/zahartd/social-network/src/services/user-service/internal/totp"
+)
+
+const (
+	totpIssuer = "social-network"
+
+	mfaChallengeTTL = 5 * time.Minute
+	// maxMFAAttempts is how many wrong codes one MFA token takes. Each
+	// wrong code also counts towards the login lockout.
+	maxMFAAttempts = 5
+
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotPending     = errors.New("no two-factor setup in progress")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired MFA token")
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTOTP issues a new TOTP secret for the user. It does not guard
+// logins until ConfirmTOTP proves the user's authenticator has it.
+func (s *userService) EnrollTOTP(ctx *gin.Context, userID string) (*models.TOTPEnrollment, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.mfaRepo.SavePendingTOTP(userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(secret, totpIssuer, user.Login),
+	}, nil
+}
+
+// ConfirmTOTP enables the pending secret given a current code from it and
+// returns fresh recovery codes. They are shown only once.
+func (s *userService) ConfirmTOTP(ctx *gin.Context, userID, code string) ([]string, error) {
+	secret, err := s.mfaRepo.GetTOTP(userID)
+	if errors.Is(err, repository.ErrTOTPNotFound) {
+		return nil, ErrMFANotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret.EnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	step, ok := totp.Verify(secret.Secret, strings.TrimSpace(code), s.now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.mfaRepo.EnableTOTP(userID, step, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrMFANotPending
+	}
+	s.audit(&models.AuthAuditEntry{Event: models.AuditMFAEnabled, UserID: userID, IPAddress: ctx.ClientIP()})
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off after checking the
+// password, and drops the recovery codes.
+func (s *userService) DisableTOTP(ctx *gin.Context, userID, password string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrWrongPassword
+	}
+	if _, err := s.mfaRepo.GetTOTP(userID); errors.Is(err, repository.ErrTOTPNotFound) {
+		return ErrMFANotEnabled
+	}
+	if err := s.mfaRepo.DeleteTOTP(userID); err != nil {
+		return err
+	}
+	s.audit(&models.AuthAuditEntry{Event: models.AuditMFADisabled, Login: user.Login, UserID: userID, IPAddress: ctx.ClientIP()})
+	return nil
+}
+
+// mfaEnabled reports whether logins of the user need a second factor.
+func (s *userService) mfaEnabled(userID string) (bool, error) {
+	secret, err := s.mfaRepo.GetTOTP(userID)
+	if errors.Is(err, repository.ErrTOTPNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return secret.EnabledAt != nil, nil
+}
+
+// startMFA records a login that passed the password check and returns the
+// token that CompleteMFALogin exchanges for a session.
+func (s *userService) startMFA(user *models.User) (*models.MFAPending, error) {
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	challenge := &models.MFAChallenge{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: s.now().Add(mfaChallengeTTL),
+	}
+	if err := s.mfaRepo.CreateChallenge(challenge); err != nil {
+		return nil, err
+	}
+	return &models.MFAPending{Token: token, ExpiresIn: int64(mfaChallengeTTL.Seconds())}, nil
+}
+
+// CompleteMFALogin finishes a login started by Login with either a TOTP
+// code or an unused recovery code. Wrong codes count towards the lockout of
+// the login, which is only reset here, not when the password matched.
+func (s *userService) CompleteMFALogin(ctx *gin.Context, mfaToken, code string) (*models.TokenPair, error) {
+	challenge, err := s.mfaRepo.GetChallenge(auth.HashOpaqueToken(mfaToken))
+	if errors.Is(err, repository.ErrMFAChallengeInvalid) {
+		return nil, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Attempts >= maxMFAAttempts {
+		return nil, ErrInvalidMFAToken
+	}
+	user, err := s.repo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+	ip := ctx.ClientIP()
+	if err := s.checkLockout(user.Login, ip); err != nil {
+		s.audit(&models.AuthAuditEntry{Event: models.AuditLoginLocked, Login: user.Login, UserID: user.ID, IPAddress: ip})
+		return nil, err
+	}
+	ok, err := s.checkSecondFactor(user, code, ip)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := s.mfaRepo.FailChallenge(challenge.ID); err != nil {
+			log.Printf("failed to count MFA attempt of user %s: %v", user.ID, err)
+		}
+		s.recordFailure(models.AuditMFAFailed, user.Login, ip, user.ID)
+		return nil, ErrInvalidMFACode
+	}
+	consumed, err := s.mfaRepo.ConsumeChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidMFAToken
+	}
+	s.clearFailures(user.Login, ip)
+	return s.issueTokens(ctx, user)
+}
+
+// checkSecondFactor accepts a TOTP code not used before, or a recovery
+// code, which is used up.
+func (s *userService) checkSecondFactor(user *models.User, code, ip string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		secret, err := s.mfaRepo.GetTOTP(user.ID)
+		if err != nil {
+			return false, err
+		}
+		step, ok := totp.Verify(secret.Secret, code, s.now())
+		if !ok {
+			return false, nil
+		}
+		return s.mfaRepo.UseTOTPStep(user.ID, step)
+	}
+	used, err := s.mfaRepo.UseRecoveryCode(user.ID, auth.HashOpaqueToken(normalizeRecoveryCode(code)))
+	if err != nil || !used {
+		return false, err
+	}
+	s.audit(&models.AuthAuditEntry{Event: models.AuditRecoveryCodeUsed, Login: user.Login, UserID: user.ID, IPAddress: ip})
+	return true, nil
+}
+
+// newRecoveryCodes returns codes formatted for display, like
+// abcd-efgh-ijkl-mnop, and their hashes.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	buf := make([]byte, recoveryCodeSize)
+	for range recoveryCodeCount {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(buf))
+		var parts []string
+		for i := 0; i < len(raw); i += 4 {
+			parts = append(parts, raw[i:min(i+4, len(raw))])
+		}
+		codes = append(codes, strings.Join(parts, "-"))
+		hashes = append(hashes, auth.HashOpaqueToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode undoes the display formatting, so codes are
+// accepted with or without dashes and in any case.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
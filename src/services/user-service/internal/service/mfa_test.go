@@ -0,0 +1,41 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("got %d codes and %d hashes, want %d", len(codes), len(hashes), recoveryCodeCount)
+	}
+	format := regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`)
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("code %q is not formatted for display", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q is repeated", code)
+		}
+		seen[code] = true
+		if got := auth.HashOpaqueToken(normalizeRecoveryCode(code)); got != hashes[i] {
+			t.Errorf("hash of %q does not match the stored one", code)
+		}
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	want := "abcdefghijklmnop"
+	for _, in := range []string{"abcd-efgh-ijkl-mnop", "ABCD-EFGH-IJKL-MNOP", "abcd efgh ijkl mnop", want} {
+		if got := normalizeRecoveryCode(in); got != want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/user-service/internal/mailer"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
+)
+
+const passwordResetTTL = time.Hour
+
+var (
+	ErrWrongPassword      = errors.New("old password is incorrect")
+	ErrInvalidResetToken  = errors.New("invalid or expired reset token")
+	errHashPassword       = errors.New("failed to hash password")
+	errUpdatePassword     = errors.New("failed to update password")
+	errRevokeSessions     = errors.New("password changed, but failed to revoke sessions")
+	errCurrentSession     = errors.New("failed to find the current session")
+	errIssuePasswordReset = errors.New("failed to issue password reset")
+)
+
+// ChangePassword sets a new password after checking the old one and logs
+// the user out everywhere except the session of token. Pending reset tokens
+// are used up together with the change, so none of them can undo it. It
+// returns how many sessions were revoked.
+func (s *userService) ChangePassword(ctx *gin.Context, userID, oldPassword, newPassword, token string) (int64, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return 0, ErrUserNotFound
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+		return 0, ErrWrongPassword
+	}
+	current, err := s.sessionRepo.GetSessionByToken(auth.TrimBearerPrefix(token))
+	if err != nil {
+		return 0, errCurrentSession
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, errHashPassword
+	}
+	revoked, err := s.repo.ChangePassword(userID, string(hash), current.FamilyID)
+	if err != nil {
+		return 0, errUpdatePassword
+	}
+	return revoked, nil
+}
+
+// RequestPasswordReset mails a single-use reset token to the owner of
+// email. Unknown emails are ignored without an error, so the endpoint does
+// not reveal which emails are registered.
+func (s *userService) RequestPasswordReset(ctx *gin.Context, email string) error {
+	err := s.throttleReset(
+		attemptKey{models.AttemptByResetEmail, email, resetEmailLimit},
+		attemptKey{models.AttemptByResetIP, ctx.ClientIP(), ipLockout},
+	)
+	if err != nil {
+		return err
+	}
+	user, err := s.repo.GetByEmail(email)
+	if err != nil {
+		log.Printf("password reset requested for unknown email: %v", err)
+		return nil
+	}
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return errIssuePasswordReset
+	}
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.resetRepo.Create(reset); err != nil {
+		return errIssuePasswordReset
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to reset your password: %s\nIt expires at %s.\n\nIf you did not ask for a reset, ignore this message.",
+			user.Firstname, token, reset.ExpiresAt.UTC().Format(time.RFC1123)),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Printf("failed to send password reset to user %s: %v", user.ID, err)
+		return errIssuePasswordReset
+	}
+	return nil
+}
+
+// ResetPassword sets a new password by a reset token and revokes all
+// sessions of the user. The token is used up only together with the
+// password change.
+func (s *userService) ResetPassword(ctx *gin.Context, resetToken, newPassword string) error {
+	if err := s.throttleReset(attemptKey{models.AttemptByResetIP, ctx.ClientIP(), ipLockout}); err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errHashPassword
+	}
+	userID, err := s.resetRepo.Consume(auth.HashOpaqueToken(resetToken), string(hash))
+	if errors.Is(err, repository.ErrResetTokenInvalid) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return errUpdatePassword
+	}
+	if err := s.sessionRepo.RevokeAllSessions(userID); err != nil {
+		return errRevokeSessions
+	}
+	return nil
+}
@@ -1,8 +1,6 @@
 package service
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -10,46 +8,125 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/segmentio/kafka-go"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/zahartd/social-network/src/events"
+	eventspb "github.com/zahartd/social-network/src/gen/go/events"
 	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/user-service/internal/mailer"
 	"github.com/zahartd/social-network/src/services/user-service/internal/models"
 	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
 )
 
 type UserService interface {
-	CreateUser(ctx *gin.Context, login, firstname, surname, email, password string) (*models.User, string, error)
-	Login(ctx *gin.Context, login, password string) (string, error)
+	CreateUser(ctx *gin.Context, login, firstname, surname, email, password string) (*models.User, *models.TokenPair, error)
+	Login(ctx *gin.Context, login, password string) (*models.TokenPair, *models.MFAPending, error)
+	CompleteMFALogin(ctx *gin.Context, mfaToken, code string) (*models.TokenPair, error)
+	RefreshToken(ctx *gin.Context, refreshToken string) (*models.TokenPair, error)
+	IntrospectToken(ctx *gin.Context, token string) (bool, error)
+	ListSessions(ctx *gin.Context, userID, token string) ([]models.SessionInfo, error)
+	RevokeSession(ctx *gin.Context, userID, sessionID string) error
+	RevokeOtherSessions(ctx *gin.Context, userID, token string) (int64, error)
 	Logout(ctx *gin.Context, token string) error
 	GetUserByID(ctx *gin.Context, id string) (*models.User, error)
 	GetUserByLogin(ctx *gin.Context, login string) (*models.User, error)
 	UpdateUser(ctx *gin.Context, id string, email, firstname, surname, phone, bio string, requesterID string) (*models.User, error)
 	DeleteUser(ctx *gin.Context, id, token string) error
+	ListRoles(ctx *gin.Context, userID string) ([]models.RoleAssignment, error)
+	GrantRole(ctx *gin.Context, userID string, role models.Role, adminID string) ([]models.RoleAssignment, error)
+	RevokeRole(ctx *gin.Context, userID string, role models.Role, adminID string) ([]models.RoleAssignment, error)
+	ChangePassword(ctx *gin.Context, userID, oldPassword, newPassword, token string) (int64, error)
+	RequestPasswordReset(ctx *gin.Context, email string) error
+	ResetPassword(ctx *gin.Context, resetToken, newPassword string) error
+	VerifyEmail(ctx *gin.Context, token string) error
+	UnlockAccount(ctx *gin.Context, userID, adminID string) error
+	ResendVerification(ctx *gin.Context, userID string) error
+	EnrollTOTP(ctx *gin.Context, userID string) (*models.TOTPEnrollment, error)
+	ConfirmTOTP(ctx *gin.Context, userID, code string) ([]string, error)
+	DisableTOTP(ctx *gin.Context, userID, password string) error
+	Follow(ctx *gin.Context, followerID, followeeID string) error
+	Unfollow(ctx *gin.Context, followerID, followeeID string) error
+	ListFollowers(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error)
+	ListFollowing(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error)
+	FollowCounts(ctx *gin.Context, userID string) (models.FollowCounts, error)
+	FollowingOf(ctx *gin.Context, userID string) ([]models.Follow, error)
+	FollowerIDs(ctx *gin.Context, userID string, limit int) ([]string, error)
+	AddCloseFriend(ctx *gin.Context, userID, friendID string) error
+	RemoveCloseFriend(ctx *gin.Context, userID, friendID string) error
+	ListCloseFriends(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error)
+	CloseFriendOf(ctx *gin.Context, userID string) ([]string, error)
+	Block(ctx *gin.Context, userID, targetID string) error
+	Unblock(ctx *gin.Context, userID, targetID string) error
+	ListBlocked(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error)
+	Mute(ctx *gin.Context, userID, targetID string) error
+	Unmute(ctx *gin.Context, userID, targetID string) error
+	ListMuted(ctx *gin.Context, userID string, page, pageSize int) ([]models.FollowUser, error)
+	Restrictions(ctx *gin.Context, userID string) (*models.Restrictions, error)
 }
 
+var (
+	ErrBaseRole     = errors.New("the user role cannot be revoked")
+	ErrSelfDemotion = errors.New("admins cannot revoke their own admin role")
+	ErrUserNotFound = errors.New("user not found")
+	errLoadRoles    = errors.New("failed to load user roles")
+
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
 type userService struct {
-	repo                repository.UserRepository
-	sessionRepo         repository.SessionRepository
-	registrationsWriter *kafka.Writer
+	repo        repository.UserRepository
+	sessionRepo repository.SessionRepository
+	roleRepo    repository.RoleRepository
+	resetRepo   repository.PasswordResetRepository
+	verifyRepo  repository.EmailVerificationRepository
+	attemptRepo repository.LoginAttemptRepository
+	auditRepo   repository.AuthAuditRepository
+	mfaRepo     repository.MFARepository
+	followRepo  repository.FollowRepository
+	blockRepo   repository.BlockRepository
+	codec       events.Codec
+	mailer      mailer.Mailer
+	now         func() time.Time
 }
 
-func NewUserService(repo repository.UserRepository, sessionRepo repository.SessionRepository, rw *kafka.Writer) UserService {
+func NewUserService(repo repository.UserRepository, sessionRepo repository.SessionRepository, roleRepo repository.RoleRepository, resetRepo repository.PasswordResetRepository, verifyRepo repository.EmailVerificationRepository, attemptRepo repository.LoginAttemptRepository, auditRepo repository.AuthAuditRepository, mfaRepo repository.MFARepository, followRepo repository.FollowRepository, blockRepo repository.BlockRepository, codec events.Codec, mailer mailer.Mailer) UserService {
 	return &userService{
-		repo:                repo,
-		sessionRepo:         sessionRepo,
-		registrationsWriter: rw,
+		repo:        repo,
+		sessionRepo: sessionRepo,
+		roleRepo:    roleRepo,
+		resetRepo:   resetRepo,
+		verifyRepo:  verifyRepo,
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		mfaRepo:     mfaRepo,
+		followRepo:  followRepo,
+		blockRepo:   blockRepo,
+		codec:       codec,
+		mailer:      mailer,
+		now:         time.Now,
 	}
 }
 
-func (s *userService) CreateUser(ctx *gin.Context, login, firstname, surname, email, password string) (*models.User, string, error) {
+// loadRoles fills user.Roles.
+func (s *userService) loadRoles(user *models.User) error {
+	roles, err := s.roleRepo.GetRoles(user.ID)
+	if err != nil {
+		return err
+	}
+	user.Roles = roles
+	return nil
+}
+
+func (s *userService) CreateUser(ctx *gin.Context, login, firstname, surname, email, password string) (*models.User, *models.TokenPair, error) {
 	existingUser, _ := s.repo.GetByLogin(login)
 	if existingUser != nil {
-		return nil, "", errors.New("user with this login already exists")
+		return nil, nil, errors.New("user with this login already exists")
 	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	user := &models.User{
 		ID:           uuid.NewString(),
@@ -59,69 +136,179 @@ func (s *userService) CreateUser(ctx *gin.Context, login, firstname, surname, em
 		Email:        email,
 		PasswordHash: string(hash),
 	}
-	err = s.repo.Create(user)
+	event, err := s.newEvent(topicUserRegistrations, user.ID, &eventspb.UserRegistered{UserId: user.ID, Email: user.Email}, s.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.repo.Create(user, event)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
+	}
+	if err := s.loadRoles(user); err != nil {
+		return nil, nil, errLoadRoles
 	}
 
 	token, err := auth.GenerateToken(user)
 	if err != nil {
-		return nil, "", errors.New("failed to generate auth token")
-	}
-
-	ev := struct {
-		UserID    string    `json:"user_id"`
-		CreatedAt time.Time `json:"created_at"`
-		Email     string    `json:"email"`
-	}{
-		UserID:    user.ID,
-		CreatedAt: user.CreatedAt,
-		Email:     user.Email,
-	}
-	payload, _ := json.Marshal(ev)
-
-	const retries = 3
-	for range retries {
-		writerCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		err = s.registrationsWriter.WriteMessages(
-			writerCtx,
-			kafka.Message{
-				Key:   []byte(user.ID),
-				Value: payload,
-			},
-		)
-		if errors.Is(err, kafka.LeaderNotAvailable) || errors.Is(err, context.DeadlineExceeded) {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		}
+		return nil, nil, errors.New("failed to generate auth token")
+	}
+	refreshToken, err := auth.CreateSession(user, token, ctx.ClientIP())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create session: %w", err)
+	}
 
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("failed to send email verification to user %s: %v", user.ID, err)
+	}
+	return user, auth.NewTokenPair(token, refreshToken), nil
+}
+
+// Login answers the same ErrInvalidCredentials for unknown logins and wrong
+// passwords, and refuses with *LockedError while the login or the client IP
+// is locked out after repeated failures. Users with two-factor
+// authentication get an MFA token instead of a token pair, to be completed
+// with CompleteMFALogin.
+func (s *userService) Login(ctx *gin.Context, login, password string) (*models.TokenPair, *models.MFAPending, error) {
+	ip := ctx.ClientIP()
+	if err := s.checkLockout(login, ip); err != nil {
+		s.audit(&models.AuthAuditEntry{Event: models.AuditLoginLocked, Login: login, IPAddress: ip})
+		return nil, nil, err
+	}
+	user, err := s.repo.GetByLogin(login)
+	hash, userID := dummyPasswordHash(), ""
+	if err == nil {
+		hash, userID = []byte(user.PasswordHash), user.ID
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || err != nil {
+		s.recordFailure(models.AuditLoginFailed, login, ip, userID)
+		return nil, nil, ErrInvalidCredentials
+	}
+	mfa, err := s.mfaEnabled(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mfa {
+		pending, err := s.startMFA(user)
 		if err != nil {
-			log.Printf("failed to write messages: %s", err.Error())
+			return nil, nil, fmt.Errorf("failed to start MFA: %w", err)
 		}
-		break
+		return nil, pending, nil
 	}
-	return user, token, nil
+	s.clearFailures(login, ip)
+	tokens, err := s.issueTokens(ctx, user)
+	return tokens, nil, err
 }
 
-func (s *userService) Login(ctx *gin.Context, login, password string) (string, error) {
-	user, err := s.repo.GetByLogin(login)
+// issueTokens starts a session for a user who passed every login check.
+func (s *userService) issueTokens(ctx *gin.Context, user *models.User) (*models.TokenPair, error) {
+	if err := s.loadRoles(user); err != nil {
+		return nil, errLoadRoles
+	}
+	token, err := auth.GenerateToken(user)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+	refreshToken, err := auth.CreateSession(user, token, ctx.ClientIP())
 	if err != nil {
-		return "", errors.New("invalid login")
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	return auth.NewTokenPair(token, refreshToken), nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. A refresh
+// token is single-use: presenting one that was already rotated means it has
+// leaked, so the whole session family is revoked.
+func (s *userService) RefreshToken(ctx *gin.Context, refreshToken string) (*models.TokenPair, error) {
+	sess, err := s.sessionRepo.GetSessionByRefreshHash(auth.HashOpaqueToken(refreshToken))
 	if err != nil {
-		return "", errors.New("invalid password")
+		return nil, ErrInvalidRefreshToken
+	}
+	if sess.RotatedAt != nil {
+		return nil, s.revokeFamily(sess)
+	}
+	if sess.RevokedAt != nil || time.Now().After(sess.RefreshExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	user, err := s.repo.GetByID(sess.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := s.loadRoles(user); err != nil {
+		return nil, errLoadRoles
 	}
 	token, err := auth.GenerateToken(user)
 	if err != nil {
-		return "", errors.New("failed to generate token")
+		return nil, errors.New("failed to generate token")
 	}
-	if err := auth.CreateSession(user, token, ctx.ClientIP()); err != nil {
-		return "", fmt.Errorf("failed to create session: %w", err)
+	next, rotated, err := auth.RotateSession(sess, user, token, ctx.ClientIP())
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+	if !rotated {
+		// Another request rotated the same token first.
+		return nil, s.revokeFamily(sess)
 	}
-	return token, nil
+	return auth.NewTokenPair(token, next), nil
+}
+
+// IntrospectToken reports whether the access token still has a live
+// session. Logout and account deletion remove the session, and refresh token
+// reuse revokes it.
+func (s *userService) IntrospectToken(ctx *gin.Context, token string) (bool, error) {
+	sess, err := s.sessionRepo.GetSessionByToken(auth.TrimBearerPrefix(token))
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return time.Now().Before(sess.ExpiresAt), nil
+}
+
+// ListSessions returns the user's active logins and marks the one token
+// belongs to.
+func (s *userService) ListSessions(ctx *gin.Context, userID, token string) ([]models.SessionInfo, error) {
+	current, err := s.sessionRepo.GetSessionByToken(auth.TrimBearerPrefix(token))
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionRepo.ListActiveSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current.FamilyID
+	}
+	return sessions, nil
+}
+
+func (s *userService) RevokeSession(ctx *gin.Context, userID, sessionID string) error {
+	revoked, err := s.sessionRepo.RevokeUserSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeOtherSessions logs the user out everywhere except the session of
+// token.
+func (s *userService) RevokeOtherSessions(ctx *gin.Context, userID, token string) (int64, error) {
+	current, err := s.sessionRepo.GetSessionByToken(auth.TrimBearerPrefix(token))
+	if err != nil {
+		return 0, err
+	}
+	return s.sessionRepo.RevokeOtherSessions(userID, current.FamilyID)
+}
+
+func (s *userService) revokeFamily(sess *models.Session) error {
+	log.Printf("refresh token reuse in session family %s of user %s", sess.FamilyID, sess.UserID)
+	if err := s.sessionRepo.RevokeFamily(sess.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return ErrRefreshTokenReused
 }
 
 func (s *userService) Logout(ctx *gin.Context, token string) error {
@@ -148,6 +335,7 @@ func (s *userService) UpdateUser(ctx *gin.Context, id string, email, firstname,
 	if err != nil {
 		return nil, err
 	}
+	emailChanged := user.Email != email
 	user.Email = email
 	user.Firstname = firstname
 	user.Surname = surname
@@ -157,6 +345,11 @@ func (s *userService) UpdateUser(ctx *gin.Context, id string, email, firstname,
 	if err != nil {
 		return nil, err
 	}
+	if emailChanged {
+		if err := s.sendVerification(ctx, user); err != nil {
+			log.Printf("failed to send email verification to user %s: %v", user.ID, err)
+		}
+	}
 	return user, nil
 }
 
@@ -166,3 +359,33 @@ func (s *userService) DeleteUser(ctx *gin.Context, id, token string) error {
 	}
 	return s.repo.Delete(id)
 }
+
+func (s *userService) ListRoles(ctx *gin.Context, userID string) ([]models.RoleAssignment, error) {
+	return s.roleRepo.ListAssignments(userID)
+}
+
+func (s *userService) GrantRole(ctx *gin.Context, userID string, role models.Role, adminID string) ([]models.RoleAssignment, error) {
+	if _, err := s.repo.GetByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	if _, err := s.roleRepo.Grant(userID, role, adminID); err != nil {
+		return nil, err
+	}
+	return s.roleRepo.ListAssignments(userID)
+}
+
+func (s *userService) RevokeRole(ctx *gin.Context, userID string, role models.Role, adminID string) ([]models.RoleAssignment, error) {
+	if role == models.RoleUser {
+		return nil, ErrBaseRole
+	}
+	if role == models.RoleAdmin && userID == adminID {
+		return nil, ErrSelfDemotion
+	}
+	if _, err := s.repo.GetByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	if _, err := s.roleRepo.Revoke(userID, role); err != nil {
+		return nil, err
+	}
+	return s.roleRepo.ListAssignments(userID)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zahartd/social-network/src/services/user-service/internal/auth"
+	"github.com/zahartd/social-network/src/services/user-service/internal/mailer"
+	"github.com/zahartd/social-network/src/services/user-service/internal/models"
+	"github.com/zahartd/social-network/src/services/user-service/internal/repository"
+)
+
+const emailVerificationTTL = 24 * time.Hour
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified     = errors.New("email is already verified")
+)
+
+// sendVerification mails the user a token confirming their current email.
+// Earlier tokens of the user stop working.
+func (s *userService) sendVerification(ctx context.Context, user *models.User) error {
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	v := &models.EmailVerification{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(emailVerificationTTL),
+	}
+	if err := s.verifyRepo.Create(v); err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to verify your email: %s\nIt expires at %s.",
+			user.Firstname, token, v.ExpiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+// VerifyEmail confirms the email a token was sent to, provided it is still
+// the user's email. New access tokens carry the email_verified claim.
+func (s *userService) VerifyEmail(ctx *gin.Context, token string) error {
+	v, err := s.verifyRepo.Consume(auth.HashOpaqueToken(token))
+	if errors.Is(err, repository.ErrVerificationTokenInvalid) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return err
+	}
+	verified, err := s.repo.MarkEmailVerified(v.UserID, v.Email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return ErrInvalidVerificationToken
+	}
+	return nil
+}
+
+func (s *userService) ResendVerification(ctx *gin.Context, userID string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+	return s.sendVerification(ctx, user)
+}
@@ -0,0 +1,114 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key from RFC 6238 appendix B, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHOTPMatchesRFC6238Vectors(t *testing.T) {
+	key, err := decodeSecret(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		step := Step(time.Unix(tt.unix, 0))
+		if got := hotp(key, uint64(step), 8); got != tt.want {
+			t.Errorf("hotp at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestCodeUsesSixDigits(t *testing.T) {
+	code, err := Code(rfcSecret, time.Unix(59, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "287082" {
+		t.Errorf("Code = %s, want 287082", code)
+	}
+}
+
+func TestVerifyAcceptsAdjacentSteps(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	tests := []struct {
+		name   string
+		at     time.Time
+		wantOK bool
+	}{
+		{"current step", now, true},
+		{"previous step", now.Add(-Period), true},
+		{"next step", now.Add(Period), true},
+		{"two steps ago", now.Add(-2 * Period), false},
+		{"two steps ahead", now.Add(2 * Period), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Code(rfcSecret, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			step, ok := Verify(rfcSecret, code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("Verify = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != Step(tt.at) {
+				t.Errorf("matched step %d, want %d", step, Step(tt.at))
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsMalformedInput(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	for _, code := range []string{"", "12345", "1234567", "abcdef"} {
+		if _, ok := Verify(rfcSecret, code, now); ok {
+			t.Errorf("Verify(%q) succeeded", code)
+		}
+	}
+	if _, ok := Verify("not base32!", "123456", now); ok {
+		t.Error("Verify with an invalid secret succeeded")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != secretSize {
+		t.Errorf("secret has %d bytes, want %d", len(key), secretSize)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	u, err := url.Parse(ProvisioningURI(rfcSecret, "social-network", "john_doe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/social-network:john_doe" {
+		t.Errorf("unexpected URI %s", u)
+	}
+	q := u.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "social-network" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("unexpected query %s", u.RawQuery)
+	}
+}
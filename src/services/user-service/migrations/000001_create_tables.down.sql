@@ -1,4 +1,4 @@
 DROP TABLE IF EXISTS user_sessions;
 DROP TABLE IF EXISTS user_roles;
 DROP TABLE IF EXISTS users;
-DROP TYPE user_role_type;
+DROP TYPE IF EXISTS user_role_type;
@@ -0,0 +1,13 @@
+CREATE TYPE user_role_type AS ENUM ('admin', 'moderator', 'approved_user', 'user');
+
+CREATE TABLE user_roles (
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role user_role_type NOT NULL,
+    assign_user_id uuid REFERENCES users(id) ON DELETE SET NULL, -- NULL - роль назначена системой
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role)
+);
+
+-- Базовая роль для уже зарегистрированных пользователей
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'user' FROM users;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_user_sessions_family_id;
+DROP INDEX IF EXISTS idx_user_sessions_refresh_token_hash;
+
+ALTER TABLE user_sessions
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS rotated_at,
+    DROP COLUMN IF EXISTS refresh_expires_at,
+    DROP COLUMN IF EXISTS refresh_token_hash,
+    DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,16 @@
+-- Каждая сессия хранит хэш refresh-токена. При обновлении создается новая
+-- сессия с тем же family_id, а старая помечается rotated_at; повторное
+-- использование старого токена отзывает всю семью сессий.
+ALTER TABLE user_sessions
+    ADD COLUMN family_id uuid,
+    ADD COLUMN refresh_token_hash TEXT,
+    ADD COLUMN refresh_expires_at TIMESTAMPTZ,
+    ADD COLUMN rotated_at TIMESTAMPTZ,
+    ADD COLUMN revoked_at TIMESTAMPTZ;
+
+UPDATE user_sessions SET family_id = id;
+
+ALTER TABLE user_sessions ALTER COLUMN family_id SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_user_sessions_refresh_token_hash ON user_sessions(refresh_token_hash);
+CREATE INDEX idx_user_sessions_family_id ON user_sessions(family_id);
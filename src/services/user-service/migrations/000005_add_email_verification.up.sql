@@ -0,0 +1,15 @@
+-- NULL - email не подтвержден. Смена email сбрасывает подтверждение.
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- Токен подтверждает конкретный адрес: после смены email старые токены не действуют.
+CREATE TABLE email_verification_tokens (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
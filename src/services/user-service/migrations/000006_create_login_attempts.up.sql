@@ -0,0 +1,22 @@
+-- Счетчики неудачных входов по логину и по IP. Счетчик обнуляется, если
+-- с последней неудачи прошло больше окна политики блокировки.
+CREATE TABLE login_attempts (
+    kind TEXT NOT NULL CHECK (kind IN ('login', 'ip')),
+    key TEXT NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    PRIMARY KEY (kind, key)
+);
+
+CREATE TABLE auth_audit_log (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event TEXT NOT NULL,
+    login TEXT,
+    user_id uuid REFERENCES users(id) ON DELETE SET NULL,
+    actor_id uuid REFERENCES users(id) ON DELETE SET NULL, -- кто выполнил действие, например администратор
+    ip_address inet,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_auth_audit_log_login ON auth_audit_log(login, created_at);
@@ -0,0 +1,30 @@
+-- TOTP-секрет пользователя. Пока enabled_at NULL, секрет выдан, но не
+-- подтвержден кодом и при входе не проверяется. last_used_step - номер
+-- последнего принятого 30-секундного шага, чтобы код нельзя было использовать повторно.
+CREATE TABLE user_totp (
+    user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+-- Одноразовые коды восстановления; хранится только SHA-256.
+CREATE TABLE mfa_recovery_codes (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
+
+-- Вход, прошедший проверку пароля и ожидающий второго фактора.
+CREATE TABLE mfa_challenges (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
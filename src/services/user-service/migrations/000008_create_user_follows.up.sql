@@ -0,0 +1,11 @@
+-- Граф подписок: follower_id подписан на followee_id.
+CREATE TABLE user_follows (
+    follower_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+-- Для списка подписчиков; список подписок обслуживается первичным ключом.
+CREATE INDEX idx_user_follows_followee ON user_follows(followee_id, created_at);
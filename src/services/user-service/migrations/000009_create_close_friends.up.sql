@@ -0,0 +1,12 @@
+-- Список близких друзей: user_id показывает посты с видимостью close_friends
+-- пользователю friend_id. Список виден только владельцу.
+CREATE TABLE close_friends (
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    friend_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, friend_id),
+    CHECK (user_id <> friend_id)
+);
+
+-- Для поиска авторов, у которых пользователь в близких друзьях.
+CREATE INDEX idx_close_friends_friend ON close_friends(friend_id);
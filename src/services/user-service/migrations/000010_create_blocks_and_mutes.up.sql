@@ -0,0 +1,21 @@
+-- Блокировка действует в обе стороны: пользователи не видят посты друг друга,
+-- не могут комментировать, лайкать и подписываться.
+CREATE TABLE user_blocks (
+    blocker_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- Для проверки блокировки в обратную сторону.
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- Заглушенный пользователь скрыт только из списков того, кто его заглушил.
+CREATE TABLE user_mutes (
+    muter_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (muter_id, muted_id),
+    CHECK (muter_id <> muted_id)
+);
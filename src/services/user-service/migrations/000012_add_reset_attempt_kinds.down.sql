@@ -0,0 +1,3 @@
+DELETE FROM login_attempts WHERE kind IN ('reset_email', 'reset_ip');
+ALTER TABLE login_attempts DROP CONSTRAINT login_attempts_kind_check;
+ALTER TABLE login_attempts ADD CONSTRAINT login_attempts_kind_check CHECK (kind IN ('login', 'ip'));
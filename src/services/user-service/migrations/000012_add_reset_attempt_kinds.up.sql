@@ -0,0 +1,4 @@
+-- Запросы на сброс пароля ограничиваются теми же счетчиками: по email и по IP.
+ALTER TABLE login_attempts DROP CONSTRAINT login_attempts_kind_check;
+ALTER TABLE login_attempts ADD CONSTRAINT login_attempts_kind_check
+    CHECK (kind IN ('login', 'ip', 'reset_email', 'reset_ip'));
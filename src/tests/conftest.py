@@ -2,9 +2,10 @@ import os
 import time
 import uuid
 
+import docker
 import pytest
 import requests
-from helpers.utils import auth_headers, make_request
+from helpers.utils import auth_headers, login_request, make_request
 from kafka import KafkaConsumer, TopicPartition
 
 
@@ -48,15 +49,33 @@ def register_user(api_gateway_url, unique_user):
 def login_user(api_gateway_url, register_user):
     login = register_user["login"]
     password = register_user["password"]
-    url = api_gateway_url + "/user/login"
-    params = {"login": login, "password": password}
-    resp = make_request("GET", url, params=params)
+    resp = login_request(api_gateway_url, login, password)
     assert resp.status_code == 200, f"Логин не удался: {resp.text}"
     data = resp.json()
     token = data.get("token")
     assert token
     return token, register_user
 
+@pytest.fixture
+def admin_user(api_gateway_url):
+    # Роль admin выдается одноразовой командой user-service через docker.sock
+    user_data = {
+        "login": "e2e_admin",
+        "firstname": "Admin",
+        "surname": "Admin",
+        "email": "e2e_admin@example.com",
+        "password": "AdminPass123"
+    }
+    resp = make_request("POST", api_gateway_url + "/user", data=user_data, headers={"Content-Type": "application/json"})
+    assert resp.status_code in (201, 400), f"Регистрация админа упала: {resp.text}"
+    result = docker.from_env().containers.get("user-service").exec_run(
+        ["./user-service", "-grant-admin", user_data["login"]]
+    )
+    assert result.exit_code == 0, f"Выдача роли admin упала: {result.output.decode()}"
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200, f"Логин админа не удался: {resp.text}"
+    return resp.json()["token"], user_data
+
 @pytest.fixture
 def user_factory(api_gateway_url):
     def _create():
@@ -75,11 +94,8 @@ def user_factory(api_gateway_url):
             data=user_data
         )
         assert resp.status_code == 201, f"Регистрация упала: {resp.text}"
-        resp = make_request(
-            "GET",
-            api_gateway_url + "/user/login",
-            params={"login": user_data["login"], "password": user_data["password"]}
-        )
+        user_data["id"] = resp.json()["user"]["id"]
+        resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
         assert resp.status_code == 200, f"Логин не удался: {resp.text}"
         data = resp.json()
         token = data.get("token")
@@ -118,10 +134,10 @@ def kafka_consumer():
         enable_auto_commit=False,
         consumer_timeout_ms=5000,
         key_deserializer=lambda k: k.decode() if k else None,
-        value_deserializer=lambda v: v.decode(),
+        value_deserializer=lambda v: v.decode(errors="replace"),
     )
 
-    topics = ['user-registrations','post-views','post-likes','post-comments']
+    topics = ['user-registrations','user-relations','post-views','post-likes','post-comments','post-lifecycle']
     tps = [TopicPartition(t, 0) for t in topics]
     consumer.assign(tps)
 
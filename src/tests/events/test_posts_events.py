@@ -1,3 +1,5 @@
+import json
+
 from helpers.utils import auth_headers, make_request, wait_for_kafka
 
 
@@ -28,15 +30,36 @@ async def test_like_and_unlike_emits_event(api_gateway_url, login_user, kafka_co
         data={"title":"t","description":"d","is_private":False,"tags":[]}
     ).json()["id"]
 
-    make_request("POST",    f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
-    make_request("DELETE",  f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
+    for method in ("POST", "POST", "DELETE", "DELETE"):
+        make_request(method, f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
+
+    types = []
+    def collect(m):
+        if post_id in m.value:
+            types.append(json.loads(m.value)["event_type"])
+        return len(types) >= 2
+
+    wait_for_kafka(kafka_consumer, topic="post-likes", predicate=collect)
+    assert types == ["post_liked", "post_unliked"], f"Повторные лайки породили лишние события: {types}"
+
+
+async def test_unlike_without_like_emits_nothing(api_gateway_url, login_user, kafka_consumer):
+    token, _ = login_user
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+
+    resp = make_request("DELETE", f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
+    assert resp.status_code < 300, f"Снятие лайка упало: {resp.text}"
 
     ok = wait_for_kafka(
         kafka_consumer,
         topic="post-likes",
         predicate=lambda m: post_id in m.value
     )
-    assert ok, "Событие post-likes не найдено"
+    assert not ok, "Событие post_unliked отправлено без лайка"
 
 
 async def test_comment_and_list_emits_event(api_gateway_url, login_user, kafka_consumer):
@@ -60,4 +83,47 @@ async def test_comment_and_list_emits_event(api_gateway_url, login_user, kafka_c
         topic="post-comments",
         predicate=lambda m: comment_id in m.value
     )
-    assert ok, "Событие post-comments не найдено"
\ No newline at end of file
+    assert ok, "Событие post-comments не найдено"
+
+
+async def test_post_lifecycle_emits_events(api_gateway_url, login_user, kafka_consumer):
+    token, user_data = login_user
+    headers = {**auth_headers(token),"Content-Type":"application/json"}
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers=headers,
+        data={"title":"t","description":"d","is_private":False,"tags":["a"]}
+    ).json()["id"]
+    make_request(
+        "PUT", f"{api_gateway_url}/posts/{post_id}",
+        headers=headers,
+        data={"title":"t2","description":"d2","is_private":True,"tags":["a","b"]}
+    )
+    make_request("DELETE", f"{api_gateway_url}/posts/{post_id}", headers=auth_headers(token))
+
+    events = {}
+    def collect(m):
+        ev = json.loads(m.value)
+        event_type = ev["event_type"]
+        if ev[event_type]["post"]["post_id"] == post_id:
+            events[event_type] = ev
+        return len(events) == 3
+
+    ok = wait_for_kafka(kafka_consumer, topic="post-lifecycle", predicate=collect)
+    assert ok, f"События post-lifecycle не найдены: {list(events)}"
+
+    for event_type, ev in events.items():
+        assert ev["schema_version"] == 1, f"Неверная версия схемы у {event_type}"
+        assert ev["event_id"], f"Нет event_id у {event_type}"
+        assert ev["occurred_at"], f"Нет occurred_at у {event_type}"
+    assert len({ev["event_id"] for ev in events.values()}) == 3
+
+    created = events["post_created"]["post_created"]["post"]
+    updated = events["post_updated"]["post_updated"]["post"]
+    deleted = events["post_deleted"]["post_deleted"]["post"]
+    assert created["user_id"] == user_data["id"]
+    assert created["tags"] == ["a"]
+    assert created["is_private"] is False
+    assert updated["tags"] == ["a","b"]
+    assert updated["is_private"] is True
+    assert deleted["user_id"] == user_data["id"]
@@ -1,4 +1,6 @@
-from helpers.utils import make_request, wait_for_kafka
+import json
+
+from helpers.utils import auth_headers, make_request, wait_for_kafka
 
 
 async def test_user_registration_emits_event(api_gateway_url, unique_user, kafka_consumer):
@@ -11,9 +13,50 @@ async def test_user_registration_emits_event(api_gateway_url, unique_user, kafka
     assert resp.status_code == 201
     user_id = resp.json()["user"]["id"]
 
+    found = []
+    def collect(m):
+        if user_id in m.value:
+            found.append(m)
+        return bool(found)
+
     ok = wait_for_kafka(
         kafka_consumer,
         topic="user-registrations",
-        predicate=lambda m: user_id in m.value,
+        predicate=collect,
+    )
+    assert ok, "Событие user-registrations не найдено"
+
+    msg = found[0]
+    headers = dict(msg.headers)
+    assert headers.get("content-type") == b"application/json", f"Нет заголовка content-type: {msg.headers}"
+    ev = json.loads(msg.value)
+    assert ev["event_type"] == "user_registered"
+    assert ev["schema_version"] == 1
+    assert ev["user_registered"]["user_id"] == user_id
+    assert ev["user_registered"]["email"] == unique_user["email"]
+
+
+async def test_follow_and_unfollow_emit_events(api_gateway_url, user_factory, kafka_consumer):
+    token, follower = user_factory()
+    _, followee = user_factory()
+    url = f"{api_gateway_url}/user/{followee['login']}/follow"
+    assert make_request("POST", url, headers=auth_headers(token)).status_code == 200
+    assert make_request("DELETE", url, headers=auth_headers(token)).status_code == 200
+
+    found = {}
+    def collect(m):
+        if m.key == follower["id"]:
+            ev = json.loads(m.value)
+            found[ev["event_type"]] = ev
+        return len(found) == 2
+
+    ok = wait_for_kafka(
+        kafka_consumer,
+        topic="user-relations",
+        predicate=collect,
     )
-    assert ok, "Событие user-registrations не найдено"
\ No newline at end of file
+    assert ok, f"События user-relations не найдены: {list(found)}"
+    for event_type in ("user_followed", "user_unfollowed"):
+        payload = found[event_type][event_type]
+        assert payload["follower_id"] == follower["id"]
+        assert payload["followee_id"] == followee["id"]
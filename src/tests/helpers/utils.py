@@ -1,14 +1,17 @@
 import json
 import logging
+import os
 import time
 
 import requests
 
 LOGGER = logging.getLogger(__name__)
 
+
 def auth_headers(token):
     return {"Authorization": f"Bearer {token}"}
 
+
 def make_request(method, url, params=None, data=None, headers=None):
     if data is not None:
         data = json.dumps(data)
@@ -17,6 +20,24 @@ def make_request(method, url, params=None, data=None, headers=None):
     LOGGER.info(f"Response: {resp.status_code} {resp.text}")
     return resp
 
+
+def login_request(api_gateway_url, login, password):
+    return make_request("POST", api_gateway_url + "/user/login", data={"login": login, "password": password},
+                        headers={"Content-Type": "application/json"})
+
+
+def create_post_request(api_gateway_url, token, **fields):
+    payload = {"title": "t", "description": "d", "tags": [], **fields}
+    return make_request("POST", api_gateway_url + "/posts", data=payload,
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+
+
+def create_post(api_gateway_url, token, **fields):
+    resp = create_post_request(api_gateway_url, token, **fields)
+    assert resp.status_code == 201, f"Ошибка создания поста: {resp.text}"
+    return resp.json()
+
+
 def wait_for_kafka(consumer, *, topic, predicate, timeout_sec=5, step_ms=200):
     deadline = time.time() + timeout_sec
     while time.time() < deadline:
@@ -30,4 +51,33 @@ def wait_for_kafka(consumer, *, topic, predicate, timeout_sec=5, step_ms=200):
                         return True
                 finally:
                     pass
-    return False
\ No newline at end of file
+    return False
+
+
+def wait_until(fetch, predicate, timeout_sec=30, step_sec=0.5):
+    deadline = time.time() + timeout_sec
+    last = None
+    while time.time() < deadline:
+        last = fetch()
+        if predicate(last):
+            return True, last
+        time.sleep(step_sec)
+    return False, last
+
+
+def last_mail(to, subject=None, timeout_sec=5):
+    """Последнее письмо для адреса из файла локального почтового ящика user-service."""
+    path = os.environ.get("MAILER_FILE", "/var/mail/social-network/outbox.jsonl")
+
+    def fetch():
+        try:
+            with open(path, encoding="utf-8") as f:
+                mails = [json.loads(line) for line in f if line.strip()]
+        except FileNotFoundError:
+            return None
+        mails = [m for m in mails if m["to"] == to and subject in (None, m["subject"])]
+        return mails[-1] if mails else None
+
+    ok, mail = wait_until(fetch, lambda m: m is not None, timeout_sec=timeout_sec)
+    assert ok, f"Письмо для {to} не пришло"
+    return mail
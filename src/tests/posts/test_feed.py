@@ -0,0 +1,64 @@
+from helpers.utils import auth_headers, create_post, make_request
+
+
+def follow(api_gateway_url, token, login, method="POST"):
+    resp = make_request(method, f"{api_gateway_url}/user/{login}/follow", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка подписки: {resp.text}"
+
+
+def get_feed(api_gateway_url, token, **params):
+    query = "&".join(f"{k}={v}" for k, v in params.items())
+    resp = make_request("GET", f"{api_gateway_url}/posts/feed?{query}", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка получения ленты: {resp.text}"
+    return resp.json()
+
+
+async def test_feed_shows_posts_of_followed_users(api_gateway_url, user_factory):
+    reader_token, _ = user_factory()
+    author_token, author = user_factory()
+    stranger_token, _ = user_factory()
+
+    old_post = create_post(api_gateway_url, author_token, title="До подписки")
+    follow(api_gateway_url, reader_token, author["login"])
+    new_post = create_post(api_gateway_url, author_token, title="После подписки")
+    create_post(api_gateway_url, author_token, title="Приватный", is_private=True)
+    create_post(api_gateway_url, stranger_token, title="Чужой")
+
+    feed = get_feed(api_gateway_url, reader_token)
+    assert [p["id"] for p in feed.get("posts", [])] == [new_post["id"], old_post["id"]], \
+        "В ленте должны быть только публичные посты подписок, новые первыми"
+    assert not feed.get("next_cursor"), "Единственная страница не должна иметь курсора"
+
+    follow(api_gateway_url, reader_token, author["login"], method="DELETE")
+    assert get_feed(api_gateway_url, reader_token).get("posts", []) == [], "После отписки посты автора должны пропасть"
+
+
+async def test_feed_cursor_pagination(api_gateway_url, user_factory):
+    reader_token, _ = user_factory()
+    first_token, first = user_factory()
+    second_token, second = user_factory()
+    follow(api_gateway_url, reader_token, first["login"])
+    follow(api_gateway_url, reader_token, second["login"])
+
+    created = []
+    for i in range(5):
+        token = first_token if i % 2 == 0 else second_token
+        created.append(create_post(api_gateway_url, token, title=f"Пост {i}")["id"])
+
+    seen, cursor = [], None
+    for _ in range(5):
+        params = {"page_size": 2}
+        if cursor:
+            params["cursor"] = cursor
+        feed = get_feed(api_gateway_url, reader_token, **params)
+        seen += [p["id"] for p in feed.get("posts", [])]
+        cursor = feed.get("next_cursor")
+        if not cursor:
+            break
+    assert seen == list(reversed(created)), "Страницы ленты должны идти по порядку без пропусков и повторов"
+
+
+async def test_feed_rejects_invalid_cursor(api_gateway_url, login_user):
+    token, _ = login_user
+    resp = make_request("GET", f"{api_gateway_url}/posts/feed?cursor=broken!", headers=auth_headers(token))
+    assert resp.status_code == 400, f"Ожидался 400: {resp.text}"
@@ -0,0 +1,27 @@
+from helpers.utils import auth_headers, make_request
+
+
+async def test_like_and_unlike_are_idempotent(api_gateway_url, created_post, user_factory):
+    post, token, _ = created_post
+    url = api_gateway_url + f"/posts/{post['id']}/like"
+    fan_token, _ = user_factory()
+
+    for _ in range(2):
+        resp = make_request("POST", url, headers=auth_headers(token))
+        assert resp.status_code == 200, f"Ошибка лайка: {resp.text}"
+        assert resp.json() == {"liked": True, "likes_count": 1}
+
+    resp = make_request("POST", url, headers=auth_headers(fan_token))
+    assert resp.json() == {"liked": True, "likes_count": 2}
+
+    for _ in range(2):
+        resp = make_request("DELETE", url, headers=auth_headers(token))
+        assert resp.status_code == 200, f"Ошибка снятия лайка: {resp.text}"
+        assert resp.json() == {"liked": False, "likes_count": 1}
+
+
+async def test_like_missing_post(api_gateway_url, login_user):
+    token, _ = login_user
+    url = api_gateway_url + "/posts/00000000-0000-0000-0000-000000000000/like"
+    resp = make_request("POST", url, headers=auth_headers(token))
+    assert resp.status_code == 404, f"Ожидался 404: {resp.text}"
@@ -0,0 +1,91 @@
+import json
+
+from helpers.utils import auth_headers, create_post, make_request, wait_for_kafka
+
+
+def moderate(url, token, action, reason="нарушение правил"):
+    return make_request("POST", url, data={"action": action, "reason": reason},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+
+
+async def test_moderator_hides_and_deletes_post(api_gateway_url, admin_user, user_factory):
+    admin_token, _ = admin_user
+    author_token, _ = user_factory()
+    reader_token, _ = user_factory()
+    post_id = create_post(api_gateway_url, author_token)["id"]
+    post_url = f"{api_gateway_url}/posts/{post_id}"
+
+    resp = moderate(post_url + "/moderation", admin_token, "hide", "спам")
+    assert resp.status_code == 200, f"Ошибка скрытия поста: {resp.text}"
+    assert resp.json()["action"] == "hide"
+    assert resp.json()["target_type"] == "post"
+
+    assert make_request("GET", post_url, headers=auth_headers(reader_token)).status_code == 404
+    resp = make_request("GET", post_url, headers=auth_headers(author_token))
+    assert resp.status_code == 200 and resp.json().get("hidden") is True
+    resp = make_request("GET", f"{api_gateway_url}/posts/list/public", headers=auth_headers(reader_token))
+    assert post_id not in [p["id"] for p in resp.json().get("posts", [])]
+
+    assert moderate(post_url + "/moderation", admin_token, "unhide", "апелляция").status_code == 200
+    assert make_request("GET", post_url, headers=auth_headers(reader_token)).status_code == 200
+
+    assert moderate(post_url + "/moderation", admin_token, "delete", "оскорбления").status_code == 200
+    assert make_request("GET", post_url, headers=auth_headers(author_token)).status_code == 404
+
+    resp = make_request("GET", post_url + "/moderation", headers=auth_headers(admin_token))
+    assert resp.status_code == 200, f"Ошибка получения журнала: {resp.text}"
+    actions = resp.json()["actions"]
+    assert [(a["action"], a["reason"]) for a in actions] == [
+        ("delete", "оскорбления"), ("unhide", "апелляция"), ("hide", "спам")]
+
+
+async def test_moderator_hides_comment(api_gateway_url, admin_user, user_factory, kafka_consumer):
+    admin_token, _ = admin_user
+    author_token, _ = user_factory()
+    post_id = create_post(api_gateway_url, author_token)["id"]
+    comments_url = f"{api_gateway_url}/posts/{post_id}/comments"
+    resp = make_request("POST", comments_url, data={"text": "плохой комментарий"},
+                        headers={**auth_headers(author_token), "Content-Type": "application/json"})
+    comment_id = resp.json()["comment"]["id"]
+    replies_url = f"{comments_url}/{comment_id}/replies"
+    resp = make_request("POST", replies_url, data={"text": "ответ"},
+                        headers={**auth_headers(author_token), "Content-Type": "application/json"})
+    assert resp.status_code == 201, f"Ошибка создания ответа: {resp.text}"
+    reply_id = resp.json()["reply"]["id"]
+
+    resp = moderate(f"{comments_url}/{comment_id}/moderation", admin_token, "hide")
+    assert resp.status_code == 200, f"Ошибка скрытия комментария: {resp.text}"
+    assert resp.json()["target_id"] == comment_id
+
+    resp = make_request("GET", comments_url, headers=auth_headers(author_token))
+    assert comment_id not in [cm["id"] for cm in resp.json().get("comments", [])]
+    resp = make_request("GET", replies_url, headers=auth_headers(author_token))
+    assert resp.status_code == 200
+    assert not resp.json().get("replies"), "Ответы на скрытый комментарий видны"
+
+    resp = moderate(f"{comments_url}/{comment_id}/moderation", admin_token, "delete")
+    assert resp.status_code == 200
+    resp = moderate(f"{comments_url}/{comment_id}/moderation", admin_token, "delete")
+    assert resp.status_code == 404
+
+    deleted = set()
+    def collect(m):
+        env = json.loads(m.value)
+        if env["event_type"] == "comment_deleted" and env["comment_deleted"]["post_id"] == post_id:
+            deleted.add(env["comment_deleted"]["comment_id"])
+        return deleted == {comment_id, reply_id}
+
+    ok = wait_for_kafka(kafka_consumer, topic="post-comments", predicate=collect)
+    assert ok, f"События comment_deleted не найдены: {deleted}"
+
+
+async def test_moderation_requires_role_and_reason(api_gateway_url, admin_user, user_factory):
+    admin_token, _ = admin_user
+    token, _ = user_factory()
+    post_id = create_post(api_gateway_url, token)["id"]
+    url = f"{api_gateway_url}/posts/{post_id}/moderation"
+
+    assert moderate(url, token, "hide").status_code == 403
+    assert make_request("GET", url, headers=auth_headers(token)).status_code == 403
+    assert moderate(url, admin_token, "hide", reason="").status_code == 400
+    assert moderate(url, admin_token, "ban").status_code == 400
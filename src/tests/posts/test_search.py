@@ -0,0 +1,70 @@
+import uuid
+
+from helpers.utils import auth_headers, create_post, make_request
+
+
+def search(api_gateway_url, token, **params):
+    resp = make_request("GET", f"{api_gateway_url}/posts/search", params=params, headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка поиска: {resp.text}"
+    return resp.json()
+
+
+def search_ids(api_gateway_url, token, **params):
+    return [p["id"] for p in search(api_gateway_url, token, **params)["posts"]]
+
+
+async def test_search_by_text(api_gateway_url, user_factory):
+    token, _ = user_factory()
+    word = uuid.uuid4().hex
+    in_title = create_post(api_gateway_url, token, title=f"{word} заголовок")
+    in_description = create_post(api_gateway_url, token, title="Другой пост", description=f"Описание {word}")
+    create_post(api_gateway_url, token, title="Без совпадений")
+
+    data = search(api_gateway_url, token, q=word)
+    assert data["total_count"] == 2
+    assert [p["id"] for p in data["posts"]] == [in_title["id"], in_description["id"]], \
+        "Совпадение в заголовке должно быть выше"
+
+    assert search_ids(api_gateway_url, token, q=word, order="recent") == [in_description["id"], in_title["id"]]
+    assert search_ids(api_gateway_url, token, q=f"{word} -заголовок") == [in_description["id"]]
+    assert search_ids(api_gateway_url, token, q=f'"{word} заголовок"') == [in_title["id"]]
+
+
+async def test_search_filters(api_gateway_url, user_factory):
+    token, user = user_factory()
+    other_token, _ = user_factory()
+    tag = uuid.uuid4().hex
+    tagged = create_post(api_gateway_url, token, title="t", tags=[tag, "go"])
+    create_post(api_gateway_url, token, title="t", tags=[tag])
+    others = create_post(api_gateway_url, other_token, title="t", tags=[tag, "go"])
+
+    assert set(search_ids(api_gateway_url, token, tags=f"{tag},go")) == {tagged["id"], others["id"]}
+    assert search_ids(api_gateway_url, token, tags=[tag, "go"], author_id=user["id"]) == [tagged["id"]]
+    assert search_ids(api_gateway_url, token, tags=tag, to="2000-01-01") == []
+    assert len(search_ids(api_gateway_url, token, tags=tag, **{"from": "2000-01-01"})) == 3
+
+
+async def test_search_respects_visibility(api_gateway_url, user_factory):
+    author_token, author = user_factory()
+    reader_token, _ = user_factory()
+    word = uuid.uuid4().hex
+    public = create_post(api_gateway_url, author_token, title=word)
+    private = create_post(api_gateway_url, author_token, title=word, visibility="only_me")
+    followers = create_post(api_gateway_url, author_token, title=word, visibility="followers")
+
+    assert set(search_ids(api_gateway_url, author_token, q=word)) == {public["id"], private["id"], followers["id"]}, \
+        "Автор должен находить свои посты"
+    assert search_ids(api_gateway_url, reader_token, q=word) == [public["id"]], \
+        "Чужие непубличные посты не должны находиться"
+
+    resp = make_request("POST", f"{api_gateway_url}/user/{author['login']}/follow", headers=auth_headers(reader_token))
+    assert resp.status_code == 200, f"Ошибка подписки: {resp.text}"
+    assert set(search_ids(api_gateway_url, reader_token, q=word)) == {public["id"], followers["id"]}
+
+
+async def test_search_rejects_invalid_params(api_gateway_url, login_user):
+    token, _ = login_user
+    for params in ({"order": "popular"}, {"author_id": "not-a-uuid"}, {"from": "01.01.2025"},
+                   {"from": "2025-02-01", "to": "2025-01-01"}, {"page_size": 101}):
+        resp = make_request("GET", f"{api_gateway_url}/posts/search", params=params, headers=auth_headers(token))
+        assert resp.status_code == 400, f"Ожидался 400 для {params}: {resp.text}"
@@ -0,0 +1,79 @@
+from helpers.utils import auth_headers, create_post_request, make_request
+
+
+def get_post(api_gateway_url, token, post_id):
+    return make_request("GET", f"{api_gateway_url}/posts/{post_id}", headers=auth_headers(token))
+
+
+def list_author_posts(api_gateway_url, token, author_id):
+    resp = make_request("GET", f"{api_gateway_url}/posts/list/public/{author_id}", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка получения постов автора: {resp.text}"
+    return [p["id"] for p in resp.json()["posts"]]
+
+
+async def test_is_private_is_still_accepted(api_gateway_url, login_user):
+    token, _ = login_user
+    resp = create_post_request(api_gateway_url, token, is_private=True)
+    assert resp.status_code == 201, f"Ошибка создания поста: {resp.text}"
+    assert resp.json()["visibility"] == "only_me", "is_private=true должен означать only_me"
+    assert resp.json()["is_private"] is True
+
+    resp = create_post_request(api_gateway_url, token)
+    assert resp.json()["visibility"] == "public"
+
+
+async def test_unknown_visibility(api_gateway_url, login_user):
+    token, _ = login_user
+    assert create_post_request(api_gateway_url, token, visibility="friends").status_code == 400
+
+
+async def test_followers_visibility(api_gateway_url, user_factory):
+    author_token, author = user_factory()
+    follower_token, _ = user_factory()
+    stranger_token, _ = user_factory()
+    resp = make_request("POST", f"{api_gateway_url}/user/{author['login']}/follow", headers=auth_headers(follower_token))
+    assert resp.status_code == 200
+
+    resp = create_post_request(api_gateway_url, author_token, visibility="followers")
+    assert resp.status_code == 201, f"Ошибка создания поста: {resp.text}"
+    post = resp.json()
+    assert post["visibility"] == "followers"
+    assert post["is_private"] is True, "Непубличный пост должен оставаться is_private для старых клиентов"
+
+    assert get_post(api_gateway_url, author_token, post["id"]).status_code == 200
+    assert get_post(api_gateway_url, follower_token, post["id"]).status_code == 200, "Подписчик должен видеть пост"
+    assert get_post(api_gateway_url, stranger_token, post["id"]).status_code == 403, "Посторонний не должен видеть пост"
+
+    assert post["id"] in list_author_posts(api_gateway_url, follower_token, author["id"])
+    assert post["id"] not in list_author_posts(api_gateway_url, stranger_token, author["id"])
+
+
+async def test_close_friends_visibility(api_gateway_url, user_factory):
+    author_token, author = user_factory()
+    friend_token, friend = user_factory()
+    follower_token, _ = user_factory()
+    for token in (friend_token, follower_token):
+        make_request("POST", f"{api_gateway_url}/user/{author['login']}/follow", headers=auth_headers(token))
+
+    resp = make_request("POST", f"{api_gateway_url}/user/close-friends/{friend['login']}", headers=auth_headers(author_token))
+    assert resp.status_code == 200, f"Ошибка добавления в близкие друзья: {resp.text}"
+    resp = make_request("GET", f"{api_gateway_url}/user/close-friends", headers=auth_headers(author_token))
+    assert [u["login"] for u in resp.json()["close_friends"]] == [friend["login"]]
+
+    post = create_post_request(api_gateway_url, author_token, visibility="close_friends").json()
+    assert get_post(api_gateway_url, friend_token, post["id"]).status_code == 200, "Близкий друг должен видеть пост"
+    assert get_post(api_gateway_url, follower_token, post["id"]).status_code == 403, "Обычный подписчик не должен видеть пост"
+
+    resp = make_request("GET", f"{api_gateway_url}/posts/feed", headers=auth_headers(friend_token))
+    assert [p["id"] for p in resp.json()["posts"]] == [post["id"]]
+    resp = make_request("GET", f"{api_gateway_url}/posts/feed", headers=auth_headers(follower_token))
+    assert resp.json()["posts"] == [], "Пост для близких друзей не должен попасть в ленту подписчика"
+
+    make_request("DELETE", f"{api_gateway_url}/user/close-friends/{friend['login']}", headers=auth_headers(author_token))
+    assert get_post(api_gateway_url, friend_token, post["id"]).status_code == 403
+
+
+async def test_cannot_add_self_to_close_friends(api_gateway_url, login_user):
+    token, user_data = login_user
+    resp = make_request("POST", f"{api_gateway_url}/user/close-friends/{user_data['login']}", headers=auth_headers(token))
+    assert resp.status_code == 400
@@ -0,0 +1,83 @@
+from helpers.utils import auth_headers, make_request, wait_until
+
+
+async def test_post_stats_counts_events(api_gateway_url, login_user):
+    token, _ = login_user
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+
+    for _ in range(2):
+        make_request("POST", f"{api_gateway_url}/posts/{post_id}/view", headers=auth_headers(token))
+    make_request("POST", f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
+    make_request(
+        "POST", f"{api_gateway_url}/posts/{post_id}/comments",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"text":"stats"}
+    )
+
+    ok, resp = wait_until(
+        lambda: make_request("GET", f"{api_gateway_url}/posts/{post_id}/stats", headers=auth_headers(token)),
+        lambda r: r.status_code == 200 and r.json() == {"post_id": post_id, "views": 2, "likes": 1, "comments": 1},
+    )
+    assert ok, f"Статистика не сошлась: {resp.text}"
+
+
+async def test_post_stats_unlike_decrements_likes(api_gateway_url, user_factory):
+    author_token, _ = user_factory()
+    fan_token, _ = user_factory()
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(author_token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+
+    def fetch():
+        return make_request("GET", f"{api_gateway_url}/posts/{post_id}/stats", headers=auth_headers(author_token))
+
+    for token in (author_token, fan_token):
+        make_request("POST", f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(token))
+    ok, resp = wait_until(fetch, lambda r: r.status_code == 200 and r.json()["likes"] == 2)
+    assert ok, f"Лайки не посчитаны: {resp.text}"
+
+    for _ in range(2):
+        make_request("DELETE", f"{api_gateway_url}/posts/{post_id}/like", headers=auth_headers(fan_token))
+    ok, resp = wait_until(fetch, lambda r: r.status_code == 200 and r.json()["likes"] == 1)
+    assert ok, f"Снятый лайк не вычтен: {resp.text}"
+
+
+async def test_post_stats_for_post_without_events(api_gateway_url, login_user):
+    token, _ = login_user
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+
+    resp = make_request("GET", f"{api_gateway_url}/posts/{post_id}/stats", headers=auth_headers(token))
+    assert resp.status_code == 200
+    assert resp.json() == {"post_id": post_id, "views": 0, "likes": 0, "comments": 0}
+
+
+async def test_post_stats_invalid_id(api_gateway_url, login_user):
+    token, _ = login_user
+    resp = make_request("GET", f"{api_gateway_url}/posts/not-a-uuid/stats", headers=auth_headers(token))
+    assert resp.status_code == 400
+
+
+async def test_post_stats_of_private_post(api_gateway_url, user_factory):
+    author_token, _ = user_factory()
+    stranger_token, _ = user_factory()
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(author_token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":True,"tags":[]}
+    ).json()["id"]
+
+    resp = make_request("GET", f"{api_gateway_url}/posts/{post_id}/stats", headers=auth_headers(author_token))
+    assert resp.status_code == 200
+    for path in ("stats", "stats/timeline?metric=views"):
+        resp = make_request("GET", f"{api_gateway_url}/posts/{post_id}/{path}", headers=auth_headers(stranger_token))
+        assert resp.status_code == 403, f"Статистика чужого приватного поста доступна: {resp.text}"
@@ -0,0 +1,51 @@
+from datetime import datetime, timedelta, timezone
+
+from helpers.utils import auth_headers, make_request, wait_until
+
+
+async def test_post_timeline_counts_today(api_gateway_url, login_user):
+    token, _ = login_user
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+
+    for _ in range(3):
+        make_request("POST", f"{api_gateway_url}/posts/{post_id}/view", headers=auth_headers(token))
+
+    today = datetime.now(timezone.utc).date()
+    week_ago = today - timedelta(days=6)
+    params = {"metric": "views", "from": week_ago.isoformat(), "to": today.isoformat()}
+
+    ok, resp = wait_until(
+        lambda: make_request("GET", f"{api_gateway_url}/posts/{post_id}/stats/timeline",
+                             params=params, headers=auth_headers(token)),
+        lambda r: r.status_code == 200 and r.json()["points"][-1]["count"] == 3,
+    )
+    assert ok, f"Динамика просмотров не сошлась: {resp.text}"
+
+    points = resp.json()["points"]
+    assert len(points) == 7
+    assert points[0]["date"] == week_ago.isoformat()
+    assert all(p["count"] == 0 for p in points[:-1])
+
+
+async def test_post_timeline_validation(api_gateway_url, login_user):
+    token, _ = login_user
+    post_id = make_request(
+        "POST", f"{api_gateway_url}/posts",
+        headers={**auth_headers(token),"Content-Type":"application/json"},
+        data={"title":"t","description":"d","is_private":False,"tags":[]}
+    ).json()["id"]
+    url = f"{api_gateway_url}/posts/{post_id}/stats/timeline"
+
+    resp = make_request("GET", url, params={"metric": "shares"}, headers=auth_headers(token))
+    assert resp.status_code == 400
+
+    resp = make_request("GET", url, params={"metric": "likes", "from": "2025-02-01", "to": "2025-01-01"},
+                        headers=auth_headers(token))
+    assert resp.status_code == 400
+
+    resp = make_request("GET", url, params={"metric": "likes", "from": "01.01.2025"}, headers=auth_headers(token))
+    assert resp.status_code == 400
@@ -0,0 +1,83 @@
+from helpers.utils import auth_headers, create_post, make_request, wait_until
+
+VIEWS = 30
+
+
+def view(api_gateway_url, token, post_id, times=VIEWS):
+    for _ in range(times):
+        make_request("POST", f"{api_gateway_url}/posts/{post_id}/view", headers=auth_headers(token))
+
+
+def top_post_ids(api_gateway_url, token):
+    r = make_request("GET", f"{api_gateway_url}/stats/top/posts",
+                     params={"metric": "views", "limit": 100}, headers=auth_headers(token))
+    return r, [p["post_id"] for p in r.json()["posts"]] if r.status_code == 200 else []
+
+
+async def test_top_posts_and_users_by_views(api_gateway_url, user_factory):
+    author_token, _ = user_factory()
+    viewer_token, _ = user_factory()
+
+    post_id = create_post(api_gateway_url, author_token)["id"]
+    author_id = make_request("GET", f"{api_gateway_url}/posts/{post_id}", headers=auth_headers(author_token)).json()["user_id"]
+    view(api_gateway_url, viewer_token, post_id)
+
+    def find_post(r):
+        if r.status_code != 200:
+            return None
+        return next((p for p in r.json()["posts"] if p["post_id"] == post_id), None)
+
+    ok, resp = wait_until(
+        lambda: make_request("GET", f"{api_gateway_url}/stats/top/posts",
+                             params={"metric": "views", "limit": 100}, headers=auth_headers(viewer_token)),
+        lambda r: (p := find_post(r)) is not None and p["count"] == VIEWS and p["user_id"] == author_id,
+    )
+    assert ok, f"Пост не попал в рейтинг: {resp.text}"
+    counts = [p["count"] for p in resp.json()["posts"]]
+    assert counts == sorted(counts, reverse=True)
+
+    ok, resp = wait_until(
+        lambda: make_request("GET", f"{api_gateway_url}/stats/top/users",
+                             params={"metric": "views", "limit": 100}, headers=auth_headers(viewer_token)),
+        lambda r: r.status_code == 200 and {"user_id": author_id, "count": VIEWS} in r.json()["users"],
+    )
+    assert ok, f"Автор не попал в рейтинг: {resp.text}"
+
+
+async def test_top_validation(api_gateway_url, login_user):
+    token, _ = login_user
+    for path in ("posts", "users"):
+        url = f"{api_gateway_url}/stats/top/{path}"
+        assert make_request("GET", url, params={"metric": "shares"}, headers=auth_headers(token)).status_code == 400
+        assert make_request("GET", url, params={"metric": "likes", "limit": 0}, headers=auth_headers(token)).status_code == 400
+        assert make_request("GET", url, params={"metric": "likes", "limit": 1000}, headers=auth_headers(token)).status_code == 400
+
+
+async def test_deleted_post_leaves_top_posts(api_gateway_url, login_user):
+    token, _ = login_user
+    post_id = create_post(api_gateway_url, token)["id"]
+    view(api_gateway_url, token, post_id)
+
+    ok, res = wait_until(lambda: top_post_ids(api_gateway_url, token), lambda res: post_id in res[1])
+    assert ok, f"Пост не попал в рейтинг: {res[0].text}"
+
+    make_request("DELETE", f"{api_gateway_url}/posts/{post_id}", headers=auth_headers(token))
+    ok, res = wait_until(lambda: top_post_ids(api_gateway_url, token), lambda res: post_id not in res[1])
+    assert ok, f"Удалённый пост остался в рейтинге: {res[0].text}"
+
+
+async def test_private_post_stays_out_of_top(api_gateway_url, user_factory):
+    token, user = user_factory()
+    private_id = create_post(api_gateway_url, token, is_private=True)["id"]
+    public_id = create_post(api_gateway_url, token)["id"]
+    view(api_gateway_url, token, private_id)
+    view(api_gateway_url, token, public_id)
+
+    ok, res = wait_until(lambda: top_post_ids(api_gateway_url, token), lambda res: public_id in res[1])
+    assert ok, f"Пост не попал в рейтинг: {res[0].text}"
+    assert private_id not in res[1], "Приватный пост попал в рейтинг"
+
+    resp = make_request("GET", f"{api_gateway_url}/stats/top/users",
+                        params={"metric": "views", "limit": 100}, headers=auth_headers(token))
+    assert {"user_id": user["id"], "count": VIEWS} in resp.json()["users"], \
+        "Просмотры приватного поста не должны учитываться в рейтинге автора"
@@ -1,4 +1,4 @@
-from helpers.utils import auth_headers, make_request
+from helpers.utils import auth_headers, login_request, make_request
 
 
 async def test_signup_and_login(api_gateway_url, unique_user):
@@ -10,14 +10,13 @@ async def test_signup_and_login(api_gateway_url, unique_user):
     assert user_data
     assert user_data.get("login") == unique_user["login"]
 
-    url = api_gateway_url + "/user/login"
-    params = {"login": unique_user["login"], "password": unique_user["password"]}
-    resp = make_request("GET", url, params=params)
+    resp = login_request(api_gateway_url, unique_user["login"], unique_user["password"])
     assert resp.status_code == 200, f"Ошибка логина: {resp.text}"
     data = resp.json()
     token = data.get("token")
     assert token
 
+
 async def test_duplicate_signup(api_gateway_url, unique_user):
     url = api_gateway_url + "/user"
     resp = make_request("POST", url, data=unique_user, headers={"Content-Type": "application/json"})
@@ -25,9 +24,26 @@ async def test_duplicate_signup(api_gateway_url, unique_user):
     resp2 = make_request("POST", url, data=unique_user, headers={"Content-Type": "application/json"})
     assert resp2.status_code != 201, "Дублирование регистрации прошло успешно, хотя должно быть отказано"
 
+
 async def test_logout(api_gateway_url, login_user):
     token, user_data = login_user
     url = api_gateway_url + "/user/logout"
     headers = auth_headers(token)
     resp = make_request("GET", url, headers=headers)
     assert resp.status_code in [200, 204]
+
+
+async def test_login_with_query_is_deprecated(api_gateway_url, register_user):
+    user_data = register_user
+    resp = make_request("GET", api_gateway_url + "/user/login",
+                        params={"login": user_data["login"], "password": user_data["password"]})
+    assert resp.status_code == 200, f"Старый GET-логин перестал работать: {resp.text}"
+    assert resp.json().get("token")
+    assert resp.headers.get("Deprecation") == "true"
+    assert "deprecated" in resp.headers.get("Warning", "")
+
+
+async def test_login_requires_json_body(api_gateway_url):
+    resp = make_request("POST", api_gateway_url + "/user/login", data={},
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 400
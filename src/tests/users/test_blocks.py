@@ -0,0 +1,93 @@
+from helpers.utils import auth_headers, create_post, make_request
+
+
+def comment(api_gateway_url, token, post_id):
+    headers = {**auth_headers(token), "Content-Type": "application/json"}
+    return make_request("POST", f"{api_gateway_url}/posts/{post_id}/comments", data={"text": "hi"}, headers=headers)
+
+
+def list_comment_authors(api_gateway_url, token, post_id):
+    resp = make_request("GET", f"{api_gateway_url}/posts/{post_id}/comments", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка получения комментариев: {resp.text}"
+    return [c["user_id"] for c in resp.json().get("comments", [])]
+
+
+async def test_block_works_both_ways(api_gateway_url, user_factory):
+    alice_token, alice = user_factory()
+    bob_token, bob = user_factory()
+    post = create_post(api_gateway_url, alice_token)
+    resp = comment(api_gateway_url, alice_token, post["id"])
+    assert resp.status_code == 201, f"Ошибка создания комментария: {resp.text}"
+    comment_id = resp.json()["comment"]["id"]
+    resp = make_request("POST", f"{api_gateway_url}/user/{alice['login']}/follow", headers=auth_headers(bob_token))
+    assert resp.status_code == 200, f"Ошибка подписки: {resp.text}"
+
+    resp = make_request("POST", f"{api_gateway_url}/user/blocks/{bob['login']}", headers=auth_headers(alice_token))
+    assert resp.status_code == 200, f"Ошибка блокировки: {resp.text}"
+    resp = make_request("GET", f"{api_gateway_url}/user/blocks", headers=auth_headers(alice_token))
+    assert [u["login"] for u in resp.json()["blocks"]] == [bob["login"]]
+
+    resp = make_request("GET", f"{api_gateway_url}/user/{alice['login']}/followers", headers=auth_headers(alice_token))
+    assert resp.json()["followers"] == [], "Блокировка должна удалить подписку"
+
+    post_url = f"{api_gateway_url}/posts/{post['id']}"
+    assert make_request("GET", post_url, headers=auth_headers(bob_token)).status_code == 403
+    assert make_request("POST", post_url + "/like", headers=auth_headers(bob_token)).status_code == 403
+    assert comment(api_gateway_url, bob_token, post["id"]).status_code == 403
+    assert make_request("GET", post_url + "/comments", headers=auth_headers(bob_token)).status_code == 403
+    replies_url = f"{post_url}/comments/{comment_id}/replies"
+    assert make_request("GET", replies_url, headers=auth_headers(bob_token)).status_code == 403
+    assert make_request("POST", post_url + "/view", headers=auth_headers(bob_token)).status_code == 403
+    assert make_request("DELETE", post_url + "/like", headers=auth_headers(bob_token)).status_code == 403
+    resp = make_request("POST", f"{api_gateway_url}/user/{alice['login']}/follow", headers=auth_headers(bob_token))
+    assert resp.status_code == 403, "Заблокированный не должен подписываться"
+    resp = make_request("POST", f"{api_gateway_url}/user/{bob['login']}/follow", headers=auth_headers(alice_token))
+    assert resp.status_code == 403, "Блокировка действует в обе стороны"
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/blocks/{bob['login']}", headers=auth_headers(alice_token))
+    assert resp.status_code == 200
+    assert make_request("GET", post_url, headers=auth_headers(bob_token)).status_code == 200
+
+
+async def test_blocked_users_comments_are_hidden(api_gateway_url, user_factory):
+    author_token, _ = user_factory()
+    alice_token, alice = user_factory()
+    bob_token, bob = user_factory()
+    post = create_post(api_gateway_url, author_token)
+    assert comment(api_gateway_url, bob_token, post["id"]).status_code == 201
+
+    make_request("POST", f"{api_gateway_url}/user/blocks/{alice['login']}", headers=auth_headers(bob_token))
+    assert list_comment_authors(api_gateway_url, alice_token, post["id"]) == [], \
+        "Комментарии заблокировавшего пользователя не должны быть видны"
+    assert list_comment_authors(api_gateway_url, author_token, post["id"]) == [bob["id"]]
+
+
+async def test_mute_hides_only_from_caller(api_gateway_url, user_factory):
+    alice_token, _ = user_factory()
+    bob_token, bob = user_factory()
+    carol_token, _ = user_factory()
+    post = create_post(api_gateway_url, bob_token)
+    assert comment(api_gateway_url, bob_token, post["id"]).status_code == 201
+
+    resp = make_request("POST", f"{api_gateway_url}/user/mutes/{bob['login']}", headers=auth_headers(alice_token))
+    assert resp.status_code == 200, f"Ошибка заглушения: {resp.text}"
+    resp = make_request("GET", f"{api_gateway_url}/user/mutes", headers=auth_headers(alice_token))
+    assert [u["login"] for u in resp.json()["mutes"]] == [bob["login"]]
+
+    url = f"{api_gateway_url}/posts/list/public/{bob['id']}"
+    assert make_request("GET", url, headers=auth_headers(alice_token)).json()["posts"] == []
+    assert [p["id"] for p in make_request("GET", url, headers=auth_headers(carol_token)).json()["posts"]] == [post["id"]]
+    assert list_comment_authors(api_gateway_url, alice_token, post["id"]) == []
+    assert list_comment_authors(api_gateway_url, carol_token, post["id"]) == [bob["id"]]
+
+    post_url = f"{api_gateway_url}/posts/{post['id']}"
+    assert make_request("GET", post_url, headers=auth_headers(alice_token)).status_code == 200, \
+        "Заглушение скрывает только из списков"
+    assert make_request("POST", post_url + "/like", headers=auth_headers(alice_token)).status_code == 200
+
+
+async def test_cannot_block_or_mute_self(api_gateway_url, login_user):
+    token, user_data = login_user
+    for kind in ("blocks", "mutes"):
+        resp = make_request("POST", f"{api_gateway_url}/user/{kind}/{user_data['login']}", headers=auth_headers(token))
+        assert resp.status_code == 400
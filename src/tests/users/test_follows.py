@@ -0,0 +1,62 @@
+from helpers.utils import auth_headers, make_request
+
+
+def follow(api_gateway_url, token, login, method="POST"):
+    return make_request(method, f"{api_gateway_url}/user/{login}/follow", headers=auth_headers(token))
+
+
+async def test_follow_and_unfollow(api_gateway_url, user_factory):
+    alice_token, alice = user_factory()
+    bob_token, bob = user_factory()
+
+    resp = follow(api_gateway_url, alice_token, bob["login"])
+    assert resp.status_code == 200, f"Ошибка подписки: {resp.text}"
+    assert follow(api_gateway_url, alice_token, bob["login"]).status_code == 200, "Повторная подписка должна быть идемпотентной"
+
+    resp = make_request("GET", f"{api_gateway_url}/user/{bob['login']}/followers", headers=auth_headers(bob_token))
+    assert resp.status_code == 200
+    followers = resp.json()["followers"]
+    assert [u["login"] for u in followers] == [alice["login"]]
+
+    resp = make_request("GET", f"{api_gateway_url}/user/{alice['login']}/following", headers=auth_headers(bob_token))
+    assert resp.status_code == 200
+    assert [u["id"] for u in resp.json()["following"]] == [bob["id"]]
+
+    resp = make_request("GET", f"{api_gateway_url}/user/{bob['login']}", headers=auth_headers(alice_token))
+    assert resp.json()["followersCount"] == 1
+    assert resp.json()["followingCount"] == 0
+    resp = make_request("GET", f"{api_gateway_url}/user/{alice['login']}", headers=auth_headers(alice_token))
+    assert resp.json()["followingCount"] == 1, "В полном профиле тоже должны быть счетчики"
+
+    resp = follow(api_gateway_url, alice_token, bob["login"], method="DELETE")
+    assert resp.status_code == 200, f"Ошибка отписки: {resp.text}"
+    resp = make_request("GET", f"{api_gateway_url}/user/{bob['login']}/followers", headers=auth_headers(bob_token))
+    assert resp.json()["followers"] == []
+
+
+async def test_cannot_follow_self(api_gateway_url, login_user):
+    token, user_data = login_user
+    assert follow(api_gateway_url, token, user_data["login"]).status_code == 400
+
+
+async def test_follow_unknown_user(api_gateway_url, login_user):
+    token, _ = login_user
+    assert follow(api_gateway_url, token, "nobody_at_all").status_code == 404
+
+
+async def test_followers_pagination(api_gateway_url, user_factory):
+    _, star = user_factory()
+    fans = [user_factory() for _ in range(3)]
+    for fan_token, _ in fans:
+        assert follow(api_gateway_url, fan_token, star["login"]).status_code == 200
+
+    url = f"{api_gateway_url}/user/{star['login']}/followers"
+    headers = auth_headers(fans[0][0])
+    first = make_request("GET", url, params={"page": 1, "page_size": 2}, headers=headers).json()["followers"]
+    second = make_request("GET", url, params={"page": 2, "page_size": 2}, headers=headers).json()["followers"]
+    assert len(first) == 2 and len(second) == 1
+    assert first[0]["login"] == fans[-1][1]["login"], "Сначала должны идти новые подписчики"
+    assert {u["login"] for u in first + second} == {fan["login"] for _, fan in fans}
+
+    resp = make_request("GET", url, params={"page_size": 0}, headers=headers)
+    assert resp.status_code == 400
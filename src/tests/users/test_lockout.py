@@ -0,0 +1,51 @@
+import uuid
+
+from helpers.utils import auth_headers, login_request, make_request
+
+
+def login(api_gateway_url, login, password):
+    return login_request(api_gateway_url, login, password)
+
+
+async def test_uniform_login_error(api_gateway_url, login_user):
+    _, user_data = login_user
+    wrong_password = login(api_gateway_url, user_data["login"], "WrongPass123")
+    unknown_login = login(api_gateway_url, f"nobody_{uuid.uuid4().hex[:8]}", "WrongPass123")
+    assert wrong_password.status_code == unknown_login.status_code == 400
+    assert wrong_password.json() == unknown_login.json(), "Ошибки не должны раскрывать, существует ли логин"
+
+
+async def test_lockout_and_admin_unlock(api_gateway_url, login_user, admin_user):
+    _, user_data = login_user
+    admin_token, _ = admin_user
+
+    for _ in range(5):
+        assert login(api_gateway_url, user_data["login"], "WrongPass123").status_code == 400
+
+    resp = login(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 429, "После 5 неудачных попыток логин должен блокироваться"
+    assert int(resp.headers["Retry-After"]) > 0
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/{user_data['login']}/lockout",
+                        headers=auth_headers(admin_token))
+    assert resp.status_code == 200, f"Ошибка разблокировки: {resp.text}"
+
+    resp = login(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200, f"После разблокировки вход должен работать: {resp.text}"
+
+
+async def test_successful_login_resets_failures(api_gateway_url, login_user):
+    _, user_data = login_user
+    for _ in range(4):
+        login(api_gateway_url, user_data["login"], "WrongPass123")
+    assert login(api_gateway_url, user_data["login"], user_data["password"]).status_code == 200
+    for _ in range(4):
+        login(api_gateway_url, user_data["login"], "WrongPass123")
+    assert login(api_gateway_url, user_data["login"], user_data["password"]).status_code == 200
+
+
+async def test_unlock_requires_admin(api_gateway_url, login_user):
+    token, user_data = login_user
+    resp = make_request("DELETE", f"{api_gateway_url}/user/{user_data['login']}/lockout",
+                        headers=auth_headers(token))
+    assert resp.status_code == 403
@@ -0,0 +1,101 @@
+import base64
+import hashlib
+import hmac
+import struct
+import time
+
+from helpers.utils import auth_headers, login_request, make_request
+
+
+def totp(secret, at=None):
+    key = base64.b32decode(secret + "=" * (-len(secret) % 8))
+    counter = int((time.time() if at is None else at) // 30)
+    digest = hmac.new(key, struct.pack(">Q", counter), hashlib.sha1).digest()
+    offset = digest[-1] & 0x0F
+    value = struct.unpack(">I", digest[offset:offset + 4])[0] & 0x7FFFFFFF
+    return f"{value % 10 ** 6:06d}"
+
+
+def enable_totp(api_gateway_url, token):
+    resp = make_request("POST", api_gateway_url + "/user/mfa/totp", headers=auth_headers(token))
+    assert resp.status_code == 201, f"Ошибка выдачи TOTP-секрета: {resp.text}"
+    secret = resp.json()["secret"]
+    assert resp.json()["otpauth_uri"].startswith("otpauth://totp/")
+
+    resp = make_request("POST", api_gateway_url + "/user/mfa/totp/confirm", data={"code": totp(secret)},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+    assert resp.status_code == 200, f"Ошибка подтверждения TOTP: {resp.text}"
+    codes = resp.json()["recovery_codes"]
+    assert len(codes) == 10
+    return secret, codes
+
+
+def complete(api_gateway_url, mfa_token, code):
+    return make_request("POST", api_gateway_url + "/user/login/mfa", data={"mfa_token": mfa_token, "code": code},
+                        headers={"Content-Type": "application/json"})
+
+
+def start_login(api_gateway_url, user_data):
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200, f"Ошибка логина: {resp.text}"
+    data = resp.json()
+    assert data.get("mfa_required") is True, "При включенной 2FA логин не должен сразу выдавать токены"
+    assert "token" not in data
+    return data["mfa_token"]
+
+
+async def test_confirm_requires_valid_code(api_gateway_url, login_user):
+    token, _ = login_user
+    resp = make_request("POST", api_gateway_url + "/user/mfa/totp", headers=auth_headers(token))
+    assert resp.status_code == 201
+    resp = make_request("POST", api_gateway_url + "/user/mfa/totp/confirm", data={"code": "000000"},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+    assert resp.status_code == 400, "Неверный код не должен включать 2FA"
+
+
+async def test_login_with_totp(api_gateway_url, login_user):
+    token, user_data = login_user
+    secret, _ = enable_totp(api_gateway_url, token)
+
+    mfa_token = start_login(api_gateway_url, user_data)
+    # Код текущего шага уже израсходован подтверждением, берем следующий.
+    code = totp(secret, time.time() + 30)
+    resp = complete(api_gateway_url, mfa_token, code)
+    assert resp.status_code == 200, f"Ошибка входа с кодом: {resp.text}"
+    new_token = resp.json()["token"]
+    resp = make_request("GET", f"{api_gateway_url}/user/{user_data['login']}", headers=auth_headers(new_token))
+    assert resp.status_code == 200
+
+    resp = complete(api_gateway_url, mfa_token, code)
+    assert resp.status_code == 401, "MFA-токен должен быть одноразовым"
+    resp = complete(api_gateway_url, start_login(api_gateway_url, user_data), code)
+    assert resp.status_code == 401, "Один и тот же TOTP-код нельзя использовать повторно"
+
+
+async def test_login_with_recovery_code(api_gateway_url, login_user):
+    token, user_data = login_user
+    _, codes = enable_totp(api_gateway_url, token)
+
+    resp = complete(api_gateway_url, start_login(api_gateway_url, user_data), codes[0].upper())
+    assert resp.status_code == 200, f"Ошибка входа по коду восстановления: {resp.text}"
+    resp = complete(api_gateway_url, start_login(api_gateway_url, user_data), codes[0])
+    assert resp.status_code == 401, "Код восстановления должен быть одноразовым"
+
+
+async def test_wrong_code_and_disable(api_gateway_url, login_user):
+    token, user_data = login_user
+    enable_totp(api_gateway_url, token)
+
+    resp = complete(api_gateway_url, start_login(api_gateway_url, user_data), "000000")
+    assert resp.status_code == 401
+
+    url = api_gateway_url + "/user/mfa/totp"
+    headers = {**auth_headers(token), "Content-Type": "application/json"}
+    resp = make_request("DELETE", url, data={"password": "WrongPass123"}, headers=headers)
+    assert resp.status_code == 403
+    resp = make_request("DELETE", url, data={"password": user_data["password"]}, headers=headers)
+    assert resp.status_code == 200, f"Ошибка отключения 2FA: {resp.text}"
+
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200
+    assert resp.json().get("token"), "После отключения 2FA логин должен сразу выдавать токены"
@@ -0,0 +1,81 @@
+import re
+import uuid
+
+from helpers.utils import auth_headers, last_mail, login_request, make_request
+
+
+def login(api_gateway_url, login, password):
+    return login_request(api_gateway_url, login, password)
+
+
+def change_password(api_gateway_url, token, login, old, new):
+    return make_request("PUT", f"{api_gateway_url}/user/{login}/password",
+                        data={"old_password": old, "new_password": new},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+
+
+async def test_change_password(api_gateway_url, login_user):
+    token, user_data = login_user
+    other = login(api_gateway_url, user_data["login"], user_data["password"]).json()
+
+    resp = change_password(api_gateway_url, token, user_data["login"], "WrongPass123", "NewPass1234")
+    assert resp.status_code == 403, "Смена пароля с неверным старым паролем должна быть отклонена"
+
+    resp = change_password(api_gateway_url, token, user_data["login"], user_data["password"], "NewPass1234")
+    assert resp.status_code == 200, f"Ошибка смены пароля: {resp.text}"
+    assert resp.json()["revoked_sessions"] >= 1
+
+    assert login(api_gateway_url, user_data["login"], user_data["password"]).status_code == 400
+    assert login(api_gateway_url, user_data["login"], "NewPass1234").status_code == 200
+
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(token))
+    assert resp.status_code == 200, "Текущая сессия должна остаться активной"
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(other["token"]))
+    assert resp.status_code == 401, "Остальные сессии должны быть отозваны"
+
+
+async def test_change_password_of_another_user(api_gateway_url, login_user, user_factory):
+    token, _ = login_user
+    _, other = user_factory()
+    resp = change_password(api_gateway_url, token, other["login"], other["password"], "NewPass1234")
+    assert resp.status_code == 401
+
+
+async def test_password_reset(api_gateway_url, login_user):
+    token, user_data = login_user
+
+    resp = make_request("POST", api_gateway_url + "/user/password/forgot", data={"email": user_data["email"]},
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 202, f"Ошибка запроса сброса пароля: {resp.text}"
+    mail = last_mail(user_data["email"], "Password reset")
+    reset_token = re.search(r"reset your password: (\S+)", mail["body"]).group(1)
+
+    reset = lambda new: make_request("POST", api_gateway_url + "/user/password/reset",
+                                     data={"token": reset_token, "new_password": new},
+                                     headers={"Content-Type": "application/json"})
+    resp = reset("weak")
+    assert resp.status_code == 400, "Слабый пароль должен быть отклонен"
+    resp = reset("ResetPass123")
+    assert resp.status_code == 200, f"Ошибка сброса пароля: {resp.text}"
+    assert reset("OtherPass123").status_code == 400, "Токен сброса должен быть одноразовым"
+
+    assert login(api_gateway_url, user_data["login"], "ResetPass123").status_code == 200
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(token))
+    assert resp.status_code == 401, "После сброса пароля все сессии должны быть отозваны"
+
+
+async def test_forgot_password_unknown_email(api_gateway_url):
+    resp = make_request("POST", api_gateway_url + "/user/password/forgot", data={"email": f"nobody_{uuid.uuid4().hex[:8]}@example.com"},
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 202, "Ответ не должен раскрывать, зарегистрирован ли email"
+
+
+async def test_forgot_password_rate_limit(api_gateway_url):
+    email = f"nobody_{uuid.uuid4().hex[:8]}@example.com"
+    forgot = lambda: make_request("POST", api_gateway_url + "/user/password/forgot", data={"email": email},
+                                  headers={"Content-Type": "application/json"})
+    for _ in range(3):
+        assert forgot().status_code == 202
+    resp = forgot()
+    assert resp.status_code == 429, f"Частые запросы сброса должны ограничиваться: {resp.text}"
+    assert int(resp.headers["Retry-After"]) > 0
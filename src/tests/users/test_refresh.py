@@ -0,0 +1,63 @@
+from helpers.utils import auth_headers, login_request, make_request
+
+
+def login(api_gateway_url, user_data):
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200, f"Ошибка логина: {resp.text}"
+    return resp.json()
+
+
+def refresh(api_gateway_url, refresh_token):
+    return make_request("POST", api_gateway_url + "/user/token/refresh",
+                        data={"refresh_token": refresh_token},
+                        headers={"Content-Type": "application/json"})
+
+
+async def test_login_returns_token_pair(api_gateway_url, login_user):
+    _, user_data = login_user
+    data = login(api_gateway_url, user_data)
+    assert data["token"]
+    assert data["refresh_token"]
+    assert data["expires_in"] == 180
+
+
+async def test_refresh_rotates_token(api_gateway_url, login_user):
+    _, user_data = login_user
+    first = login(api_gateway_url, user_data)
+
+    resp = refresh(api_gateway_url, first["refresh_token"])
+    assert resp.status_code == 200, f"Ошибка обновления токена: {resp.text}"
+    second = resp.json()
+    assert second["refresh_token"] != first["refresh_token"]
+    assert second["token"] != first["token"]
+
+    resp = make_request("GET", f"{api_gateway_url}/user/{user_data['login']}", headers=auth_headers(second["token"]))
+    assert resp.status_code == 200, f"Новый токен не работает: {resp.text}"
+
+    resp = refresh(api_gateway_url, second["refresh_token"])
+    assert resp.status_code == 200, f"Повторное обновление не удалось: {resp.text}"
+
+
+async def test_refresh_token_reuse_revokes_family(api_gateway_url, login_user):
+    _, user_data = login_user
+    first = login(api_gateway_url, user_data)
+    second = refresh(api_gateway_url, first["refresh_token"]).json()
+
+    resp = refresh(api_gateway_url, first["refresh_token"])
+    assert resp.status_code == 401, "Повторное использование refresh-токена должно быть отклонено"
+
+    resp = refresh(api_gateway_url, second["refresh_token"])
+    assert resp.status_code == 401, "После обнаружения повторного использования вся семья сессий должна быть отозвана"
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(second["token"]))
+    assert resp.status_code == 401, "Токен доступа отозванной сессии не должен работать"
+
+    other = login(api_gateway_url, user_data)
+    resp = refresh(api_gateway_url, other["refresh_token"])
+    assert resp.status_code == 200, "Другие входы пользователя не должны отзываться"
+
+
+async def test_refresh_with_unknown_token(api_gateway_url):
+    assert refresh(api_gateway_url, "not-a-refresh-token").status_code == 401
+    resp = make_request("POST", api_gateway_url + "/user/token/refresh", data={},
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 400
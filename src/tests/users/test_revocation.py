@@ -0,0 +1,37 @@
+from helpers.utils import auth_headers, make_request
+
+
+async def test_logout_revokes_token_everywhere(api_gateway_url, login_user):
+    token, user_data = login_user
+    url = api_gateway_url + "/posts/list/my"
+    assert make_request("GET", url, headers=auth_headers(token)).status_code == 200
+
+    resp = make_request("GET", api_gateway_url + "/user/logout", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка выхода: {resp.text}"
+
+    resp = make_request("GET", url, headers=auth_headers(token))
+    assert resp.status_code == 401, "После выхода токен не должен работать для /posts"
+    resp = make_request("GET", f"{api_gateway_url}/user/{user_data['login']}", headers=auth_headers(token))
+    assert resp.status_code == 401, "После выхода токен не должен работать для /user"
+
+
+async def test_account_deletion_revokes_token(api_gateway_url, user_factory):
+    token, user_data = user_factory()
+    url = api_gateway_url + "/posts/list/my"
+    assert make_request("GET", url, headers=auth_headers(token)).status_code == 200
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/{user_data['login']}", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка удаления: {resp.text}"
+
+    resp = make_request("GET", url, headers=auth_headers(token))
+    assert resp.status_code == 401, "После удаления аккаунта токен не должен работать"
+
+
+async def test_registration_token_is_usable(api_gateway_url, unique_user):
+    resp = make_request("POST", api_gateway_url + "/user", data=unique_user,
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 201, f"Ошибка регистрации: {resp.text}"
+    data = resp.json()
+    assert data["refresh_token"]
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(data["token"]))
+    assert resp.status_code == 200, f"Токен из регистрации не работает: {resp.text}"
@@ -0,0 +1,62 @@
+import base64
+import json
+
+from helpers.utils import auth_headers, login_request, make_request
+
+
+def token_roles(token):
+    payload = token.split(".")[1]
+    payload += "=" * (-len(payload) % 4)
+    return json.loads(base64.urlsafe_b64decode(payload)).get("roles")
+
+
+async def test_new_user_has_user_role(login_user):
+    token, _ = login_user
+    assert token_roles(token) == ["user"]
+
+
+async def test_admin_grants_and_revokes_role(api_gateway_url, admin_user, user_factory):
+    admin_token, admin_data = admin_user
+    assert "admin" in token_roles(admin_token)
+    _, user_data = user_factory()
+    url = api_gateway_url + f"/user/{user_data['login']}/roles"
+
+    resp = make_request("POST", url, data={"role": "moderator"},
+                        headers={**auth_headers(admin_token), "Content-Type": "application/json"})
+    assert resp.status_code == 200, f"Ошибка выдачи роли: {resp.text}"
+    roles = {r["role"]: r for r in resp.json()["roles"]}
+    assert set(roles) == {"user", "moderator"}
+    assert roles["moderator"]["assignedBy"] is not None
+    assert roles["user"]["assignedBy"] is None
+
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert sorted(token_roles(resp.json()["token"])) == ["moderator", "user"]
+
+    resp = make_request("DELETE", url + "/moderator", headers=auth_headers(admin_token))
+    assert resp.status_code == 200, f"Ошибка отзыва роли: {resp.text}"
+    assert [r["role"] for r in resp.json()["roles"]] == ["user"]
+
+    resp = make_request("DELETE", url + "/user", headers=auth_headers(admin_token))
+    assert resp.status_code == 400
+
+    resp = make_request("DELETE", api_gateway_url + f"/user/{admin_data['login']}/roles/admin",
+                        headers=auth_headers(admin_token))
+    assert resp.status_code == 400
+
+
+async def test_non_admin_cannot_manage_roles(api_gateway_url, user_factory):
+    token, user_data = user_factory()
+    url = api_gateway_url + f"/user/{user_data['login']}/roles"
+
+    resp = make_request("POST", url, data={"role": "admin"},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+    assert resp.status_code == 403
+    assert make_request("GET", url, headers=auth_headers(token)).status_code == 403
+
+
+async def test_grant_unknown_role(api_gateway_url, admin_user, user_factory):
+    admin_token, _ = admin_user
+    _, user_data = user_factory()
+    resp = make_request("POST", api_gateway_url + f"/user/{user_data['login']}/roles", data={"role": "root"},
+                        headers={**auth_headers(admin_token), "Content-Type": "application/json"})
+    assert resp.status_code == 400
@@ -0,0 +1,77 @@
+from helpers.utils import auth_headers, login_request, make_request
+
+
+def login(api_gateway_url, user_data):
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert resp.status_code == 200, f"Ошибка логина: {resp.text}"
+    return resp.json()
+
+
+def list_sessions(api_gateway_url, token):
+    resp = make_request("GET", api_gateway_url + "/user/sessions", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка получения сессий: {resp.text}"
+    return resp.json()["sessions"]
+
+
+async def test_list_sessions(api_gateway_url, login_user):
+    token, user_data = login_user
+    before = len(list_sessions(api_gateway_url, token))
+    other = login(api_gateway_url, user_data)
+
+    sessions = list_sessions(api_gateway_url, token)
+    assert len(sessions) == before + 1
+    assert [s["current"] for s in sessions].count(True) == 1
+    for s in sessions:
+        assert s["ipAddress"]
+        assert s["createdAt"] and s["expiresAt"]
+
+    # ротация refresh-токена не создает новую сессию
+    resp = make_request("POST", api_gateway_url + "/user/token/refresh",
+                        data={"refresh_token": other["refresh_token"]},
+                        headers={"Content-Type": "application/json"})
+    assert resp.status_code == 200
+    assert len(list_sessions(api_gateway_url, token)) == before + 1
+
+
+async def test_revoke_session(api_gateway_url, login_user):
+    token, user_data = login_user
+    other = login(api_gateway_url, user_data)
+    other_id = next(s["id"] for s in list_sessions(api_gateway_url, other["token"]) if s["current"])
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/sessions/{other_id}", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка отзыва сессии: {resp.text}"
+
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(other["token"]))
+    assert resp.status_code == 401, "Отозванная сессия не должна работать"
+    assert other_id not in [s["id"] for s in list_sessions(api_gateway_url, token)]
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/sessions/{other_id}", headers=auth_headers(token))
+    assert resp.status_code == 404
+
+
+async def test_revoke_session_of_another_user(api_gateway_url, login_user, user_factory):
+    token, _ = login_user
+    other_token, _ = user_factory()
+    other_id = list_sessions(api_gateway_url, other_token)[0]["id"]
+
+    resp = make_request("DELETE", f"{api_gateway_url}/user/sessions/{other_id}", headers=auth_headers(token))
+    assert resp.status_code == 404, "Нельзя отозвать чужую сессию"
+    resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(other_token))
+    assert resp.status_code == 200
+
+
+async def test_revoke_other_sessions(api_gateway_url, login_user):
+    token, user_data = login_user
+    first = login(api_gateway_url, user_data)
+    second = login(api_gateway_url, user_data)
+
+    resp = make_request("DELETE", api_gateway_url + "/user/sessions", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка выхода с других устройств: {resp.text}"
+    # регистрация тоже открывает сессию
+    assert resp.json()["revoked"] >= 2
+
+    for other in (first, second):
+        resp = make_request("GET", api_gateway_url + "/posts/list/my", headers=auth_headers(other["token"]))
+        assert resp.status_code == 401
+    sessions = list_sessions(api_gateway_url, token)
+    assert len(sessions) == 1 and sessions[0]["current"]
@@ -0,0 +1,73 @@
+import base64
+import json
+import re
+
+from helpers.utils import auth_headers, last_mail, login_request, make_request
+
+
+def verification_token(email):
+    mail = last_mail(email, "Verify your email")
+    return re.search(r"verify your email: (\S+)", mail["body"]).group(1)
+
+
+def verify(api_gateway_url, token):
+    return make_request("POST", api_gateway_url + "/user/verify-email", data={"token": token},
+                        headers={"Content-Type": "application/json"})
+
+
+def email_verified_claim(token):
+    payload = token.split(".")[1]
+    payload += "=" * (-len(payload) % 4)
+    return json.loads(base64.urlsafe_b64decode(payload))["email_verified"]
+
+
+def get_profile(api_gateway_url, token, login):
+    resp = make_request("GET", f"{api_gateway_url}/user/{login}", headers=auth_headers(token))
+    assert resp.status_code == 200, f"Ошибка получения профиля: {resp.text}"
+    return resp.json()
+
+
+async def test_verify_email_on_signup(api_gateway_url, login_user):
+    token, user_data = login_user
+    assert email_verified_claim(token) is False
+    assert "emailVerifiedAt" not in get_profile(api_gateway_url, token, user_data["login"])
+
+    code = verification_token(user_data["email"])
+    resp = verify(api_gateway_url, code)
+    assert resp.status_code == 200, f"Ошибка подтверждения email: {resp.text}"
+    assert verify(api_gateway_url, code).status_code == 400, "Токен подтверждения должен быть одноразовым"
+
+    assert get_profile(api_gateway_url, token, user_data["login"])["emailVerifiedAt"]
+    resp = login_request(api_gateway_url, user_data["login"], user_data["password"])
+    assert email_verified_claim(resp.json()["token"]) is True
+
+    resp = make_request("POST", api_gateway_url + "/user/verify-email/resend", headers=auth_headers(token))
+    assert resp.status_code == 400, "Повторная отправка для подтвержденного email должна быть отклонена"
+
+
+async def test_email_change_requires_new_verification(api_gateway_url, login_user):
+    token, user_data = login_user
+    old_code = verification_token(user_data["email"])
+
+    new_email = "new_" + user_data["email"]
+    resp = make_request("PUT", f"{api_gateway_url}/user/{user_data['login']}",
+                        data={"email": new_email, "firstname": user_data["firstname"], "surname": user_data["surname"]},
+                        headers={**auth_headers(token), "Content-Type": "application/json"})
+    assert resp.status_code == 200, f"Ошибка обновления профиля: {resp.text}"
+
+    assert verify(api_gateway_url, old_code).status_code == 400, "Токен для старого email не должен работать"
+    assert verify(api_gateway_url, verification_token(new_email)).status_code == 200
+    assert get_profile(api_gateway_url, token, user_data["login"])["emailVerifiedAt"]
+
+
+async def test_resend_verification(api_gateway_url, login_user):
+    token, user_data = login_user
+    first = verification_token(user_data["email"])
+
+    resp = make_request("POST", api_gateway_url + "/user/verify-email/resend", headers=auth_headers(token))
+    assert resp.status_code == 202, f"Ошибка повторной отправки: {resp.text}"
+    second = verification_token(user_data["email"])
+    assert second != first
+
+    assert verify(api_gateway_url, first).status_code == 400, "Предыдущий токен должен перестать работать"
+    assert verify(api_gateway_url, second).status_code == 200